@@ -0,0 +1,66 @@
+package core
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+const (
+	// AuthMetadataKey is gRPC metadata key carrying the token of the client
+	AuthMetadataKey = "authorization"
+	authScheme      = "Bearer "
+)
+
+// TokenCredentials attaches the token to every call of the control client;
+// use with grpc.WithPerRPCCredentials
+type TokenCredentials string
+
+func (t TokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	if t == "" {
+		return nil, nil
+	}
+	return map[string]string{AuthMetadataKey: authScheme + string(t)}, nil
+}
+
+// RequireTransportSecurity allows tokens over plain connections as the control
+// API is usually served inside the event's network
+func (t TokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// ParseToken extracts the token from the value of the authorization
+// metadata; both bare tokens and bearer scheme are accepted
+func ParseToken(value string) string {
+	if strings.HasPrefix(value, authScheme) {
+		return strings.TrimPrefix(value, authScheme)
+	}
+	return value
+}
+
+// SignAnnouncement computes the signature of the visual's announcement so
+// that the server knows it's been sent by the visual holding the token
+func SignAnnouncement(token string, announcement *pb.Announcement) []byte {
+	var (
+		mac    = hmac.New(sha256.New, []byte(token))
+		sentAt = make([]byte, 8)
+	)
+	binary.BigEndian.PutUint64(sentAt, uint64(announcement.SentAt))
+	mac.Write(announcement.Registration)
+	mac.Write(sentAt)
+	return mac.Sum(nil)
+}
+
+// ParseRole maps the role name given in any case to the role
+func ParseRole(name string) (pb.Role, error) {
+	role, ok := pb.Role_value[strings.ToUpper(name)]
+	if !ok {
+		return pb.Role_VIEWER, fmt.Errorf("role should be either viewer, starter or admin, not %s", name)
+	}
+	return pb.Role(role), nil
+}
@@ -0,0 +1,234 @@
+// Package certs issues certificates of a local CA and sets up TLS of the
+// gRPC connections between control clients, the server and the visuals
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kkoralsky/gosprints/core"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// file names of the generated certificates and keys
+const (
+	CAFile        = "ca.pem"
+	CAKeyFile     = "ca-key.pem"
+	ServerFile    = "server.pem"
+	ServerKeyFile = "server-key.pem"
+	VisualFile    = "visual.pem"
+	VisualKeyFile = "visual-key.pem"
+)
+
+// Generate creates local CA in the directory unless there is one already and
+// issues certificates of the server and the visuals signed by it
+func Generate(cfg core.CertsConfig) error {
+	var validFor = time.Duration(cfg.ValidDays) * 24 * time.Hour
+
+	if err := os.MkdirAll(cfg.Dir, 0700); err != nil {
+		return err
+	}
+	ca, caKey, err := loadCA(cfg.Dir)
+	if os.IsNotExist(err) {
+		if ca, caKey, err = newCA(cfg.Dir, validFor); err == nil {
+			core.InfoLogger.Printf("new CA created in %s", cfg.Dir)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	// server's certificate is presented to the visuals as well as visual's one
+	// to the server it registers with
+	err = issue(cfg.Dir, ServerFile, ServerKeyFile, "gosprints server", splitHosts(cfg.Hosts), ca, caKey,
+		validFor, x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth)
+	if err != nil {
+		return err
+	}
+	return issue(cfg.Dir, VisualFile, VisualKeyFile, "gosprints visual", splitHosts(cfg.VisualHosts), ca, caKey,
+		validFor, x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth)
+}
+
+func splitHosts(hosts string) (split []string) {
+	for _, host := range strings.Split(hosts, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			split = append(split, host)
+		}
+	}
+	return
+}
+
+func loadCA(dir string) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	pair, err := tls.LoadX509KeyPair(filepath.Join(dir, CAFile), filepath.Join(dir, CAKeyFile))
+	if err != nil {
+		if _, statErr := os.Stat(filepath.Join(dir, CAFile)); os.IsNotExist(statErr) {
+			return nil, nil, statErr
+		}
+		return nil, nil, err
+	}
+	ca, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	key, ok := pair.PrivateKey.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, nil, errors.New("CA key is not an ECDSA key")
+	}
+	return ca, key, nil
+}
+
+func newCA(dir string, validFor time.Duration) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	template, key, err := newTemplate("gosprints CA", validFor)
+	if err != nil {
+		return nil, nil, err
+	}
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = write(dir, CAFile, CAKeyFile, der, key); err != nil {
+		return nil, nil, err
+	}
+	ca, err := x509.ParseCertificate(der)
+	return ca, key, err
+}
+
+// issue writes certificate for the hosts signed by the CA
+func issue(dir, certFile, keyFile, name string, hosts []string, ca *x509.Certificate, caKey *ecdsa.PrivateKey,
+	validFor time.Duration, usage ...x509.ExtKeyUsage) error {
+	template, key, err := newTemplate(name, validFor)
+	if err != nil {
+		return err
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+	template.ExtKeyUsage = usage
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		return err
+	}
+	if err = write(dir, certFile, keyFile, der, key); err != nil {
+		return err
+	}
+	core.InfoLogger.Printf("%s issued for %s", certFile, strings.Join(hosts, ", "))
+	return nil
+}
+
+func newTemplate(name string, validFor time.Duration) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name, Organization: []string{"gosprints"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(validFor),
+	}, key, nil
+}
+
+func write(dir, certFile, keyFile string, der []byte, key *ecdsa.PrivateKey) error {
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, certFile),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, keyFile),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)
+}
+
+func loadPool(caFile string) (*x509.CertPool, error) {
+	b, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(b) {
+		return nil, fmt.Errorf("no certificates found in %s", caFile)
+	}
+	return pool, nil
+}
+
+// ServerConfig serves TLS with the certificate; with the CA given clients
+// have to present certificates signed by it. There's no config if there is no
+// certificate so that the server stays insecure.
+func ServerConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	if certFile == "" {
+		return nil, nil
+	}
+	pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	var tlsConfig = &tls.Config{Certificates: []tls.Certificate{pair}}
+	if caFile != "" {
+		if tlsConfig.ClientCAs, err = loadPool(caFile); err != nil {
+			return nil, err
+		}
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
+
+// ServerOptions serves gRPC with the config returned by ServerConfig
+func ServerOptions(tlsConfig *tls.Config) []grpc.ServerOption {
+	if tlsConfig == nil {
+		return nil
+	}
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(tlsConfig))}
+}
+
+// DialOption connects over TLS verifying the peer against the CA and
+// presents the certificate if given; connection is insecure without the CA
+func DialOption(caFile, certFile, keyFile string) (grpc.DialOption, error) {
+	if caFile == "" {
+		return grpc.WithInsecure(), nil
+	}
+	pool, err := loadPool(caFile)
+	if err != nil {
+		return nil, err
+	}
+	var tlsConfig = &tls.Config{RootCAs: pool}
+	if certFile != "" {
+		pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{pair}
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)), nil
+}
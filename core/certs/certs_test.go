@@ -0,0 +1,94 @@
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kkoralsky/gosprints/core"
+)
+
+func TestGenerate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gosprints-certs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cfg := core.CertsConfig{Dir: dir, Hosts: "localhost,127.0.0.1", VisualHosts: "vision", ValidDays: 1}
+	if err = Generate(cfg); err != nil {
+		t.Fatal(err)
+	}
+	ca, err := ioutil.ReadFile(filepath.Join(dir, CAFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// CA is reused on the next run so that issued certificates stay valid
+	if err = Generate(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := ioutil.ReadFile(filepath.Join(dir, CAFile)); string(again) != string(ca) {
+		t.Error("existing CA shouldn't be replaced")
+	}
+
+	pool, err := loadPool(filepath.Join(dir, CAFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := tls.LoadX509KeyPair(filepath.Join(dir, ServerFile), filepath.Join(dir, ServerKeyFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	visual, err := tls.LoadX509KeyPair(filepath.Join(dir, VisualFile), filepath.Join(dir, VisualKeyFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// server connecting to the visual with mutual TLS
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+	errs := make(chan error, 1)
+	go func() {
+		errs <- tls.Server(serverConn, &tls.Config{
+			Certificates: []tls.Certificate{visual},
+			ClientCAs:    pool,
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+		}).Handshake()
+	}()
+	err = tls.Client(clientConn, &tls.Config{
+		Certificates: []tls.Certificate{server},
+		RootCAs:      pool,
+		ServerName:   "vision",
+	}).Handshake()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = <-errs; err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(server.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = cert.VerifyHostname("127.0.0.1"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInsecureWithoutCertificates(t *testing.T) {
+	if tlsConfig, err := ServerConfig("", "", ""); err != nil || ServerOptions(tlsConfig) != nil {
+		t.Errorf("server without certificate should stay insecure: %v", err)
+	}
+	if _, err := DialOption("", "", ""); err != nil {
+		t.Error(err)
+	}
+	if _, err := DialOption("missing.pem", "", ""); err == nil {
+		t.Error("missing CA should be reported")
+	}
+}
@@ -19,11 +19,28 @@ type ServerConfig struct {
 	CountDownTime      uint
 	FailstartThreshold uint
 	Port               uint
+	HTTPPort           uint
+	DiscoveryPort      uint
+	DistFactor         uint
+	SplitDistance      uint
+	SplitTime          uint
+	TraceInterval      uint
+	DbBackups          uint
 	RaceMode           rune
 	raceMode           string
 	DbPath             string
+	DbBackend          string
 	InputDevice        string
 	OutputVisuals      string
+	AdminToken         string
+	StarterToken       string
+	ViewerToken        string
+	VisualToken        string
+	PairingRole        string
+	TLSCert            string
+	TLSKey             string
+	TLSCA              string
+	TLSClientAuth      bool
 	GrpcDebug          bool
 	Fullscreen         bool
 }
@@ -38,9 +55,66 @@ type VisualConfig struct {
 	Fullscreen       bool
 	ResolutionWidth  uint
 	ResolutionHeight uint
+	WebPort          uint
+	DiscoveryPort    uint
+	Server           string
+	Token            string
+	TLSCert          string
+	TLSKey           string
+	TLSCA            string
 	GrpcDebug        bool
 }
 
+// MigrateConfig is database migration configuration struct
+type MigrateConfig struct {
+	From        string
+	FromBackend string
+	To          string
+	ToBackend   string
+	DistFactor  uint
+}
+
+// ExportConfig is results export configuration struct
+type ExportConfig struct {
+	DbPath     string
+	DbBackend  string
+	Tournament string
+	Format     string
+	Out        string
+}
+
+// PdfConfig is printable result sheets & certificates configuration struct
+type PdfConfig struct {
+	DbPath       string
+	DbBackend    string
+	Tournament   string
+	Title        string
+	Date         string
+	Player       string
+	Out          string
+	DistFactor   uint
+	Certificates bool
+}
+
+// CertsConfig is configuration of the local CA and certificates generation
+type CertsConfig struct {
+	Dir         string
+	Hosts       string
+	VisualHosts string
+	ValidDays   uint
+}
+
+// ImportConfig is players & historic results import configuration struct
+type ImportConfig struct {
+	DbPath     string
+	DbBackend  string
+	Tournament string
+	Players    string
+	Results    string
+	Format     string
+	DryRun     bool
+}
+
 var (
 	defaultServerConfig = ServerConfig{
 		DestValue:          400,
@@ -48,10 +122,15 @@ var (
 		CountDownTime:      3000,
 		FailstartThreshold: 5,
 		Port:               9999,
+		DistFactor:         25 * 5, // 25cm * 5
+		SplitDistance:      100,
+		SplitTime:          1,
+		TraceInterval:      200,
 		RaceMode:           't',
 		InputDevice:        "SHM:5,6",
 		OutputVisuals:      "localhost:9998",
-		DbPath:             "sprints.pb",
+		DbBackups:          5,
+		DbBackend:          "pb",
 		GrpcDebug:          false,
 	}
 	defaultVisConfig = VisualConfig{
@@ -63,8 +142,30 @@ var (
 		Fullscreen:       false,
 		ResolutionWidth:  640,
 		ResolutionHeight: 480,
+		WebPort:          9996,
 		GrpcDebug:        false,
 	}
+	defaultMigrateConfig = MigrateConfig{
+		FromBackend: "pb",
+		ToBackend:   "bolt",
+	}
+	defaultExportConfig = ExportConfig{
+		DbBackend: "pb",
+		Format:    "csv",
+	}
+	defaultImportConfig = ImportConfig{
+		DbBackend: "pb",
+	}
+	defaultPdfConfig = PdfConfig{
+		DbBackend:  "pb",
+		DistFactor: 25 * 5,
+	}
+	defaultCertsConfig = CertsConfig{
+		Dir:         "certs",
+		Hosts:       "localhost,127.0.0.1",
+		VisualHosts: "localhost,127.0.0.1",
+		ValidDays:   825,
+	}
 )
 
 // Setup maps command line options into ServerConfig struct
@@ -85,14 +186,50 @@ func (s *ServerConfig) Setup() *flag.FlagSet {
 		"how many wheel turnovers is acceptable during countdown")
 	cfg.UintVar(&s.Port, "port", defaultServerConfig.Port,
 		"TCP port for remote race control")
+	cfg.UintVar(&s.HTTPPort, "http_port", defaultServerConfig.HTTPPort,
+		"TCP port of the web control panel and the JSON API described at /v1/openapi.json, e.g. 9997; "+
+			"0 disables")
+	cfg.UintVar(&s.DistFactor, "dist_factor", defaultServerConfig.DistFactor,
+		"roller circum in cm * sampling rate; used to compute distances and speeds")
+	cfg.UintVar(&s.SplitDistance, "split_distance", defaultServerConfig.SplitDistance,
+		"interval in meters of split times taken in distance constrained races; 0 disables")
+	cfg.UintVar(&s.SplitTime, "split_time", defaultServerConfig.SplitTime,
+		"interval in seconds of split distances taken in time constrained races; 0 disables")
+	cfg.UintVar(&s.TraceInterval, "trace_interval", defaultServerConfig.TraceInterval,
+		"interval in miliseconds of speed trace samples; 0 disables")
 	cfg.StringVar(&s.raceMode, "race_mode", string(defaultServerConfig.RaceMode),
 		"race mode: either t for time constrained race or d for distance constrained")
 	cfg.StringVar(&s.InputDevice, "input_device", defaultServerConfig.InputDevice,
 		"in the form: <type>:<device1_spec>,<device2_spec>,...")
 	cfg.StringVar(&s.DbPath, "db_path", defaultServerConfig.DbPath,
-		"file where to load & save tournament data")
+		"file where to load & save tournament data; sprints.pb or sprints.db depending on -db_backend if empty")
+	cfg.UintVar(&s.DbBackups, "db_backups", defaultServerConfig.DbBackups,
+		"how many rotating backups of the -db_path file to keep")
+	cfg.StringVar(&s.DbBackend, "db_backend", defaultServerConfig.DbBackend,
+		"database backend: either pb for protobuf file or bolt for embedded key/value database")
 	cfg.StringVar(&s.OutputVisuals, "visuals", defaultServerConfig.OutputVisuals,
-		"comma seperated output visual addresses ie. ip:port,hostname:port etc.")
+		"comma seperated output visual addresses ie. ip:port,hostname:port etc.; more can register later")
+	cfg.UintVar(&s.DiscoveryPort, "discovery_port", defaultServerConfig.DiscoveryPort,
+		"UDP port to listen on for visuals announcing themselves within the LAN; 0 disables; "+
+			"with auth enabled the announcements have to be signed with the visual or admin token")
+	cfg.StringVar(&s.AdminToken, "admin_token", defaultServerConfig.AdminToken,
+		"pre-shared token of control clients allowed to manage tournaments and configuration")
+	cfg.StringVar(&s.StarterToken, "starter_token", defaultServerConfig.StarterToken,
+		"pre-shared token of control clients allowed to run races")
+	cfg.StringVar(&s.ViewerToken, "viewer_token", defaultServerConfig.ViewerToken,
+		"pre-shared token of control clients allowed to watch results")
+	cfg.StringVar(&s.VisualToken, "visual_token", defaultServerConfig.VisualToken,
+		"pre-shared token of visuals allowed to register themselves and nothing else")
+	cfg.StringVar(&s.PairingRole, "pairing_role", defaultServerConfig.PairingRole,
+		"role granted to clients paired with the code shown on visuals: viewer, starter or admin; empty disables pairing")
+	cfg.StringVar(&s.TLSCert, "tls_cert", defaultServerConfig.TLSCert,
+		"certificate of the server; enables TLS of the control port and is presented to visuals")
+	cfg.StringVar(&s.TLSKey, "tls_key", defaultServerConfig.TLSKey,
+		"private key of the -tls_cert")
+	cfg.StringVar(&s.TLSCA, "tls_ca", defaultServerConfig.TLSCA,
+		"CA certificate; enables TLS of the connections to visuals which are verified against it")
+	cfg.BoolVar(&s.TLSClientAuth, "tls_client_auth", defaultServerConfig.TLSClientAuth,
+		"require control clients to present certificates signed by the -tls_ca")
 	cfg.BoolVar(&s.GrpcDebug, "grpc_debug", defaultServerConfig.GrpcDebug,
 		"run GRPC server in debug mode")
 	cfg.BoolVar(&s.Fullscreen, "fullscreen", defaultServerConfig.Fullscreen,
@@ -117,6 +254,95 @@ func (s *ServerConfig) Validate() (errs []error) {
 		ErrorLogger.Println(err)
 	}
 
+	if err = validateDb(s.DbBackend, &s.DbPath); err != nil {
+		errs = append(errs, err)
+		ErrorLogger.Println(err)
+	}
+
+	if s.PairingRole != "" {
+		if _, err = ParseRole(s.PairingRole); err != nil {
+			err = fmt.Errorf("pairing %v", err)
+			errs = append(errs, err)
+			ErrorLogger.Println(err)
+		}
+	}
+
+	if err = validateTLS(s.TLSCert, s.TLSKey); err != nil {
+		errs = append(errs, err)
+		ErrorLogger.Println(err)
+	}
+	if s.TLSClientAuth && (s.TLSCA == "" || s.TLSCert == "") {
+		err = errors.New("-tls_client_auth needs both -tls_ca and -tls_cert")
+		errs = append(errs, err)
+		ErrorLogger.Println(err)
+	}
+
+	return
+}
+
+func validateTLS(cert, key string) error {
+	if (cert == "") != (key == "") {
+		return errors.New("both -tls_cert and -tls_key should be given")
+	}
+	return nil
+}
+
+// dbPaths are default database files of the backends
+var dbPaths = map[string]string{
+	"pb":   "sprints.pb",
+	"bolt": "sprints.db",
+}
+
+// validateDb checks the backend and names the file after it unless given
+func validateDb(backend string, path *string) error {
+	defaultPath, ok := dbPaths[backend]
+	if !ok {
+		return fmt.Errorf("db backend should be either pb or bolt, not %s", backend)
+	}
+	if *path == "" {
+		*path = defaultPath
+	}
+	return nil
+}
+
+// Setup maps command line options into MigrateConfig struct
+func (m *MigrateConfig) Setup() *flag.FlagSet {
+	cfg := flag.NewFlagSet("migrate", flag.ExitOnError)
+	cfg.Usage = func() {
+		fmt.Printf("\nmigrate configuration\n")
+		cfg.PrintDefaults()
+	}
+	cfg.StringVar(&m.From, "from", defaultMigrateConfig.From,
+		"database file to copy tournaments from; named after -from_backend if empty")
+	cfg.StringVar(&m.FromBackend, "from_backend", defaultMigrateConfig.FromBackend,
+		"backend of the -from database: either pb or bolt")
+	cfg.StringVar(&m.To, "to", defaultMigrateConfig.To,
+		"database file to copy tournaments to; named after -to_backend if empty")
+	cfg.StringVar(&m.ToBackend, "to_backend", defaultMigrateConfig.ToBackend,
+		"backend of the -to database: either pb or bolt")
+	cfg.UintVar(&m.DistFactor, "dist_factor", defaultMigrateConfig.DistFactor,
+		"roller circum in cm * sampling rate the results of time constrained tournaments were "+
+			"recorded with; converts them from device units into meters, 0 leaves them as they are")
+
+	return cfg
+}
+
+// Validate validates whether migrate configuration is correct
+func (m *MigrateConfig) Validate() (errs []error) {
+	for _, db := range []struct {
+		backend string
+		path    *string
+	}{{m.FromBackend, &m.From}, {m.ToBackend, &m.To}} {
+		if err := validateDb(db.backend, db.path); err != nil {
+			errs = append(errs, err)
+			ErrorLogger.Println(err)
+		}
+	}
+	if m.From == m.To {
+		err := errors.New("-from and -to should be different files")
+		errs = append(errs, err)
+		ErrorLogger.Println(err)
+	}
 	return
 }
 
@@ -138,7 +364,7 @@ func (c *VisualConfig) Setup() *flag.FlagSet {
 	cfg.StringVar(&c.HostName, "name", hostName,
 		"vision instance identification")
 	cfg.StringVar(&c.VisName, "vis_name", defaultVisConfig.VisName,
-		"visual name")
+		"visual name: bar, clock, clock2, game or web (served to browsers on -web_port)")
 	cfg.UintVar(&c.MovingUnit, "moving_unit", defaultVisConfig.MovingUnit,
 		"how many pixels to move in animation on one -sampling_rate")
 	cfg.BoolVar(&c.Fullscreen, "fullscreen", defaultVisConfig.Fullscreen,
@@ -147,12 +373,196 @@ func (c *VisualConfig) Setup() *flag.FlagSet {
 		"visualisation window/screen width in pixels")
 	cfg.UintVar(&c.ResolutionHeight, "height", defaultVisConfig.ResolutionHeight,
 		"visualization window/screen height in pixels")
+	cfg.UintVar(&c.WebPort, "web_port", defaultVisConfig.WebPort,
+		"TCP port of the page served to browsers by the \"web\" visual")
+	cfg.StringVar(&c.Server, "server", defaultVisConfig.Server,
+		"address of the server to register with so that it doesn't need to know the visual in advance")
+	cfg.StringVar(&c.Token, "token", defaultVisConfig.Token,
+		"visual token (as in server) to register with the -server and sign the announcements")
+	cfg.UintVar(&c.DiscoveryPort, "discovery_port", defaultVisConfig.DiscoveryPort,
+		"UDP port to broadcast the visual's registration to within the LAN (as in server); 0 disables")
+	cfg.StringVar(&c.TLSCert, "tls_cert", defaultVisConfig.TLSCert,
+		"certificate of the visual; enables TLS")
+	cfg.StringVar(&c.TLSKey, "tls_key", defaultVisConfig.TLSKey,
+		"private key of the -tls_cert")
+	cfg.StringVar(&c.TLSCA, "tls_ca", defaultVisConfig.TLSCA,
+		"CA certificate; only the server presenting certificate signed by it is let in")
 	cfg.BoolVar(&c.GrpcDebug, "grpc_debug", defaultVisConfig.GrpcDebug,
 		"run GRPC server in debug mode")
 
 	return cfg
 }
 
+// Validate validates whether visual configuration is correct
+func (c *VisualConfig) Validate() (errs []error) {
+	if err := validateTLS(c.TLSCert, c.TLSKey); err != nil {
+		errs = append(errs, err)
+		ErrorLogger.Println(err)
+	}
+	if c.TLSCA != "" && c.TLSCert == "" {
+		err := errors.New("-tls_ca needs -tls_cert as well")
+		errs = append(errs, err)
+		ErrorLogger.Println(err)
+	}
+	if c.Token != "" && c.Server == "" && c.DiscoveryPort == 0 {
+		err := errors.New("-token is used with -server or -discovery_port only")
+		errs = append(errs, err)
+		ErrorLogger.Println(err)
+	}
+	return
+}
+
+// Setup maps command line options into ExportConfig struct
+func (e *ExportConfig) Setup() *flag.FlagSet {
+	cfg := flag.NewFlagSet("export", flag.ExitOnError)
+	cfg.Usage = func() {
+		fmt.Printf("\nexport configuration\n")
+		cfg.PrintDefaults()
+	}
+	cfg.StringVar(&e.DbPath, "db_path", defaultExportConfig.DbPath,
+		"file where tournament data is stored; sprints.pb or sprints.db depending on -db_backend if empty")
+	cfg.StringVar(&e.DbBackend, "db_backend", defaultExportConfig.DbBackend,
+		"database backend: either pb or bolt")
+	cfg.StringVar(&e.Tournament, "tournament", defaultExportConfig.Tournament,
+		"name of the tournament to export; the last one if empty")
+	cfg.StringVar(&e.Format, "format", defaultExportConfig.Format,
+		"export format: csv, json or xlsx")
+	cfg.StringVar(&e.Out, "out", defaultExportConfig.Out,
+		"output file; - for standard output, named after the tournament if empty")
+
+	return cfg
+}
+
+// Validate validates whether export configuration is correct
+func (e *ExportConfig) Validate() (errs []error) {
+	if err := validateDb(e.DbBackend, &e.DbPath); err != nil {
+		errs = append(errs, err)
+		ErrorLogger.Println(err)
+	}
+	return
+}
+
+// Setup maps command line options into PdfConfig struct
+func (p *PdfConfig) Setup() *flag.FlagSet {
+	cfg := flag.NewFlagSet("pdf", flag.ExitOnError)
+	cfg.Usage = func() {
+		fmt.Printf("\npdf configuration\n")
+		cfg.PrintDefaults()
+	}
+	cfg.StringVar(&p.DbPath, "db_path", defaultPdfConfig.DbPath,
+		"file where tournament data is stored; sprints.pb or sprints.db depending on -db_backend if empty")
+	cfg.StringVar(&p.DbBackend, "db_backend", defaultPdfConfig.DbBackend,
+		"database backend: either pb or bolt")
+	cfg.StringVar(&p.Tournament, "tournament", defaultPdfConfig.Tournament,
+		"name of the tournament to print; the last one if empty")
+	cfg.StringVar(&p.Title, "title", defaultPdfConfig.Title,
+		"event title printed on top of every page; tournament name if empty")
+	cfg.StringVar(&p.Date, "date", defaultPdfConfig.Date,
+		"event date; today if empty")
+	cfg.BoolVar(&p.Certificates, "certificates", defaultPdfConfig.Certificates,
+		"print certificates instead of result sheet")
+	cfg.StringVar(&p.Player, "player", defaultPdfConfig.Player,
+		"print certificate of the given player only")
+	cfg.UintVar(&p.DistFactor, "dist_factor", defaultPdfConfig.DistFactor,
+		"roller circum in cm * sampling rate (as in server)")
+	cfg.StringVar(&p.Out, "out", defaultPdfConfig.Out,
+		"output file; - for standard output, named after the tournament if empty")
+
+	return cfg
+}
+
+// Validate validates whether pdf configuration is correct
+func (p *PdfConfig) Validate() (errs []error) {
+	if err := validateDb(p.DbBackend, &p.DbPath); err != nil {
+		errs = append(errs, err)
+		ErrorLogger.Println(err)
+	}
+	if p.Player != "" && !p.Certificates {
+		err := errors.New("-player is allowed only along with -certificates")
+		errs = append(errs, err)
+		ErrorLogger.Println(err)
+	}
+	return
+}
+
+// Setup maps command line options into ImportConfig struct
+func (i *ImportConfig) Setup() *flag.FlagSet {
+	cfg := flag.NewFlagSet("import", flag.ExitOnError)
+	cfg.Usage = func() {
+		fmt.Printf("\nimport configuration\n")
+		fmt.Printf("refused while the server uses the db; import through the server API then\n")
+		cfg.PrintDefaults()
+	}
+	cfg.StringVar(&i.DbPath, "db_path", defaultImportConfig.DbPath,
+		"file where tournament data is stored; sprints.pb or sprints.db depending on -db_backend if empty")
+	cfg.StringVar(&i.DbBackend, "db_backend", defaultImportConfig.DbBackend,
+		"database backend: either pb or bolt")
+	cfg.StringVar(&i.Tournament, "tournament", defaultImportConfig.Tournament,
+		"name of the tournament to import into; the last one if empty")
+	cfg.StringVar(&i.Players, "players", defaultImportConfig.Players,
+		"file with players to register: name, gender & category")
+	cfg.StringVar(&i.Results, "results", defaultImportConfig.Results,
+		"file with historic results: name, gender, result, unit & destValue")
+	cfg.StringVar(&i.Format, "format", defaultImportConfig.Format,
+		"format of the files: csv or json; guessed from extension if empty")
+	cfg.BoolVar(&i.DryRun, "dry_run", defaultImportConfig.DryRun,
+		"only validate the files and report what would be imported")
+
+	return cfg
+}
+
+// Validate validates whether import configuration is correct
+func (i *ImportConfig) Validate() (errs []error) {
+	if err := validateDb(i.DbBackend, &i.DbPath); err != nil {
+		errs = append(errs, err)
+		ErrorLogger.Println(err)
+	}
+	if i.Players == "" && i.Results == "" {
+		err := errors.New("either -players or -results file should be given")
+		errs = append(errs, err)
+		ErrorLogger.Println(err)
+	}
+	return
+}
+
+// Setup maps command line options into CertsConfig struct
+func (c *CertsConfig) Setup() *flag.FlagSet {
+	hosts := defaultCertsConfig.Hosts
+	if hostName, err := os.Hostname(); err == nil {
+		hosts += "," + hostName
+	}
+	cfg := flag.NewFlagSet("certs", flag.ExitOnError)
+	cfg.Usage = func() {
+		fmt.Printf("\ncerts configuration\n")
+		cfg.PrintDefaults()
+	}
+	cfg.StringVar(&c.Dir, "dir", defaultCertsConfig.Dir,
+		"directory to write the certificates into; CA found there is reused")
+	cfg.StringVar(&c.Hosts, "hosts", hosts,
+		"comma separated host names & IPs the server is reached at")
+	cfg.StringVar(&c.VisualHosts, "visual_hosts", defaultCertsConfig.VisualHosts,
+		"comma separated host names & IPs of the visuals as given in server's -visuals")
+	cfg.UintVar(&c.ValidDays, "valid_days", defaultCertsConfig.ValidDays,
+		"how many days the certificates are valid for")
+
+	return cfg
+}
+
+// Validate validates whether certs configuration is correct
+func (c *CertsConfig) Validate() (errs []error) {
+	if c.Dir == "" {
+		err := errors.New("-dir should be given")
+		errs = append(errs, err)
+		ErrorLogger.Println(err)
+	}
+	if c.ValidDays == 0 {
+		err := errors.New("-valid_days should be positive")
+		errs = append(errs, err)
+		ErrorLogger.Println(err)
+	}
+	return
+}
+
 // FlagsetParse parses flags and prints usage if no options are given
 func FlagsetParse(flagset *flag.FlagSet, args []string, argsValidation func() []error) {
 	flagset.Parse(args)
@@ -14,6 +14,7 @@ type InputDevice interface {
 	Clean() error                                                 // resets players distance to 0
 	Check() (int, error)                                          // checks if any player has exceeded the falseStart distance
 	Close() error                                                 // performs cleanups: closes all devices, files etc.
+	Type() string                                                 // returns device type as given in configuration
 }
 
 // SetupDevice parses device configuration string and returns proper InputDevice interface
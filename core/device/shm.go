@@ -209,6 +209,11 @@ func (s *ShmReader) Check() (int, error) {
 	return -1, nil
 }
 
+// Type returns SHM
+func (s *ShmReader) Type() string {
+	return "SHM"
+}
+
 // Close terminates counter companion program and closes all SHM files
 func (s *ShmReader) Close() error {
 	var errs []string
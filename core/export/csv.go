@@ -0,0 +1,37 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+var csvHeader = []string{"place", "name", "gender", "category", "result", "unit", "destValue"}
+
+func writeCSV(w io.Writer, tournament *pb.Tournament) error {
+	var cw = csv.NewWriter(w)
+
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, r := range rankings(tournament) {
+		for _, row := range Rows(tournament, r.gender, r.category) {
+			err := cw.Write([]string{
+				fmt.Sprint(row.Place),
+				row.Name,
+				row.Gender,
+				row.Category,
+				fmt.Sprintf("%.3f", row.Result),
+				row.Unit,
+				fmt.Sprint(row.DestValue),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,124 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/kkoralsky/gosprints/core"
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+type exporter struct {
+	write    func(w io.Writer, tournament *pb.Tournament) error
+	mimeType string
+}
+
+var exporters = map[string]exporter{
+	"csv":  {write: writeCSV, mimeType: "text/csv"},
+	"json": {write: writeJSON, mimeType: "application/json"},
+	"xlsx": {write: writeXLSX, mimeType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+}
+
+// Row is a single ranked result as it gets exported; Category is the player
+// category the place is ranked within, empty in the overall ranking
+type Row struct {
+	Place     int     `json:"place"`
+	Name      string  `json:"name"`
+	Gender    string  `json:"gender"`
+	Category  string  `json:"category,omitempty"`
+	Result    float32 `json:"result"`
+	Unit      string  `json:"unit"`
+	DestValue uint32  `json:"destValue"`
+}
+
+// ranking groups results which are placed together: all the results of the
+// gender or the ones of players in the category only
+type ranking struct {
+	gender   pb.Gender
+	category string
+}
+
+func (r ranking) String() string {
+	if r.category == "" {
+		return r.gender.String()
+	}
+	return fmt.Sprintf("%s %s", r.gender, r.category)
+}
+
+// rankings returns the overall ranking of every gender followed by rankings
+// of its player categories
+func rankings(tournament *pb.Tournament) (r []ranking) {
+	for _, gender := range core.Genders {
+		r = append(r, ranking{gender: gender})
+	}
+	for _, gender := range core.Genders {
+		for _, category := range core.Categories(tournament, gender) {
+			r = append(r, ranking{gender: gender, category: category})
+		}
+	}
+	return
+}
+
+// Formats returns names of all the supported formats
+func Formats() (formats []string) {
+	for format := range exporters {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return
+}
+
+func MimeType(format string) string {
+	return exporters[format].mimeType
+}
+
+// FileName suggests name of the file exported from the tournament
+func FileName(tournament *pb.Tournament, format string) string {
+	var name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>| `, r) {
+			return '_'
+		}
+		return r
+	}, tournament.Name)
+	return fmt.Sprintf("%s.%s", name, format)
+}
+
+// Write renders results of the tournament in the given format
+func Write(w io.Writer, tournament *pb.Tournament, format string) error {
+	exp, ok := exporters[format]
+	if !ok {
+		return fmt.Errorf("unknown export format: %s; should be one of: %s", format,
+			strings.Join(Formats(), ", "))
+	}
+	return exp.write(w, tournament)
+}
+
+// Rows returns ranked results of the given gender, of the players in the
+// category only if it's given, sorted the same way as Sprints.GetResults does
+func Rows(tournament *pb.Tournament, gender pb.Gender, category string) []Row {
+	var rows = []Row{}
+	for i, result := range core.RankedResults(tournament, gender, category) {
+		value, unit := resultValue(tournament.Mode, result.Result)
+		rows = append(rows, Row{
+			Place:     i + 1,
+			Name:      result.Player.Name,
+			Gender:    result.Player.Gender.String(),
+			Category:  category,
+			Result:    value,
+			Unit:      unit,
+			DestValue: result.DestValue,
+		})
+	}
+	return rows
+}
+
+// resultValue converts stored result into the value presented to people:
+// miliseconds into seconds for distance constrained and relay races
+func resultValue(mode pb.Tournament_TournamentMode, result float32) (float32, string) {
+	if mode != pb.Tournament_TIME {
+		return result / 1000, "s"
+	}
+	return result, "m"
+}
@@ -0,0 +1,188 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+var testTournament = &pb.Tournament{
+	Name:      "test tournament",
+	Mode:      pb.Tournament_DISTANCE,
+	DestValue: 400,
+	Result: []*pb.Result{
+		{Player: &pb.Player{Name: "slow", Gender: pb.Gender_MALE}, Result: 30500, DestValue: 400},
+		{Player: &pb.Player{Name: "fast", Gender: pb.Gender_MALE}, Result: 20250, DestValue: 400},
+		{Player: &pb.Player{Name: "cheater", Gender: pb.Gender_MALE}, Result: 100, DestValue: 400,
+			Disqualified: true},
+		{Player: &pb.Player{Name: "she", Gender: pb.Gender_FEMALE}, Result: 25000, DestValue: 400},
+	},
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, testTournament, "csv"); err != nil {
+		t.Fatal(err)
+	}
+	expected := `place,name,gender,category,result,unit,destValue
+1,fast,MALE,,20.250,s,400
+2,slow,MALE,,30.500,s,400
+1,she,FEMALE,,25.000,s,400
+`
+	if buf.String() != expected {
+		t.Errorf("unexpected csv:\n%s", buf.String())
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var (
+		buf    bytes.Buffer
+		parsed jsonTournament
+	)
+	if err := Write(&buf, testTournament, "json"); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed.Results["MALE"]) != 2 || parsed.Results["MALE"][0].Name != "fast" {
+		t.Errorf("unexpected male results: %v", parsed.Results["MALE"])
+	}
+	if len(parsed.Results["OTHER"]) != 0 {
+		t.Errorf("there should be no other results: %v", parsed.Results["OTHER"])
+	}
+}
+
+func TestWriteXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, testTournament, "xlsx"); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(zr.File) != 7 {
+		t.Errorf("there should be 7 files in the package, not %d", len(zr.File))
+	}
+	for _, f := range zr.File {
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			var sheet bytes.Buffer
+			r, _ := f.Open()
+			sheet.ReadFrom(r)
+			if !strings.Contains(sheet.String(), "<t>fast</t>") {
+				t.Errorf("fast should be in the first sheet: %s", sheet.String())
+			}
+		}
+	}
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	if err := Write(&bytes.Buffer{}, testTournament, "doc"); err == nil {
+		t.Error("unknown format should fail")
+	}
+}
+
+var testCategoryTournament = &pb.Tournament{
+	Name:      "category tournament",
+	Mode:      pb.Tournament_DISTANCE,
+	DestValue: 400,
+	Result: []*pb.Result{
+		{Player: &pb.Player{Name: "senior", Gender: pb.Gender_MALE}, Result: 20000, DestValue: 400},
+		{Player: &pb.Player{Name: "slow junior", Gender: pb.Gender_MALE, Category: "junior"}, Result: 27000,
+			DestValue: 400},
+		{Player: &pb.Player{Name: "fast junior", Gender: pb.Gender_MALE, Category: "junior"}, Result: 22000,
+			DestValue: 400},
+		{Player: &pb.Player{Name: "veteran", Gender: pb.Gender_MALE, Category: "masters & co"}, Result: 25000,
+			DestValue: 400},
+		{Player: &pb.Player{Name: "she", Gender: pb.Gender_FEMALE, Category: "junior"}, Result: 26000,
+			DestValue: 400},
+	},
+}
+
+func TestWriteCSVCategories(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, testCategoryTournament, "csv"); err != nil {
+		t.Fatal(err)
+	}
+	expected := `place,name,gender,category,result,unit,destValue
+1,senior,MALE,,20.000,s,400
+2,fast junior,MALE,,22.000,s,400
+3,veteran,MALE,,25.000,s,400
+4,slow junior,MALE,,27.000,s,400
+1,she,FEMALE,,26.000,s,400
+1,fast junior,MALE,junior,22.000,s,400
+2,slow junior,MALE,junior,27.000,s,400
+1,veteran,MALE,masters & co,25.000,s,400
+1,she,FEMALE,junior,26.000,s,400
+`
+	if buf.String() != expected {
+		t.Errorf("unexpected csv:\n%s", buf.String())
+	}
+}
+
+func TestWriteJSONCategories(t *testing.T) {
+	var (
+		buf    bytes.Buffer
+		parsed jsonTournament
+	)
+	if err := Write(&buf, testCategoryTournament, "json"); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed.Results["MALE"]) != 4 {
+		t.Errorf("overall ranking should have all the results: %v", parsed.Results["MALE"])
+	}
+	juniors := parsed.Categories["MALE"]["junior"]
+	if len(juniors) != 2 || juniors[0].Name != "fast junior" || juniors[0].Place != 1 || juniors[0].Category != "junior" {
+		t.Errorf("unexpected male juniors: %v", juniors)
+	}
+	if len(parsed.Categories["FEMALE"]["junior"]) != 1 || parsed.Categories["OTHER"] != nil {
+		t.Errorf("unexpected categories: %v", parsed.Categories)
+	}
+}
+
+func TestWriteXLSXCategories(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, testCategoryTournament, "xlsx"); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 3 genders and 3 categories
+	if len(zr.File) != 10 {
+		t.Errorf("there should be 10 files in the package, not %d", len(zr.File))
+	}
+	for _, f := range zr.File {
+		if f.Name == "xl/workbook.xml" {
+			var workbook bytes.Buffer
+			r, _ := f.Open()
+			workbook.ReadFrom(r)
+			if !strings.Contains(workbook.String(), `name="MALE masters &amp; co"`) {
+				t.Errorf("category sheet should be named after it: %s", workbook.String())
+			}
+		}
+	}
+}
+
+func TestXLSXSheetNameUnique(t *testing.T) {
+	var used = make(map[string]bool)
+	for _, c := range []struct{ name, sheet string }{
+		{"MALE veterans over fifty from abroad", "MALE veterans over fifty from a"},
+		{"male veterans over fifty from around", "male veterans over fifty fr (2)"},
+		{"MALE veterans over fifty from a", "MALE veterans over fifty fr (3)"},
+		{"FEMALE", "FEMALE"},
+	} {
+		if sheet := xlsxSheetName(c.name, used); sheet != c.sheet {
+			t.Errorf("sheet of %q should be named %q, not %q", c.name, c.sheet, sheet)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/kkoralsky/gosprints/core"
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+type jsonTournament struct {
+	Name      string           `json:"name"`
+	Mode      string           `json:"mode"`
+	DestValue uint32           `json:"destValue"`
+	Results   map[string][]Row `json:"results"`
+	// rankings of player categories by gender
+	Categories map[string]map[string][]Row `json:"categories,omitempty"`
+}
+
+func writeJSON(w io.Writer, tournament *pb.Tournament) error {
+	var t = jsonTournament{
+		Name:      tournament.Name,
+		Mode:      tournament.Mode.String(),
+		DestValue: tournament.DestValue,
+		Results:   make(map[string][]Row, len(core.Genders)),
+	}
+	for _, gender := range core.Genders {
+		t.Results[gender.String()] = Rows(tournament, gender, "")
+		for _, category := range core.Categories(tournament, gender) {
+			if t.Categories == nil {
+				t.Categories = make(map[string]map[string][]Row)
+			}
+			if t.Categories[gender.String()] == nil {
+				t.Categories[gender.String()] = make(map[string][]Row)
+			}
+			t.Categories[gender.String()][category] = Rows(tournament, gender, category)
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(t)
+}
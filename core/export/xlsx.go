@@ -0,0 +1,147 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+// minimal SpreadsheetML package: one worksheet per ranking with inline strings
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+%s</Types>`
+	xlsxSheetContentType = `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+`
+	xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets>
+%s</sheets>
+</workbook>`
+	xlsxWorkbookSheet = `<sheet name="%s" sheetId="%d" r:id="rId%d"/>
+`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+%s</Relationships>`
+	xlsxWorkbookSheetRel = `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>
+`
+	xlsxSheet = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<sheetData>
+%s</sheetData>
+</worksheet>`
+)
+
+func writeXLSX(w io.Writer, tournament *pb.Tournament) error {
+	var (
+		contentTypes, sheets, sheetRels bytes.Buffer
+		worksheets                      []string
+		sheetNames                      = make(map[string]bool)
+		zw                              = zip.NewWriter(w)
+	)
+	for i, r := range rankings(tournament) {
+		var (
+			n    = i + 1
+			data bytes.Buffer
+		)
+		xlsxRow(&data, 1, csvHeader)
+		for j, row := range Rows(tournament, r.gender, r.category) {
+			xlsxRow(&data, j+2, []interface{}{row.Place, row.Name, row.Gender, row.Category,
+				row.Result, row.Unit, row.DestValue})
+		}
+		worksheets = append(worksheets, fmt.Sprintf(xlsxSheet, data.String()))
+		fmt.Fprintf(&contentTypes, xlsxSheetContentType, n)
+		fmt.Fprintf(&sheets, xlsxWorkbookSheet, xlsxSheetName(r.String(), sheetNames), n, n)
+		fmt.Fprintf(&sheetRels, xlsxWorkbookSheetRel, n, n)
+	}
+
+	var parts = [][2]string{
+		{"[Content_Types].xml", fmt.Sprintf(xlsxContentTypes, contentTypes.String())},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, sheets.String())},
+		{"xl/_rels/workbook.xml.rels", fmt.Sprintf(xlsxWorkbookRels, sheetRels.String())},
+	}
+	for i, worksheet := range worksheets {
+		parts = append(parts, [2]string{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), worksheet})
+	}
+	for _, part := range parts {
+		f, err := zw.Create(part[0])
+		if err != nil {
+			return err
+		}
+		if _, err = io.WriteString(f, part[1]); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// xlsxSheetName makes valid sheet name: at most 31 characters without the
+// ones spreadsheets don't allow, escaped for the workbook. Names have to be
+// unique regardless of case, so the ones already used get numbered.
+func xlsxSheetName(name string, used map[string]bool) string {
+	var buf bytes.Buffer
+
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	unique := truncate(name, 31)
+	for i := 2; used[strings.ToLower(unique)]; i++ {
+		suffix := fmt.Sprintf(" (%d)", i)
+		unique = truncate(name, 31-len(suffix)) + suffix
+	}
+	used[strings.ToLower(unique)] = true
+	xml.EscapeText(&buf, []byte(unique))
+	return buf.String()
+}
+
+func truncate(s string, n int) string {
+	if runes := []rune(s); len(runes) > n {
+		return string(runes[:n])
+	}
+	return s
+}
+
+// xlsxRow writes cells of the row; strings go inline, numbers as values
+func xlsxRow(buf *bytes.Buffer, n int, cells interface{}) {
+	var values []interface{}
+	switch c := cells.(type) {
+	case []string:
+		for _, v := range c {
+			values = append(values, v)
+		}
+	case []interface{}:
+		values = c
+	}
+
+	fmt.Fprintf(buf, `<row r="%d">`, n)
+	for i, value := range values {
+		ref := fmt.Sprintf("%c%d", 'A'+i, n)
+		switch v := value.(type) {
+		case string:
+			fmt.Fprintf(buf, `<c r="%s" t="inlineStr"><is><t>`, ref)
+			xml.EscapeText(buf, []byte(v))
+			buf.WriteString(`</t></is></c>`)
+		case float32:
+			fmt.Fprintf(buf, `<c r="%s"><v>%.3f</v></c>`, ref, v)
+		default:
+			fmt.Fprintf(buf, `<c r="%s"><v>%v</v></c>`, ref, v)
+		}
+	}
+	buf.WriteString("</row>\n")
+}
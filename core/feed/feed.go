@@ -0,0 +1,166 @@
+// Package feed broadcasts race events as JSON over WebSocket to browsers;
+// it backs the live feed of the server as well as the web visual.
+//
+// Every text frame is an object:
+//
+//	{"event": "<name>", "data": <message>}
+//
+// with data being the JSON mapping of the protocol buffers message specific
+// to the event (see the constants). Clients connecting late get the last
+// tournament, staged race and its finish again so they don't have to wait
+// for the next race.
+package feed
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/kkoralsky/gosprints/core"
+	"golang.org/x/net/websocket"
+)
+
+// events with the message sent as data
+const (
+	Tournament  = "tournament"  // Tournament set up or loaded
+	Race        = "race"        // Race: players staged for the next race
+	Countdown   = "countdown"   // Starter: countdown before the start began
+	Update      = "update"      // Racer: distance of the lane in device units
+	Swap        = "swap"        // Swap: relay team changed the rider
+	Elimination = "elimination" // Elimination: rider knocked out or caught
+	Abort       = "abort"       // AbortMessage: race aborted
+	Finish      = "finish"      // Results of the race just finished
+	Results     = "results"     // Results requested to be shown
+)
+
+// buffer of events kept for the slow client before it's dropped
+const buffer = 256
+
+var marshaler = &jsonpb.Marshaler{EmitDefaults: true}
+
+type frame struct {
+	Event string          `json:"event"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// Feed keeps the connected clients along with the events repeated to the
+// new ones
+type Feed struct {
+	mu          sync.Mutex
+	subscribers map[chan []byte]bool
+	// last events sent again to the new subscribers
+	tournament, race, finish []byte
+}
+
+func New() *Feed {
+	return &Feed{subscribers: make(map[chan []byte]bool)}
+}
+
+// Send publishes the event to every client; the ones not keeping up are
+// dropped rather than holding the race back
+func (f *Feed) Send(event string, msg proto.Message) {
+	data, err := marshaler.MarshalToString(msg)
+	if err != nil {
+		core.ErrorLogger.Printf("couldnt encode %s event: %v", event, err)
+		return
+	}
+	encoded, _ := json.Marshal(frame{Event: event, Data: json.RawMessage(data)})
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch event {
+	case Tournament:
+		f.tournament, f.race, f.finish = encoded, nil, nil
+	case Race:
+		f.race, f.finish = encoded, nil
+	case Abort:
+		f.race = nil
+	case Finish:
+		f.finish = encoded
+	}
+	for events := range f.subscribers {
+		select {
+		case events <- encoded:
+		default:
+			core.ErrorLogger.Println("feed client too slow; dropping it")
+			delete(f.subscribers, events)
+			close(events)
+		}
+	}
+}
+
+func (f *Feed) subscribe() chan []byte {
+	var events = make(chan []byte, buffer)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, encoded := range [][]byte{f.tournament, f.race, f.finish} {
+		if encoded != nil {
+			events <- encoded
+		}
+	}
+	f.subscribers[events] = true
+	return events
+}
+
+func (f *Feed) unsubscribe(events chan []byte) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.subscribers[events] {
+		delete(f.subscribers, events)
+		close(events)
+	}
+}
+
+// Clients tells how many clients are connected
+func (f *Feed) Clients() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.subscribers)
+}
+
+// serve writes the events to the connection until either side gives up;
+// anything the client sends is ignored
+func (f *Feed) serve(ws *websocket.Conn) {
+	var (
+		events = f.subscribe()
+		closed = make(chan struct{})
+	)
+	defer f.unsubscribe(events)
+	go func() {
+		io.Copy(ioutil.Discard, ws)
+		close(closed)
+	}()
+
+	for {
+		select {
+		case encoded, ok := <-events:
+			if !ok {
+				ws.Close()
+				return
+			}
+			if err := websocket.Message.Send(ws, string(encoded)); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
+
+// Handler upgrades the request to WebSocket streaming the events; any origin
+// is accepted as pages showing them are often opened from local files
+func (f *Feed) Handler() http.Handler {
+	return websocket.Server{
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler:   f.serve,
+	}
+}
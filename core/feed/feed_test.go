@@ -0,0 +1,61 @@
+package feed
+
+import (
+	"encoding/json"
+	"testing"
+
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+func events(t *testing.T, subscriber chan []byte) (names []string) {
+	for {
+		select {
+		case encoded, ok := <-subscriber:
+			if !ok {
+				return append(names, "closed")
+			}
+			var f frame
+			if err := json.Unmarshal(encoded, &f); err != nil {
+				t.Fatal(err)
+			}
+			names = append(names, f.Event)
+		default:
+			return names
+		}
+	}
+}
+
+func TestReplay(t *testing.T) {
+	var f = New()
+
+	f.Send(Tournament, &pb.Tournament{Name: "replay"})
+	f.Send(Race, &pb.Race{})
+	f.Send(Update, &pb.Racer{})
+	f.Send(Finish, &pb.Results{})
+	if got := events(t, f.subscribe()); len(got) != 3 || got[0] != Tournament || got[1] != Race || got[2] != Finish {
+		t.Errorf("tournament, race and finish should be replayed, got %v", got)
+	}
+
+	f.Send(Race, &pb.Race{})
+	f.Send(Abort, &pb.AbortMessage{})
+	if got := events(t, f.subscribe()); len(got) != 1 || got[0] != Tournament {
+		t.Errorf("aborted race shouldn't be replayed, got %v", got)
+	}
+}
+
+func TestSlowClient(t *testing.T) {
+	var (
+		f          = New()
+		subscriber = f.subscribe()
+	)
+	for i := 0; i <= buffer; i++ {
+		f.Send(Update, &pb.Racer{Distance: uint32(i)})
+	}
+	if f.Clients() != 0 {
+		t.Error("client not keeping up should be dropped")
+	}
+	if got := events(t, subscriber); len(got) != buffer+1 || got[buffer] != "closed" {
+		t.Errorf("dropped client should get buffered events and be closed, got %d", len(got))
+	}
+	f.unsubscribe(subscriber)
+}
@@ -0,0 +1,53 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+)
+
+// readCSV reads entries of the file with header; columns are recognized by
+// their names so the export can be imported back
+func readCSV(r io.Reader) (entries []Entry, err error) {
+	var cr = csv.NewReader(r)
+
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var columns = make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, errors.New("name column is missing")
+	}
+
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return entries, nil
+		} else if err != nil {
+			return nil, err
+		}
+		field := func(name string) string {
+			if i, ok := columns[strings.ToLower(name)]; ok && i < len(record) {
+				return record[i]
+			}
+			return ""
+		}
+		entries = append(entries, Entry{
+			Name:      field("name"),
+			Gender:    field("gender"),
+			Category:  field("category"),
+			Result:    field("result"),
+			Unit:      field("unit"),
+			DestValue: field("destValue"),
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+// Entry is a single player or result read from the imported file
+type Entry struct {
+	Name      string
+	Gender    string
+	Category  string
+	Result    string
+	Unit      string
+	DestValue string
+}
+
+type reader func(r io.Reader) ([]Entry, error)
+
+var readers = map[string]reader{
+	"csv":  readCSV,
+	"json": readJSON,
+}
+
+// Formats returns names of all the supported formats
+func Formats() []string {
+	return []string{"csv", "json"}
+}
+
+// Players reads registered players; every entry which can't be turned into
+// a player is reported with its number
+func Players(r io.Reader, format string) (players []*pb.Player, errs []error) {
+	entries, err := read(r, format)
+	if err != nil {
+		return nil, []error{err}
+	}
+	for i, entry := range entries {
+		player, err := entry.player()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("entry %d: %v", i+1, err))
+			continue
+		}
+		players = append(players, player)
+	}
+	return
+}
+
+// Results reads historic results of the tournament; results of distance
+// constrained and relay races are given in seconds unless unit is ms, missing
+// destination value defaults to the tournament's one
+func Results(r io.Reader, format string, tournament *pb.Tournament) (results []*pb.Result, errs []error) {
+	entries, err := read(r, format)
+	if err != nil {
+		return nil, []error{err}
+	}
+	for i, entry := range entries {
+		result, err := entry.result(tournament)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("entry %d: %v", i+1, err))
+			continue
+		}
+		results = append(results, result)
+	}
+	return
+}
+
+// NewPlayers leaves out players already registered in the tournament or
+// repeated in the import
+func NewPlayers(tournament *pb.Tournament, players []*pb.Player) (added []*pb.Player, duplicates []string) {
+	var seen = make(map[string]bool)
+
+	for _, player := range tournament.Player {
+		seen[playerKey(player)] = true
+	}
+	for _, player := range players {
+		key := playerKey(player)
+		if seen[key] {
+			duplicates = append(duplicates, player.Name)
+			continue
+		}
+		seen[key] = true
+		added = append(added, player)
+	}
+	return
+}
+
+// NewResults leaves out results already present in the tournament or
+// repeated in the import; results of the same player, distance/time and
+// value are considered the same
+func NewResults(tournament *pb.Tournament, results []*pb.Result) (added []*pb.Result, duplicates []string) {
+	var seen = make(map[string]bool)
+
+	for _, result := range tournament.Result {
+		seen[resultKey(result)] = true
+	}
+	for _, result := range results {
+		key := resultKey(result)
+		if seen[key] {
+			duplicates = append(duplicates, fmt.Sprintf("%s: %.3f", result.Player.Name, result.Result))
+			continue
+		}
+		seen[key] = true
+		added = append(added, result)
+	}
+	return
+}
+
+func read(r io.Reader, format string) ([]Entry, error) {
+	read, ok := readers[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown import format: %s; should be one of: %s", format,
+			strings.Join(Formats(), ", "))
+	}
+	return read(r)
+}
+
+func (e Entry) player() (*pb.Player, error) {
+	var name = strings.TrimSpace(e.Name)
+
+	if name == "" {
+		return nil, errors.New("player name not given")
+	}
+	gender, err := parseGender(e.Gender)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Player{Name: name, Gender: gender, Category: strings.TrimSpace(e.Category)}, nil
+}
+
+func (e Entry) result(tournament *pb.Tournament) (*pb.Result, error) {
+	var destValue = tournament.DestValue
+
+	player, err := e.player()
+	if err != nil {
+		return nil, err
+	}
+	value, err := strconv.ParseFloat(strings.TrimSpace(e.Result), 32)
+	if err != nil || value <= 0 {
+		return nil, fmt.Errorf("invalid result of %s: %q", player.Name, e.Result)
+	}
+	if strings.TrimSpace(e.DestValue) != "" {
+		v, err := strconv.ParseUint(strings.TrimSpace(e.DestValue), 10, 32)
+		if err != nil || v == 0 {
+			return nil, fmt.Errorf("invalid destination value of %s: %q", player.Name, e.DestValue)
+		}
+		destValue = uint32(v)
+	}
+
+	switch unit := strings.TrimSpace(e.Unit); {
+	case tournament.Mode != pb.Tournament_TIME && (unit == "" || unit == "s"):
+		value *= 1000
+	case tournament.Mode != pb.Tournament_TIME && unit == "ms":
+	case tournament.Mode == pb.Tournament_TIME && (unit == "" || unit == "m"):
+	default:
+		return nil, fmt.Errorf("unit %s doesn't match %s tournament", unit, tournament.Mode)
+	}
+
+	return &pb.Result{Player: player, Result: float32(value), DestValue: destValue}, nil
+}
+
+// parseGender accepts gender names as well as their first letters
+func parseGender(gender string) (pb.Gender, error) {
+	gender = strings.ToUpper(strings.TrimSpace(gender))
+	for value, name := range pb.Gender_name {
+		if gender == name || gender == name[:1] {
+			return pb.Gender(value), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown gender: %q", gender)
+}
+
+func playerKey(player *pb.Player) string {
+	return strings.ToLower(strings.TrimSpace(player.Name))
+}
+
+func resultKey(result *pb.Result) string {
+	var name string
+	if result.Player != nil {
+		name = playerKey(result.Player)
+	}
+	return fmt.Sprintf("%s\x00%d\x00%.3f", name, result.DestValue, result.Result)
+}
@@ -0,0 +1,74 @@
+package importer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kkoralsky/gosprints/core/export"
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+var testTournament = &pb.Tournament{
+	Name:      "test tournament",
+	Mode:      pb.Tournament_DISTANCE,
+	DestValue: 400,
+	Player:    []*pb.Player{{Name: "Registered", Gender: pb.Gender_MALE}},
+	Result: []*pb.Result{
+		{Player: &pb.Player{Name: "fast", Gender: pb.Gender_MALE}, Result: 20250, DestValue: 400},
+	},
+}
+
+func TestPlayersCSV(t *testing.T) {
+	players, errs := Players(strings.NewReader(`name,gender,category
+anna,f,junior
+ registered ,MALE,
+anna,F,junior
+,m,
+bob,x,
+`), "csv")
+	if len(errs) != 2 || !strings.HasPrefix(errs[0].Error(), "entry 4:") {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if len(players) != 3 || players[0].Gender != pb.Gender_FEMALE || players[0].Category != "junior" {
+		t.Fatalf("unexpected players: %v", players)
+	}
+	added, duplicates := NewPlayers(testTournament, players)
+	if len(added) != 1 || added[0].Name != "anna" || len(duplicates) != 2 {
+		t.Errorf("unexpected duplicates detection: %v %v", added, duplicates)
+	}
+}
+
+func TestResultsJSON(t *testing.T) {
+	results, errs := Results(strings.NewReader(`[
+		{"name": "new", "gender": "OTHER", "result": 21.5},
+		{"name": "fast", "gender": "MALE", "result": 20250, "unit": "ms", "destValue": 400},
+		{"name": "bad", "gender": "MALE", "result": 21, "unit": "m"}
+	]`), "json", testTournament)
+	if len(errs) != 1 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if len(results) != 2 || results[0].Result != 21500 || results[0].DestValue != 400 {
+		t.Fatalf("unexpected results: %v", results)
+	}
+	added, duplicates := NewResults(testTournament, results)
+	if len(added) != 1 || len(duplicates) != 1 {
+		t.Errorf("unexpected duplicates detection: %v %v", added, duplicates)
+	}
+}
+
+func TestExportRoundTrip(t *testing.T) {
+	for _, format := range Formats() {
+		var buf bytes.Buffer
+		if err := export.Write(&buf, testTournament, format); err != nil {
+			t.Fatal(err)
+		}
+		results, errs := Results(&buf, format, testTournament)
+		if len(errs) != 0 || len(results) != 1 {
+			t.Fatalf("%s: unexpected import: %v %v", format, results, errs)
+		}
+		if _, duplicates := NewResults(testTournament, results); len(duplicates) != 1 {
+			t.Errorf("%s: exported result should be a duplicate", format)
+		}
+	}
+}
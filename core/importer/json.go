@@ -0,0 +1,76 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"sort"
+)
+
+type jsonEntry struct {
+	Name      string      `json:"name"`
+	Gender    string      `json:"gender"`
+	Category  string      `json:"category"`
+	Result    json.Number `json:"result"`
+	Unit      string      `json:"unit"`
+	DestValue json.Number `json:"destValue"`
+}
+
+// readJSON reads either a list of entries or results grouped by gender the
+// way they're exported
+func readJSON(r io.Reader) ([]Entry, error) {
+	var (
+		list     []jsonEntry
+		exported struct {
+			Results map[string][]jsonEntry `json:"results"`
+		}
+	)
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 {
+		return nil, nil
+	}
+	switch b[0] {
+	case '[':
+		if err = json.Unmarshal(b, &list); err != nil {
+			return nil, err
+		}
+	case '{':
+		if err = json.Unmarshal(b, &exported); err != nil {
+			return nil, err
+		}
+		var genders []string
+		for gender := range exported.Results {
+			genders = append(genders, gender)
+		}
+		sort.Strings(genders)
+		for _, gender := range genders {
+			for _, entry := range exported.Results[gender] {
+				if entry.Gender == "" {
+					entry.Gender = gender
+				}
+				list = append(list, entry)
+			}
+		}
+	default:
+		return nil, errors.New("expected list of entries or exported results")
+	}
+
+	var entries = make([]Entry, 0, len(list))
+	for _, entry := range list {
+		entries = append(entries, Entry{
+			Name:      entry.Name,
+			Gender:    entry.Gender,
+			Category:  entry.Category,
+			Result:    entry.Result.String(),
+			Unit:      entry.Unit,
+			DestValue: entry.DestValue.String(),
+		})
+	}
+	return entries, nil
+}
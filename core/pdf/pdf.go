@@ -0,0 +1,166 @@
+package pdf
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gobuffalo/packr"
+	"github.com/jung-kurt/gofpdf"
+	"github.com/kkoralsky/gosprints/core"
+	"github.com/kkoralsky/gosprints/core/export"
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+const (
+	fontPath   = "m50.ttf"
+	fontFamily = "m50"
+	MimeType   = "application/pdf"
+)
+
+// Event describes what is printed on top of every page
+type Event struct {
+	Title      string
+	Date       string
+	DistFactor uint
+}
+
+// ResultSheet renders ranked results table of the tournament; one page per
+// gender which has any results followed by pages of its player categories
+func ResultSheet(w io.Writer, tournament *pb.Tournament, event Event) error {
+	doc, err := newDocument("P")
+	if err != nil {
+		return err
+	}
+	for _, gender := range core.Genders {
+		var results = core.RankedResults(tournament, gender, "")
+		if len(results) == 0 {
+			continue
+		}
+		resultTable(doc, tournament, event, fmt.Sprintf("%s - %s", tournament.Name, gender), results)
+		for _, category := range core.Categories(tournament, gender) {
+			resultTable(doc, tournament, event, fmt.Sprintf("%s - %s %s", tournament.Name, gender, category),
+				core.RankedResults(tournament, gender, category))
+		}
+	}
+	if doc.PageCount() == 0 {
+		doc.AddPage()
+		header(doc, event, tournament.Name)
+		doc.CellFormat(0, 10, "no results yet", "", 1, "C", false, 0, "")
+	}
+	return doc.Output(w)
+}
+
+// resultTable renders the ranked results on a new page
+func resultTable(doc *gofpdf.Fpdf, tournament *pb.Tournament, event Event, subtitle string, results []*pb.Result) {
+	doc.AddPage()
+	header(doc, event, subtitle)
+
+	doc.SetFontSize(12)
+	doc.SetFillColor(220, 220, 220)
+	for _, column := range []struct {
+		width float64
+		name  string
+	}{{20, "place"}, {90, "name"}, {40, "result"}, {40, "speed"}} {
+		doc.CellFormat(column.width, 9, column.name, "B", 0, "C", true, 0, "")
+	}
+	doc.Ln(-1)
+	for i, result := range results {
+		doc.CellFormat(20, 8, fmt.Sprintf("%d.", i+1), "", 0, "C", false, 0, "")
+		doc.CellFormat(90, 8, result.Player.Name, "", 0, "L", false, 0, "")
+		doc.CellFormat(40, 8, formatResult(tournament.Mode, result), "", 0, "R", false, 0, "")
+		doc.CellFormat(40, 8, fmt.Sprintf("%.1f km/h", core.Speed(tournament.Mode, result,
+			event.DistFactor)), "", 0, "R", false, 0, "")
+		doc.Ln(-1)
+	}
+}
+
+// Certificates renders finisher certificate for every ranked rider or just
+// for the given one; riders in a player category get their place in it too
+func Certificates(w io.Writer, tournament *pb.Tournament, event Event, playerName string) error {
+	doc, err := newDocument("L")
+	if err != nil {
+		return err
+	}
+	for _, gender := range core.Genders {
+		var results = core.RankedResults(tournament, gender, "")
+		for i, result := range results {
+			if playerName != "" && result.Player.Name != playerName {
+				continue
+			}
+			doc.AddPage()
+			header(doc, event, tournament.Name)
+			doc.Ln(15)
+			doc.SetFontSize(20)
+			doc.CellFormat(0, 12, "certificate of finish", "", 1, "C", false, 0, "")
+			doc.SetFontSize(40)
+			doc.CellFormat(0, 25, result.Player.Name, "", 1, "C", false, 0, "")
+			doc.SetFontSize(18)
+			place := fmt.Sprintf("place %d of %d (%s)", i+1, len(results), gender)
+			if category := result.Player.Category; category != "" {
+				categoryResults := core.RankedResults(tournament, gender, category)
+				place += fmt.Sprintf(", %d of %d (%s)", categoryPlace(categoryResults, result),
+					len(categoryResults), category)
+			}
+			doc.CellFormat(0, 12, place, "", 1, "C", false, 0, "")
+			doc.CellFormat(0, 12, formatResult(tournament.Mode, result),
+				"", 1, "C", false, 0, "")
+			doc.CellFormat(0, 12, fmt.Sprintf("average speed %.1f km/h",
+				core.Speed(tournament.Mode, result, event.DistFactor)), "", 1, "C", false, 0, "")
+		}
+	}
+	if doc.PageCount() == 0 {
+		if playerName != "" {
+			return fmt.Errorf("no ranked result of %s in %s", playerName, tournament.Name)
+		}
+		return fmt.Errorf("no ranked results in %s", tournament.Name)
+	}
+	return doc.Output(w)
+}
+
+// categoryPlace returns place of the result among the ranked results
+func categoryPlace(results []*pb.Result, result *pb.Result) int {
+	for i, r := range results {
+		if r == result {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// FileName suggests name of the printout file of the tournament
+func FileName(tournament *pb.Tournament, certificates bool) string {
+	var name = export.FileName(tournament, "pdf")
+	if certificates {
+		return strings.TrimSuffix(name, ".pdf") + "_certificates.pdf"
+	}
+	return name
+}
+
+func newDocument(orientation string) (*gofpdf.Fpdf, error) {
+	box := packr.NewBox("../visual/assets")
+	font, err := box.MustBytes(fontPath)
+	if err != nil {
+		return nil, err
+	}
+	doc := gofpdf.New(orientation, "mm", "A4", "")
+	doc.AddUTF8FontFromBytes(fontFamily, "", font)
+	doc.SetFont(fontFamily, "", 12)
+	return doc, doc.Error()
+}
+
+func header(doc *gofpdf.Fpdf, event Event, subtitle string) {
+	doc.SetFontSize(28)
+	doc.CellFormat(0, 15, event.Title, "", 1, "C", false, 0, "")
+	doc.SetFontSize(14)
+	doc.CellFormat(0, 8, subtitle, "", 1, "C", false, 0, "")
+	doc.CellFormat(0, 8, event.Date, "", 1, "C", false, 0, "")
+	doc.Ln(8)
+}
+
+func formatResult(mode pb.Tournament_TournamentMode, result *pb.Result) string {
+	if mode == pb.Tournament_TIME {
+		return fmt.Sprintf("%.2f m in %d s", result.Result, result.DestValue)
+	}
+	return fmt.Sprintf("%.3f s", result.Result/1000)
+}
@@ -0,0 +1,79 @@
+package pdf
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+var (
+	testEvent      = Event{Title: "test event", Date: "2020-02-02", DistFactor: 125}
+	testTournament = &pb.Tournament{
+		Name:      "test tournament",
+		Mode:      pb.Tournament_DISTANCE,
+		DestValue: 400,
+		Result: []*pb.Result{
+			{Player: &pb.Player{Name: "senior", Gender: pb.Gender_MALE}, Result: 20000, DestValue: 400},
+			{Player: &pb.Player{Name: "junior", Gender: pb.Gender_MALE, Category: "junior"}, Result: 22000,
+				DestValue: 400},
+			{Player: &pb.Player{Name: "żaneta", Gender: pb.Gender_FEMALE, Category: "junior"}, Result: 26000,
+				DestValue: 400},
+			{Player: &pb.Player{Name: "cheater", Gender: pb.Gender_MALE}, Result: 100, DestValue: 400,
+				Disqualified: true},
+		},
+	}
+	pageObject = regexp.MustCompile(`/Type /Page\b[^s]`)
+)
+
+// pages counts pages of the rendered document
+func pages(t *testing.T, buf *bytes.Buffer) int {
+	if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")) {
+		t.Fatalf("document should be PDF: %q", buf.String()[:10])
+	}
+	return len(pageObject.FindAll(buf.Bytes(), -1))
+}
+
+func TestResultSheet(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ResultSheet(&buf, testTournament, testEvent); err != nil {
+		t.Fatal(err)
+	}
+	// men, male juniors, women and female juniors
+	if n := pages(t, &buf); n != 4 {
+		t.Errorf("result sheet should have 4 pages, got %d", n)
+	}
+
+	buf.Reset()
+	if err := ResultSheet(&buf, &pb.Tournament{Name: "empty"}, testEvent); err != nil {
+		t.Fatal(err)
+	}
+	if n := pages(t, &buf); n != 1 {
+		t.Errorf("empty result sheet should have 1 page, got %d", n)
+	}
+}
+
+func TestCertificates(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Certificates(&buf, testTournament, testEvent, ""); err != nil {
+		t.Fatal(err)
+	}
+	if n := pages(t, &buf); n != 3 {
+		t.Errorf("every ranked rider should get a certificate, got %d", n)
+	}
+
+	buf.Reset()
+	if err := Certificates(&buf, testTournament, testEvent, "junior"); err != nil {
+		t.Fatal(err)
+	}
+	if n := pages(t, &buf); n != 1 {
+		t.Errorf("single certificate should be printed, got %d", n)
+	}
+
+	for _, name := range []string{"cheater", "nobody"} {
+		if err := Certificates(&bytes.Buffer{}, testTournament, testEvent, name); err == nil {
+			t.Errorf("%s shouldn't get a certificate", name)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"sort"
+
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+// Genders in the order results are presented
+var Genders = []pb.Gender{pb.Gender_MALE, pb.Gender_FEMALE, pb.Gender_OTHER}
+
+// SortResults ranks results from the best one: the longest distance in time
+// constrained races (the one reached first on a tie), the longest stay in
+// elimination races, the shortest time of the riders who weren't caught in
+// pursuit races and the shortest time in distance constrained and relay ones
+func SortResults(results []*pb.Result, mode pb.Tournament_TournamentMode) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].DestValue == results[j].DestValue {
+			return IsBetterResult(mode, results[i], results[j])
+		}
+		if mode == pb.Tournament_TIME || mode == pb.Tournament_ELIMINATION {
+			return results[i].DestValue < results[j].DestValue
+		}
+		return results[i].DestValue > results[j].DestValue
+	})
+}
+
+// IsBetterResult compares results of the same destination value
+func IsBetterResult(mode pb.Tournament_TournamentMode, a, b *pb.Result) bool {
+	switch mode {
+	case pb.Tournament_TIME:
+		if a.Result == b.Result && a.ReachedAt > 0 && b.ReachedAt > 0 {
+			return a.ReachedAt < b.ReachedAt
+		}
+		return a.Result > b.Result
+	case pb.Tournament_ELIMINATION:
+		if a.Result == b.Result {
+			return a.Place < b.Place
+		}
+		return a.Result > b.Result
+	case pb.Tournament_PURSUIT:
+		if a.Eliminated != b.Eliminated {
+			return !a.Eliminated
+		}
+		if a.Result == b.Result {
+			return a.Place < b.Place
+		}
+		if a.Eliminated {
+			return a.Result > b.Result
+		}
+		return a.Result < b.Result
+	default:
+		return a.Result < b.Result
+	}
+}
+
+// RankedResults returns sorted results of the given gender leaving out the
+// disqualified ones; only results of the players in the category if it's given
+func RankedResults(tournament *pb.Tournament, gender pb.Gender, category string) (results []*pb.Result) {
+	for _, result := range tournament.Result {
+		if result.Player != nil && result.Player.Gender == gender && !result.Disqualified &&
+			(category == "" || result.Player.Category == category) {
+			results = append(results, result)
+		}
+	}
+	SortResults(results, tournament.Mode)
+	return
+}
+
+// Categories returns player categories of the ranked results of the given
+// gender in alphabetical order
+func Categories(tournament *pb.Tournament, gender pb.Gender) (categories []string) {
+	var seen = make(map[string]bool)
+	for _, result := range RankedResults(tournament, gender, "") {
+		if category := result.Player.Category; category != "" && !seen[category] {
+			seen[category] = true
+			categories = append(categories, category)
+		}
+	}
+	sort.Strings(categories)
+	return
+}
+
+// Meters converts distance in device units into meters; distFactor is roller
+// circumference in cm multiplied by sampling rate
+func Meters(distance float32, distFactor uint) float32 {
+	return distance * float32(distFactor) / 100
+}
+
+// Speed returns average speed in km/h of the result; unknown for elimination
+// races and caught riders
+func Speed(mode pb.Tournament_TournamentMode, result *pb.Result, distFactor uint) float32 {
+	var meters, seconds float32
+
+	if mode == pb.Tournament_ELIMINATION || result.Eliminated {
+		return 0
+	} else if mode == pb.Tournament_TIME {
+		meters = result.Result
+		seconds = float32(result.DestValue)
+	} else {
+		meters = Meters(float32(result.DestValue), distFactor)
+		seconds = result.Result / 1000
+	}
+	if seconds <= 0 {
+		return 0
+	}
+	return meters / seconds * 3.6
+}
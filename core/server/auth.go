@@ -0,0 +1,297 @@
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kkoralsky/gosprints/core"
+	pb "github.com/kkoralsky/gosprints/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// sprintsService prefixes full names of the Sprints methods
+	sprintsService      = "/pb.Sprints/"
+	pairingCodeValidity = 2 * time.Minute
+	// pairingInterval is how often new code can be requested
+	pairingInterval = 10 * time.Second
+	// pairingAttempts are wrong guesses invalidating the code
+	pairingAttempts = 5
+	// pairingMaxFails are wrong guesses of all the codes locking the pairing
+	// out for pairingLockout
+	pairingMaxFails = 20
+	pairingLockout  = 15 * time.Minute
+	// announcementWindow is how far off the time of the visual's announcement
+	// may be; clocks of the visuals aren't expected to be exact
+	announcementWindow = time.Minute
+)
+
+// methodRoles is the lowest role allowed to call the Sprints method; methods
+// missing here are for admins only
+var methodRoles = map[string]pb.Role{
+	"GetResults":           pb.Role_VIEWER,
+	"GetTournamentNames":   pb.Role_VIEWER,
+	"GetCurrentTournament": pb.Role_VIEWER,
+	"GetPersonalBests":     pb.Role_VIEWER,
+	"GetRecords":           pb.Role_VIEWER,
+	"GetLegResults":        pb.Role_VIEWER,
+	"ExportResults":        pb.Role_VIEWER,
+	"PrintResults":         pb.Role_VIEWER,
+	liveFeedMethod:         pb.Role_VIEWER,
+	"NewRace":              pb.Role_STARTER,
+	"StartRace":            pb.Role_STARTER,
+	"AbortRace":            pb.Role_STARTER,
+	"ShowResults":          pb.Role_STARTER,
+}
+
+// visualMethods are all the visual token is allowed to call; admins can call
+// them as well
+var visualMethods = map[string]bool{
+	"RegisterVisual":   true,
+	"UnregisterVisual": true,
+}
+
+// publicMethods can be called without any token
+var publicMethods = map[string]bool{
+	"RequestPairing": true,
+	"Pair":           true,
+}
+
+// authenticator keeps tokens of the control clients and the pairing code
+// currently shown on the visuals
+type authenticator struct {
+	mu     sync.Mutex
+	tokens map[string]pb.Role
+	// visualToken registers visuals and signs their announcements only, so
+	// display boxes don't have to hold the admin token
+	visualToken    string
+	pairing        bool
+	pairingRole    pb.Role
+	pairingCode    string
+	pairingExpires time.Time
+	pairingFails   int
+	// brute forcing the codes is limited across the codes
+	pairingRequested   time.Time
+	pairingTotalFails  int
+	pairingLockedUntil time.Time
+}
+
+func setupAuthenticator(cfg core.ServerConfig) (*authenticator, error) {
+	var (
+		a   = &authenticator{tokens: make(map[string]pb.Role), visualToken: cfg.VisualToken}
+		err error
+	)
+
+	for token, role := range map[string]pb.Role{
+		cfg.ViewerToken:  pb.Role_VIEWER,
+		cfg.StarterToken: pb.Role_STARTER,
+		cfg.AdminToken:   pb.Role_ADMIN,
+	} {
+		if token != "" {
+			a.tokens[token] = role
+		}
+	}
+	if cfg.PairingRole != "" {
+		a.pairing = true
+		if a.pairingRole, err = core.ParseRole(cfg.PairingRole); err != nil {
+			return nil, fmt.Errorf("pairing %v", err)
+		}
+	}
+	if !a.enabled() {
+		core.InfoLogger.Println("no tokens nor pairing configured; control API is open to anyone")
+	}
+	return a, nil
+}
+
+// enabled tells whether the clients have to authenticate at all
+func (a *authenticator) enabled() bool {
+	return len(a.tokens) > 0 || a.visualToken != "" || a.pairing
+}
+
+// authorize checks whether the token sent with the call grants the role
+// required by the method
+func (a *authenticator) authorize(ctx context.Context, fullMethod string) error {
+	if !a.enabled() {
+		return nil
+	}
+	var method = fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	if publicMethods[method] && strings.HasPrefix(fullMethod, sprintsService) {
+		return nil
+	}
+	required, ok := methodRoles[method]
+	if !ok || !strings.HasPrefix(fullMethod, sprintsService) {
+		required = pb.Role_ADMIN
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(core.AuthMetadataKey)
+	if len(values) == 0 {
+		return status.Error(codes.Unauthenticated, "token not given")
+	}
+	token := core.ParseToken(values[0])
+	if a.visualToken != "" && token == a.visualToken {
+		if !visualMethods[method] || !strings.HasPrefix(fullMethod, sprintsService) {
+			return status.Errorf(codes.PermissionDenied, "%s is not allowed to visuals", method)
+		}
+		return nil
+	}
+	a.mu.Lock()
+	role, ok := a.tokens[token]
+	a.mu.Unlock()
+	if !ok {
+		return status.Error(codes.Unauthenticated, "invalid token")
+	}
+	if role < required {
+		return status.Errorf(codes.PermissionDenied, "%s requires %s role", method, required)
+	}
+	return nil
+}
+
+// verifyAnnouncement checks whether the announcement is recent and signed with
+// either the visual or the admin token; with auth disabled anything goes
+func (a *authenticator) verifyAnnouncement(announcement *pb.Announcement) error {
+	if !a.enabled() {
+		return nil
+	}
+	age := time.Since(time.Unix(announcement.SentAt, 0))
+	if age > announcementWindow || age < -announcementWindow {
+		return fmt.Errorf("announcement sent %v ago; check the clocks", age.Round(time.Second))
+	}
+	if a.visualToken != "" && hmac.Equal(announcement.Signature, core.SignAnnouncement(a.visualToken, announcement)) {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for token, role := range a.tokens {
+		if role == pb.Role_ADMIN && hmac.Equal(announcement.Signature, core.SignAnnouncement(token, announcement)) {
+			return nil
+		}
+	}
+	return errors.New("announcement not signed with valid token")
+}
+
+// serverOptions installs the interceptors enforcing roles on every call
+func (a *authenticator) serverOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.UnaryInterceptor(a.unaryInterceptor),
+		grpc.StreamInterceptor(a.streamInterceptor),
+	}
+}
+
+func (a *authenticator) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	if err := a.authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *authenticator) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	if err := a.authorize(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// newPairingCode replaces the pairing code with a fresh one unless requested
+// too often or locked out
+func (a *authenticator) newPairingCode() (*pb.PairingCode, error) {
+	if !a.pairing {
+		return nil, status.Error(codes.FailedPrecondition, "pairing is disabled")
+	}
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return nil, err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err = a.pairingLocked(); err != nil {
+		return nil, err
+	}
+	if time.Since(a.pairingRequested) < pairingInterval {
+		return nil, status.Error(codes.ResourceExhausted, "pairing code requested too often")
+	}
+	a.pairingRequested = time.Now()
+	a.pairingCode = fmt.Sprintf("%06d", n.Int64())
+	a.pairingExpires = time.Now().Add(pairingCodeValidity)
+	a.pairingFails = 0
+
+	return &pb.PairingCode{
+		Code:     a.pairingCode,
+		Role:     a.pairingRole,
+		ValidFor: uint32(pairingCodeValidity / time.Second),
+	}, nil
+}
+
+// pairingLocked assumes the lock is held
+func (a *authenticator) pairingLocked() error {
+	if time.Now().Before(a.pairingLockedUntil) {
+		return status.Error(codes.ResourceExhausted, "pairing locked out after too many wrong codes")
+	}
+	return nil
+}
+
+// pair exchanges valid pairing code for a new token; the code can be used
+// only once and gets invalidated after too many wrong guesses
+func (a *authenticator) pair(code string) (*pb.Pairing, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.pairingLocked(); err != nil {
+		return nil, err
+	}
+	if a.pairingCode == "" || time.Now().After(a.pairingExpires) {
+		return nil, status.Error(codes.FailedPrecondition, "no pairing code requested")
+	}
+	if code != a.pairingCode {
+		if a.pairingFails++; a.pairingFails >= pairingAttempts {
+			a.pairingCode = ""
+		}
+		if a.pairingTotalFails++; a.pairingTotalFails >= pairingMaxFails {
+			a.pairingCode = ""
+			a.pairingTotalFails = 0
+			a.pairingLockedUntil = time.Now().Add(pairingLockout)
+			core.ErrorLogger.Printf("too many wrong pairing codes; pairing locked out for %v", pairingLockout)
+		}
+		return nil, status.Error(codes.Unauthenticated, "invalid pairing code")
+	}
+	a.pairingCode = ""
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	token := hex.EncodeToString(b)
+	a.tokens[token] = a.pairingRole
+	core.InfoLogger.Printf("new %s paired", a.pairingRole)
+
+	return &pb.Pairing{Token: token, Role: a.pairingRole}, nil
+}
+
+func (s *Sprints) RequestPairing(context.Context, *pb.Empty) (*pb.Empty, error) {
+	code, err := s.auth.newPairingCode()
+	if err != nil {
+		return nil, err
+	}
+	s.visMux.ShowPairingCode(code)
+	return &pb.Empty{}, nil
+}
+
+func (s *Sprints) Pair(_ context.Context, pairingRequest *pb.PairingRequest) (*pb.Pairing, error) {
+	return s.auth.pair(pairingRequest.Code)
+}
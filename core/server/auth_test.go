@@ -0,0 +1,146 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kkoralsky/gosprints/core"
+	pb "github.com/kkoralsky/gosprints/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func withToken(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(core.AuthMetadataKey, "Bearer "+token))
+}
+
+func TestAuthorize(t *testing.T) {
+	a := testAuthenticator(t, core.ServerConfig{
+		AdminToken:   "admin",
+		StarterToken: "starter",
+		ViewerToken:  "viewer",
+		VisualToken:  "visual",
+	})
+
+	for _, c := range []struct {
+		ctx    context.Context
+		method string
+		code   codes.Code
+	}{
+		{context.Background(), "/pb.Sprints/GetResults", codes.Unauthenticated},
+		{withToken("wrong"), "/pb.Sprints/GetResults", codes.Unauthenticated},
+		{withToken("viewer"), "/pb.Sprints/GetResults", codes.OK},
+		{withToken("viewer"), "/pb.Sprints/StartRace", codes.PermissionDenied},
+		{withToken("starter"), "/pb.Sprints/AbortRace", codes.OK},
+		{withToken("starter"), "/pb.Sprints/NewTournament", codes.PermissionDenied},
+		{withToken("admin"), "/pb.Sprints/NewTournament", codes.OK},
+		{withToken("starter"), "/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo", codes.PermissionDenied},
+		{context.Background(), "/pb.Sprints/Pair", codes.OK},
+		{withToken("visual"), "/pb.Sprints/RegisterVisual", codes.OK},
+		{withToken("visual"), "/pb.Sprints/UnregisterVisual", codes.OK},
+		{withToken("visual"), "/pb.Sprints/GetResults", codes.PermissionDenied},
+		{withToken("visual"), "/pb.Sprints/DeleteTournament", codes.PermissionDenied},
+		{withToken("starter"), "/pb.Sprints/RegisterVisual", codes.PermissionDenied},
+		{withToken("admin"), "/pb.Sprints/RegisterVisual", codes.OK},
+	} {
+		if code := status.Code(a.authorize(c.ctx, c.method)); code != c.code {
+			t.Errorf("%s should end up with %s, not %s", c.method, c.code, code)
+		}
+	}
+
+	if err := testAuthenticator(t, core.ServerConfig{}).authorize(context.Background(), "/pb.Sprints/NewTournament"); err != nil {
+		t.Errorf("without tokens everyone should be allowed: %v", err)
+	}
+}
+
+func testAuthenticator(t *testing.T, cfg core.ServerConfig) *authenticator {
+	a, err := setupAuthenticator(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestPairing(t *testing.T) {
+	a := testAuthenticator(t, core.ServerConfig{PairingRole: "starter"})
+
+	if _, err := a.pair("000000"); err == nil {
+		t.Error("pairing without requested code should fail")
+	}
+	code, err := a.newPairingCode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pairing, err := a.pair(code.Code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pairing.Role != pb.Role_STARTER {
+		t.Errorf("pairing should grant starter role, not %s", pairing.Role)
+	}
+	if err = a.authorize(withToken(pairing.Token), "/pb.Sprints/StartRace"); err != nil {
+		t.Error(err)
+	}
+	if _, err = a.pair(code.Code); err == nil {
+		t.Error("pairing code should be used only once")
+	}
+
+	if _, err = a.newPairingCode(); err == nil {
+		t.Error("pairing code shouldn't be requested again right away")
+	}
+	a.pairingRequested = time.Time{}
+	if code, err = a.newPairingCode(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < pairingAttempts; i++ {
+		a.pair("wrong")
+	}
+	if _, err = a.pair(code.Code); err == nil {
+		t.Error("pairing code should be invalidated after too many wrong guesses")
+	}
+}
+
+func TestPairingLockout(t *testing.T) {
+	a := testAuthenticator(t, core.ServerConfig{PairingRole: "admin"})
+
+	var code *pb.PairingCode
+	for fails := 0; fails < pairingMaxFails; fails++ {
+		if fails%pairingAttempts == 0 {
+			var err error
+			a.pairingRequested = time.Time{}
+			if code, err = a.newPairingCode(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		a.pair("wrong")
+	}
+	a.pairingRequested = time.Time{}
+	if _, err := a.newPairingCode(); err == nil {
+		t.Error("pairing should be locked out after too many wrong guesses")
+	}
+	if _, err := a.pair(code.Code); err == nil {
+		t.Error("pairing code shouldn't be accepted when locked out")
+	}
+
+	a.pairingLockedUntil = time.Now()
+	code, err := a.newPairingCode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = a.pair(code.Code); err != nil {
+		t.Errorf("pairing should work again after the lockout: %v", err)
+	}
+}
+
+func TestInvalidPairingRole(t *testing.T) {
+	if _, err := setupAuthenticator(core.ServerConfig{PairingRole: "root"}); err == nil {
+		t.Error("unknown pairing role should be rejected")
+	}
+	a := testAuthenticator(t, core.ServerConfig{PairingRole: "Starter"})
+	if a.pairingRole != pb.Role_STARTER {
+		t.Errorf("pairing role should be starter, not %s", a.pairingRole)
+	}
+}
@@ -0,0 +1,420 @@
+package server
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/kkoralsky/gosprints/proto"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// tournament name -> tournament without its results
+	tournamentsBucket = []byte("tournaments")
+	// sequence -> tournament name; keeps order of creation
+	orderBucket = []byte("order")
+	// tournament name -> nested bucket of sequence -> result
+	resultsBucket = []byte("results")
+	// tournament name -> nested bucket of sequence -> registered player
+	registeredBucket = []byte("registered")
+	// player key -> nested bucket of tournament name \x00 sequence -> nothing
+	playersBucket = []byte("players")
+)
+
+// boltStorage keeps tournaments, their results and registered players as
+// separate records of the bolt key/value database; results are indexed by
+// their players
+type boltStorage struct {
+	db *bolt.DB
+}
+
+func setupBoltStorage(fileName string) (*boltStorage, error) {
+	db, err := bolt.Open(fileName, dbFileMode, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{tournamentsBucket, orderBucket, resultsBucket, registeredBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		if tx.Bucket(playersBucket) != nil {
+			return nil
+		}
+		// results stored before the players index
+		if _, err := tx.CreateBucket(playersBucket); err != nil {
+			return err
+		}
+		return indexPlayers(tx)
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &boltStorage{db: db}, nil
+}
+
+func (s *boltStorage) ListTournaments() (tournaments []*pb.Tournament, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(orderBucket).ForEach(func(_, name []byte) error {
+			tournament, err := getTournamentHeader(tx, name)
+			if err != nil {
+				return err
+			}
+			tournaments = append(tournaments, tournament)
+			return nil
+		})
+	})
+	return
+}
+
+func (s *boltStorage) GetTournaments() (tournaments []*pb.Tournament, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(orderBucket).ForEach(func(_, name []byte) error {
+			tournament, err := getTournament(tx, name)
+			if err != nil {
+				return err
+			}
+			tournaments = append(tournaments, tournament)
+			return nil
+		})
+	})
+	return
+}
+
+func (s *boltStorage) GetTournament(name string) (tournament *pb.Tournament, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		tournament, err = getTournament(tx, []byte(name))
+		return err
+	})
+	return
+}
+
+func (s *boltStorage) SaveTournament(tournament *pb.Tournament) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		var name = []byte(tournament.Name)
+
+		if err := deleteResults(tx, name); err != nil {
+			return err
+		}
+		if err := putTournamentHeader(tx, tournament); err != nil {
+			return err
+		}
+		if err := putRegistered(tx, tournament); err != nil {
+			return err
+		}
+		for _, result := range tournament.Result {
+			if err := putResult(tx, name, result); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *boltStorage) AddResult(tournament *pb.Tournament, result *pb.Result) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := putTournamentHeader(tx, tournament); err != nil {
+			return err
+		}
+		if err := putRegistered(tx, tournament); err != nil {
+			return err
+		}
+		return putResult(tx, []byte(tournament.Name), result)
+	})
+}
+
+func (s *boltStorage) RenameTournament(name, newName string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket(tournamentsBucket).Get([]byte(newName)) != nil {
+			return fmt.Errorf("%s already exists", newName)
+		}
+		tournament, err := getTournament(tx, []byte(name))
+		if err != nil {
+			return err
+		}
+		// keep renamed tournament in its place of the order
+		seq := orderKey(tx, []byte(name))
+		if err = deleteTournament(tx, []byte(name)); err != nil {
+			return err
+		}
+		if err = tx.Bucket(orderBucket).Put(seq, []byte(newName)); err != nil {
+			return err
+		}
+		tournament.Name = newName
+		if err = putTournamentHeader(tx, tournament); err != nil {
+			return err
+		}
+		if err = putRegistered(tx, tournament); err != nil {
+			return err
+		}
+		for _, result := range tournament.Result {
+			if err = putResult(tx, []byte(newName), result); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *boltStorage) DeleteTournament(name string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket(tournamentsBucket).Get([]byte(name)) == nil {
+			return errNotFound
+		}
+		return deleteTournament(tx, []byte(name))
+	})
+}
+
+func (s *boltStorage) GetPlayerResults(playerName string) (records []*pb.Record, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		index := tx.Bucket(playersBucket).Bucket([]byte(playerKey(playerName)))
+		if index == nil {
+			return nil
+		}
+		return index.ForEach(func(k, _ []byte) error {
+			var (
+				sep            = bytes.IndexByte(k, 0)
+				tournamentName = k[:sep]
+				result         = &pb.Result{}
+			)
+			tournament, err := getTournamentHeader(tx, tournamentName)
+			if err != nil {
+				return err
+			}
+			if err = proto.Unmarshal(tx.Bucket(resultsBucket).Bucket(tournamentName).Get(k[sep+1:]), result); err != nil {
+				return err
+			}
+			records = append(records, &pb.Record{
+				Result:         result,
+				Mode:           tournament.Mode,
+				TournamentName: tournament.Name,
+			})
+			return nil
+		})
+	})
+	return
+}
+
+func (s *boltStorage) Close() error {
+	return s.db.Close()
+}
+
+func getTournamentHeader(tx *bolt.Tx, name []byte) (*pb.Tournament, error) {
+	var (
+		tournament = &pb.Tournament{}
+		b          = tx.Bucket(tournamentsBucket).Get(name)
+	)
+	if b == nil {
+		return nil, errNotFound
+	}
+	return tournament, proto.Unmarshal(b, tournament)
+}
+
+func getTournament(tx *bolt.Tx, name []byte) (*pb.Tournament, error) {
+	tournament, err := getTournamentHeader(tx, name)
+	if err != nil {
+		return nil, err
+	}
+	if registered := tx.Bucket(registeredBucket).Bucket(name); registered != nil {
+		err = registered.ForEach(func(_, b []byte) error {
+			var player = &pb.Player{}
+			if err := proto.Unmarshal(b, player); err != nil {
+				return err
+			}
+			tournament.Player = append(tournament.Player, player)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	results := tx.Bucket(resultsBucket).Bucket(name)
+	if results == nil {
+		return tournament, nil
+	}
+	err = results.ForEach(func(_, b []byte) error {
+		var result = &pb.Result{}
+		if err := proto.Unmarshal(b, result); err != nil {
+			return err
+		}
+		tournament.Result = append(tournament.Result, result)
+		return nil
+	})
+	return tournament, err
+}
+
+// putTournamentHeader stores tournament without its results and registered
+// players; new tournaments are appended to the order of creation
+func putTournamentHeader(tx *bolt.Tx, tournament *pb.Tournament) error {
+	var (
+		header      = *tournament
+		name        = []byte(tournament.Name)
+		tournaments = tx.Bucket(tournamentsBucket)
+	)
+	header.Result = nil
+	header.Player = nil
+	b, err := proto.Marshal(&header)
+	if err != nil {
+		return err
+	}
+	if tournaments.Get(name) == nil && orderKey(tx, name) == nil {
+		order := tx.Bucket(orderBucket)
+		seq, err := order.NextSequence()
+		if err != nil {
+			return err
+		}
+		if err = order.Put(itob(seq), name); err != nil {
+			return err
+		}
+	}
+	return tournaments.Put(name, b)
+}
+
+// putRegistered replaces registered players of the tournament
+func putRegistered(tx *bolt.Tx, tournament *pb.Tournament) error {
+	var name = []byte(tournament.Name)
+
+	if tx.Bucket(registeredBucket).Bucket(name) != nil {
+		if err := tx.Bucket(registeredBucket).DeleteBucket(name); err != nil {
+			return err
+		}
+	}
+	if len(tournament.Player) == 0 {
+		return nil
+	}
+	registered, err := tx.Bucket(registeredBucket).CreateBucket(name)
+	if err != nil {
+		return err
+	}
+	for i, player := range tournament.Player {
+		b, err := proto.Marshal(player)
+		if err != nil {
+			return err
+		}
+		if err = registered.Put(itob(uint64(i)), b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func putResult(tx *bolt.Tx, tournamentName []byte, result *pb.Result) error {
+	results, err := tx.Bucket(resultsBucket).CreateBucketIfNotExists(tournamentName)
+	if err != nil {
+		return err
+	}
+	seq, err := results.NextSequence()
+	if err != nil {
+		return err
+	}
+	b, err := proto.Marshal(result)
+	if err != nil {
+		return err
+	}
+	if err = results.Put(itob(seq), b); err != nil {
+		return err
+	}
+	return indexResult(tx, tournamentName, itob(seq), result)
+}
+
+// indexResult adds the result to the index of its player
+func indexResult(tx *bolt.Tx, tournamentName, seq []byte, result *pb.Result) error {
+	if result.Player == nil {
+		return nil
+	}
+	index, err := tx.Bucket(playersBucket).CreateBucketIfNotExists([]byte(playerKey(result.Player.Name)))
+	if err != nil {
+		return err
+	}
+	return index.Put(indexKey(tournamentName, seq), []byte{})
+}
+
+// indexPlayers indexes all the stored results by their players
+func indexPlayers(tx *bolt.Tx) error {
+	return tx.Bucket(resultsBucket).ForEach(func(tournamentName, _ []byte) error {
+		return tx.Bucket(resultsBucket).Bucket(tournamentName).ForEach(func(seq, b []byte) error {
+			var result = &pb.Result{}
+			if err := proto.Unmarshal(b, result); err != nil {
+				return err
+			}
+			return indexResult(tx, tournamentName, seq, result)
+		})
+	})
+}
+
+// deleteResults removes results of the tournament along with their players
+// index entries
+func deleteResults(tx *bolt.Tx, tournamentName []byte) error {
+	var results = tx.Bucket(resultsBucket).Bucket(tournamentName)
+	if results == nil {
+		return nil
+	}
+	err := results.ForEach(func(seq, b []byte) error {
+		var result = &pb.Result{}
+		if err := proto.Unmarshal(b, result); err != nil {
+			return err
+		}
+		if result.Player == nil {
+			return nil
+		}
+		var key = []byte(playerKey(result.Player.Name))
+		index := tx.Bucket(playersBucket).Bucket(key)
+		if index == nil {
+			return nil
+		}
+		if err := index.Delete(indexKey(tournamentName, seq)); err != nil {
+			return err
+		}
+		if k, _ := index.Cursor().First(); k == nil {
+			return tx.Bucket(playersBucket).DeleteBucket(key)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(resultsBucket).DeleteBucket(tournamentName)
+}
+
+func deleteTournament(tx *bolt.Tx, name []byte) error {
+	if err := deleteResults(tx, name); err != nil {
+		return err
+	}
+	if tx.Bucket(registeredBucket).Bucket(name) != nil {
+		if err := tx.Bucket(registeredBucket).DeleteBucket(name); err != nil {
+			return err
+		}
+	}
+	if seq := orderKey(tx, name); seq != nil {
+		if err := tx.Bucket(orderBucket).Delete(seq); err != nil {
+			return err
+		}
+	}
+	return tx.Bucket(tournamentsBucket).Delete(name)
+}
+
+func orderKey(tx *bolt.Tx, name []byte) []byte {
+	var c = tx.Bucket(orderBucket).Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		if bytes.Equal(v, name) {
+			return append([]byte{}, k...)
+		}
+	}
+	return nil
+}
+
+func indexKey(tournamentName, seq []byte) []byte {
+	var key = append(append([]byte{}, tournamentName...), 0)
+	return append(key, seq...)
+}
+
+func itob(v uint64) []byte {
+	var b = make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b
+}
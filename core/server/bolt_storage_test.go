@@ -0,0 +1,169 @@
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/kkoralsky/gosprints/proto"
+	bolt "go.etcd.io/bbolt"
+)
+
+func testResult(name string, result float32) *pb.Result {
+	return &pb.Result{Player: &pb.Player{Name: name}, Result: result, DestValue: 400}
+}
+
+// testStorage runs the same checks against every backend
+func testStorage(t *testing.T, s Storage) {
+	for _, name := range []string{"first", "second", "third"} {
+		tournament := &pb.Tournament{Name: name, Mode: pb.Tournament_DISTANCE, DestValue: 400}
+		if err := s.SaveTournament(tournament); err != nil {
+			t.Fatal(err)
+		}
+	}
+	second, err := s.GetTournament("second")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second.Player = []*pb.Player{{Name: "rider", Category: "junior"}, {Name: "other"}}
+	for i, result := range []float32{20000, 19000} {
+		second.Result = append(second.Result, testResult("rider", result))
+		if err = s.AddResult(second, second.Result[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if second, err = s.GetTournament("second"); err != nil || len(second.Result) != 2 || second.Result[1].Result != 19000 {
+		t.Fatalf("added results should be stored in order: %v %v", second, err)
+	}
+	// saving replaces the results
+	second.Result = second.Result[:1]
+	if err = s.SaveTournament(second); err != nil {
+		t.Fatal(err)
+	}
+	if second, _ = s.GetTournament("second"); len(second.Result) != 1 {
+		t.Errorf("saved tournament should have 1 result, got %d", len(second.Result))
+	}
+	if len(second.Player) != 2 || second.Player[0].Category != "junior" || second.Player[1].Name != "other" {
+		t.Errorf("registered players should be stored in order, got %v", second.Player)
+	}
+	third, _ := s.GetTournament("third")
+	third.Result = []*pb.Result{testResult("Rider ", 18000), testResult("other", 17000)}
+	if err = s.SaveTournament(third); err != nil {
+		t.Fatal(err)
+	}
+	records, err := s.GetPlayerResults("rider")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("rider should have 2 results, got %v", records)
+	}
+	for _, record := range records {
+		if record.Mode != pb.Tournament_DISTANCE || (record.TournamentName == "second") != (record.Result.Result == 20000) {
+			t.Errorf("result should be found along with its tournament: %v", record)
+		}
+	}
+
+	if err = s.RenameTournament("second", "third"); err == nil {
+		t.Error("tournament shouldn't be renamed over the existing one")
+	}
+	if err = s.RenameTournament("second", "renamed"); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.DeleteTournament("first"); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.DeleteTournament("first"); err != errNotFound {
+		t.Errorf("deleted tournament shouldn't be found, got %v", err)
+	}
+	if _, err = s.GetTournament("second"); err != errNotFound {
+		t.Errorf("renamed tournament shouldn't be found by the old name, got %v", err)
+	}
+
+	tournaments, err := s.GetTournaments()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tournaments) != 2 || tournaments[0].Name != "renamed" || tournaments[1].Name != "third" {
+		t.Fatalf("renamed tournament should keep its place, got %v", tournaments)
+	}
+	if len(tournaments[0].Result) != 1 || tournaments[0].Result[0].Player.Name != "rider" || len(tournaments[0].Player) != 2 {
+		t.Errorf("renamed tournament should keep its results and players, got %v", tournaments[0])
+	}
+	records, _ = s.GetPlayerResults("rider")
+	for _, record := range records {
+		if record.TournamentName == "second" {
+			t.Errorf("results should be found by the new tournament name, got %v", record)
+		}
+	}
+	if records, _ = s.GetPlayerResults("nobody"); len(records) != 0 {
+		t.Errorf("nobody should have no results, got %v", records)
+	}
+	list, err := s.ListTournaments()
+	if err != nil || len(list) != 2 {
+		t.Errorf("tournaments should be listed: %v %v", list, err)
+	}
+}
+
+func TestStorageBackends(t *testing.T) {
+	for _, backend := range []string{ProtoBackend, BoltBackend} {
+		t.Run(backend, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "gosprints-db")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			s, err := setupStorage(backend, filepath.Join(dir, "sprints"), 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testStorage(t, s)
+
+			// everything is there after reopening
+			if err = s.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if s, err = setupStorage(backend, filepath.Join(dir, "sprints"), 0); err != nil {
+				t.Fatal(err)
+			}
+			defer s.Close()
+			if tournaments, _ := s.GetTournaments(); len(tournaments) != 2 || len(tournaments[0].Result) != 1 {
+				t.Errorf("tournaments should be reopened, got %v", tournaments)
+			}
+		})
+	}
+}
+
+func TestBoltStorageIndexesStoredResults(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gosprints-db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fileName := filepath.Join(dir, "sprints.db")
+
+	s, err := setupBoltStorage(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.SaveTournament(&pb.Tournament{Name: "stored", Result: []*pb.Result{testResult("rider", 20000)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// as if the results were stored before the players index
+	s.db.Update(func(tx *bolt.Tx) error {
+		return tx.DeleteBucket(playersBucket)
+	})
+	s.Close()
+
+	if s, err = setupBoltStorage(fileName); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if records, err := s.GetPlayerResults("rider"); err != nil || len(records) != 1 {
+		t.Errorf("stored results should be indexed on open, got %v %v", records, err)
+	}
+}
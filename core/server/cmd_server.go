@@ -24,11 +24,11 @@ func (c *CmdServer) Stop() {
 	c.tcpListener.Close()
 }
 
-func SetupCmdServer(port uint, debug bool, sprints *Sprints) (*CmdServer, error) {
+func SetupCmdServer(port uint, debug bool, sprints *Sprints, opts ...grpc.ServerOption) (*CmdServer, error) {
 	var (
 		c = &CmdServer{
 			port:       port,
-			grpcServer: grpc.NewServer(),
+			grpcServer: grpc.NewServer(append(sprints.auth.serverOptions(), opts...)...),
 			Sprints:    sprints,
 		}
 		err error
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"net"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/kkoralsky/gosprints/core"
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+// discoveryBuffer fits the Announcement datagram
+const discoveryBuffer = 2048
+
+// Discovery adds the visuals broadcasting their Announcement over UDP within
+// the LAN to the mux; with auth enabled only the ones signed with the token
+// allowed to register visuals are
+type Discovery struct {
+	conn   *net.UDPConn
+	visMux *VisMux
+	auth   *authenticator
+}
+
+func SetupDiscovery(port uint, visMux *VisMux, auth *authenticator) (*Discovery, error) {
+	var (
+		d   = &Discovery{visMux: visMux, auth: auth}
+		err error
+	)
+	d.conn, err = net.ListenUDP("udp4", &net.UDPAddr{Port: int(port)})
+	return d, err
+}
+
+// Run handles the announcements until stopped
+func (d *Discovery) Run() {
+	var buf = make([]byte, discoveryBuffer)
+
+	for {
+		n, sender, err := d.conn.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+		var (
+			announcement = &pb.Announcement{}
+			registration = &pb.VisualRegistration{}
+		)
+		if err = proto.Unmarshal(buf[:n], announcement); err == nil {
+			err = proto.Unmarshal(announcement.Registration, registration)
+		}
+		if err != nil {
+			core.DebugLogger.Printf("invalid announcement from %s: %v", sender, err)
+			continue
+		}
+		if err = d.auth.verifyAnnouncement(announcement); err != nil {
+			core.ErrorLogger.Printf("announcement from %s rejected: %v", sender, err)
+			continue
+		}
+		address, err := visualAddress(sender.IP.String(), registration)
+		if err == nil {
+			err = d.visMux.DialVisual(address, registration.Configuration)
+		}
+		if err != nil {
+			core.ErrorLogger.Printf("couldnt add visual announced by %s: %v", sender, err)
+		}
+	}
+}
+
+func (d *Discovery) Stop() {
+	d.conn.Close()
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+
+	"github.com/kkoralsky/gosprints/core"
+	"github.com/kkoralsky/gosprints/core/export"
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+func (s *Sprints) ExportResults(_ context.Context, exportSpec *pb.ExportSpec) (*pb.ExportedFile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var buf bytes.Buffer
+
+	tournament, err := s.getTournament(exportSpec.TournamentName)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkLegacyResults(tournament); err != nil {
+		return nil, err
+	}
+	if err = export.Write(&buf, tournament, exportSpec.Format); err != nil {
+		return nil, err
+	}
+	return &pb.ExportedFile{
+		FileName: export.FileName(tournament, exportSpec.Format),
+		MimeType: export.MimeType(exportSpec.Format),
+		Content:  buf.Bytes(),
+	}, nil
+}
+
+// Export writes results of the tournament stored in the database into the
+// file or standard output
+func Export(cfg core.ExportConfig) error {
+	var (
+		out        io.Writer = os.Stdout
+		tournament *pb.Tournament
+	)
+	sprintsDb, err := SetupSprintsDb(cfg.DbBackend, cfg.DbPath, 0)
+	if err != nil {
+		return err
+	}
+	defer sprintsDb.Close()
+
+	if cfg.Tournament == "" {
+		tournament, err = sprintsDb.GetLastTournament()
+	} else {
+		tournament, err = sprintsDb.GetTournament(cfg.Tournament)
+	}
+	if err != nil {
+		return err
+	}
+	if err = checkLegacyResults(tournament); err != nil {
+		return err
+	}
+
+	if cfg.Out != "-" {
+		if cfg.Out == "" {
+			cfg.Out = export.FileName(tournament, cfg.Format)
+		}
+		f, err := os.Create(cfg.Out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	if err = export.Write(out, tournament, cfg.Format); err != nil {
+		return err
+	}
+	if cfg.Out != "-" {
+		core.InfoLogger.Printf("%s exported to %s", tournament.Name, cfg.Out)
+	}
+	return nil
+}
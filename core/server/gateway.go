@@ -0,0 +1,344 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/kkoralsky/gosprints/core"
+	pb "github.com/kkoralsky/gosprints/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	gatewayPrefix = "/v1/"
+	openAPIPath   = gatewayPrefix + "openapi.json"
+	ndjsonType    = "application/x-ndjson"
+)
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	marshaler   = &jsonpb.Marshaler{EmitDefaults: true}
+)
+
+// gatewayMethod is the Sprints RPC mapped onto POST /v1/<name>
+type gatewayMethod struct {
+	name     string
+	request  reflect.Type
+	response reflect.Type
+	stream   reflect.Type
+}
+
+// gateway translates JSON requests into calls of the Sprints service; the
+// methods are taken from the generated pb.SprintsServer interface so that
+// every RPC is exposed
+type gateway struct {
+	sprints *Sprints
+	methods map[string]*gatewayMethod
+	openAPI []byte
+}
+
+func setupGateway(sprints *Sprints) *gateway {
+	var g = &gateway{sprints: sprints, methods: gatewayMethods()}
+
+	openAPI, err := json.MarshalIndent(openAPIDescription(g.methods), "", "  ")
+	if err != nil {
+		core.ErrorLogger.Printf("couldnt describe the gateway: %v", err)
+	}
+	g.openAPI = append(openAPI, '\n')
+
+	return g
+}
+
+// gatewayMethods maps every method of the Sprints service: unary ones take
+// context and request returning response, server streams take request and
+// the stream sending the responses
+func gatewayMethods() map[string]*gatewayMethod {
+	var (
+		methods = make(map[string]*gatewayMethod)
+		server  = reflect.TypeOf((*pb.SprintsServer)(nil)).Elem()
+	)
+	for i := 0; i < server.NumMethod(); i++ {
+		var (
+			method = server.Method(i)
+			m      = &gatewayMethod{name: method.Name}
+		)
+		if method.Type.In(0) == contextType {
+			m.request, m.response = method.Type.In(1), method.Type.Out(0)
+		} else {
+			m.request, m.stream = method.Type.In(0), method.Type.In(1)
+			send, _ := m.stream.MethodByName("Send")
+			m.response = send.Type.In(0)
+		}
+		methods[method.Name] = m
+	}
+	return methods
+}
+
+func (g *gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == openAPIPath {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(g.openAPI)
+		return
+	}
+	m, ok := g.methods[strings.TrimPrefix(r.URL.Path, gatewayPrefix)]
+	if !ok {
+		writeError(w, status.Errorf(codes.NotFound, "no such method: %s", r.URL.Path))
+		return
+	}
+	// anything but viewing has to be JSON posted so that links, images or
+	// forms of other sites opened within the venue can't call it
+	if role, ok := methodRoles[m.name]; ok && role == pb.Role_VIEWER {
+		if r.Method != http.MethodPost && r.Method != http.MethodGet {
+			w.Header().Set("Allow", "GET, POST")
+			writeError(w, status.Error(codes.Unimplemented, "only GET and POST are allowed"))
+			return
+		}
+	} else {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", "POST")
+			writeError(w, status.Errorf(codes.Unimplemented, "%s allows POST only", m.name))
+			return
+		}
+		if contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); contentType != "application/json" {
+			writeError(w, status.Errorf(codes.InvalidArgument, "%s takes application/json only", m.name))
+			return
+		}
+	}
+
+	var ctx = r.Context()
+	if addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+	}
+	if token := r.Header.Get("Authorization"); token != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(core.AuthMetadataKey, token))
+	}
+	if err := g.sprints.auth.authorize(ctx, sprintsService+m.name); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var (
+		request = reflect.New(m.request.Elem())
+		err     error
+	)
+	if r.Method == http.MethodGet {
+		err = queryRequest(r.URL.Query(), request.Interface().(proto.Message))
+	} else if err = jsonpb.Unmarshal(r.Body, request.Interface().(proto.Message)); err == io.EOF {
+		err = nil
+	}
+	if err != nil {
+		writeError(w, status.Errorf(codes.InvalidArgument, "invalid %s: %v", m.request.Elem().Name(), err))
+		return
+	}
+	call := reflect.ValueOf(g.sprints).MethodByName(m.name)
+
+	if m.stream != nil {
+		stream := newNDJSONStream(ctx, w)
+		out := call.Call([]reflect.Value{request, reflect.ValueOf(stream.typed(m.stream))})
+		if err, _ := out[0].Interface().(error); err != nil {
+			if stream.started {
+				core.ErrorLogger.Printf("%s stream broken: %v", m.name, err)
+			} else {
+				writeError(w, err)
+			}
+		} else if !stream.started {
+			w.Header().Set("Content-Type", ndjsonType)
+		}
+		return
+	}
+
+	out := call.Call([]reflect.Value{reflect.ValueOf(ctx), request})
+	if err, _ := out[1].Interface().(error); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := marshaler.Marshal(w, out[0].Interface().(proto.Message)); err != nil {
+		core.ErrorLogger.Printf("couldnt send %s response: %v", m.name, err)
+	}
+}
+
+// queryRequest fills the request of GET from the query parameters named after
+// its fields, in camel or snake case alike; repeated fields are given by
+// repeating the parameter while nested messages can't be given at all
+func queryRequest(query url.Values, request proto.Message) error {
+	var (
+		t      = reflect.TypeOf(request).Elem()
+		props  = proto.GetProperties(t)
+		fields = make(map[string]json.RawMessage, len(query))
+	)
+	for name, values := range query {
+		var field = -1
+		for i, prop := range props.Prop {
+			if prop.OrigName != "" && queryName(prop.OrigName) == queryName(name) {
+				field = i
+				break
+			}
+		}
+		if field == -1 {
+			return fmt.Errorf("unknown parameter %s", name)
+		}
+		var (
+			fieldType = t.Field(field).Type
+			prop      = props.Prop[field]
+			encoded   = make([]json.RawMessage, len(values))
+		)
+		if prop.Repeated {
+			fieldType = fieldType.Elem()
+		} else if len(values) > 1 {
+			return fmt.Errorf("%s given more than once", name)
+		}
+		for i, value := range values {
+			var err error
+			if encoded[i], err = queryValue(fieldType, prop.Enum != "", value); err != nil {
+				return fmt.Errorf("%s: %v", name, err)
+			}
+		}
+		if prop.Repeated {
+			fields[prop.OrigName], _ = json.Marshal(encoded)
+		} else {
+			fields[prop.OrigName] = encoded[0]
+		}
+	}
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return jsonpb.Unmarshal(bytes.NewReader(b), request)
+}
+
+func queryName(name string) string {
+	return strings.ToLower(strings.Replace(name, "_", "", -1))
+}
+
+// queryValue encodes the query parameter as JSON value of the field type;
+// enums are given either by name or by number
+func queryValue(fieldType reflect.Type, enum bool, value string) (json.RawMessage, error) {
+	switch fieldType.Kind() {
+	case reflect.String:
+		return json.Marshal(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(b)
+	case reflect.Int32, reflect.Int64, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			if enum {
+				return json.Marshal(value)
+			}
+			return nil, fmt.Errorf("%q is not a number", value)
+		}
+		return json.RawMessage(value), nil
+	default:
+		return nil, fmt.Errorf("%s can't be given as query parameter", fieldType)
+	}
+}
+
+// writeError responds with HTTP status matching the gRPC code of the error
+func writeError(w http.ResponseWriter, err error) {
+	var st = status.Convert(err)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus(st.Code()))
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": st.Message(),
+		"code":  st.Code(),
+	})
+}
+
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusMethodNotAllowed
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ndjsonStream sends messages of the server stream as newline delimited JSON
+type ndjsonStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	w       http.ResponseWriter
+	started bool
+}
+
+func newNDJSONStream(ctx context.Context, w http.ResponseWriter) *ndjsonStream {
+	return &ndjsonStream{ctx: ctx, w: w}
+}
+
+func (s *ndjsonStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *ndjsonStream) SendMsg(m interface{}) error {
+	if !s.started {
+		s.w.Header().Set("Content-Type", ndjsonType)
+		s.started = true
+	}
+	if err := marshaler.Marshal(s.w, m.(proto.Message)); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(s.w, "\n"); err != nil {
+		return err
+	}
+	if flusher, ok := s.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+type resultStream struct{ *ndjsonStream }
+
+func (s resultStream) Send(result *pb.Result) error { return s.SendMsg(result) }
+
+type recordStream struct{ *ndjsonStream }
+
+func (s recordStream) Send(record *pb.Record) error { return s.SendMsg(record) }
+
+// typed wraps the stream into the one implementing the server stream
+// interface of the method
+func (s *ndjsonStream) typed(streamType reflect.Type) interface{} {
+	for _, typed := range []interface{}{resultStream{s}, recordStream{s}} {
+		if reflect.TypeOf(typed).Implements(streamType) {
+			return typed
+		}
+	}
+	panic(fmt.Sprintf("no NDJSON stream implements %s", streamType))
+}
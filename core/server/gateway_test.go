@@ -0,0 +1,204 @@
+package server
+
+import (
+	"bufio"
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/kkoralsky/gosprints/core"
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+var updateOpenAPI = flag.Bool("update_openapi", false, "regenerate proto/sprints.openapi.json")
+
+const openAPIFile = "../../proto/sprints.openapi.json"
+
+func gatewayCall(t *testing.T, url, method, token, body string) *http.Response {
+	req, err := http.NewRequest(http.MethodPost, url+gatewayPrefix+method, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestGateway(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+	server := httptest.NewServer(setupGateway(s))
+	defer server.Close()
+
+	resp := gatewayCall(t, server.URL, "NewRace", "",
+		`{"players": [{"name": "first"}, {"name": "second"}], "destValue": 20}`)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("NewRace: %s", resp.Status)
+	}
+	if resp = gatewayCall(t, server.URL, "StartRace", "", ""); resp.StatusCode != http.StatusOK {
+		t.Fatalf("StartRace: %s", resp.Status)
+	}
+	waitForRace(t, s)
+
+	resp = gatewayCall(t, server.URL, "GetResults", "", `{"gender": "MALE"}`)
+	if contentType := resp.Header.Get("Content-Type"); contentType != ndjsonType {
+		t.Errorf("results should be streamed as NDJSON, not %s", contentType)
+	}
+	var (
+		scanner = bufio.NewScanner(resp.Body)
+		results []*pb.Result
+	)
+	for scanner.Scan() {
+		result := &pb.Result{}
+		if err := jsonpb.UnmarshalString(scanner.Text(), result); err != nil {
+			t.Fatal(err)
+		}
+		results = append(results, result)
+	}
+	resp.Body.Close()
+	if len(results) != 2 {
+		t.Errorf("race should have 2 results, got %d", len(results))
+	}
+
+	resp = gatewayCall(t, server.URL, "GetCurrentTournament", "", "")
+	tournament := &pb.Tournament{}
+	if err := jsonpb.Unmarshal(resp.Body, tournament); err != nil {
+		t.Fatal(err)
+	}
+	if tournament.Name != "concurrent" {
+		t.Errorf("current tournament should be sent, got %s", tournament.Name)
+	}
+
+	for _, c := range []struct {
+		method, body string
+		status       int
+	}{
+		{"NoSuchMethod", "", http.StatusNotFound},
+		{"NewRace", "{not json", http.StatusBadRequest},
+		{"Pair", `{"code": "123456"}`, http.StatusBadRequest},
+	} {
+		if resp = gatewayCall(t, server.URL, c.method, "", c.body); resp.StatusCode != c.status {
+			t.Errorf("%s should respond with %d, not %s", c.method, c.status, resp.Status)
+		}
+	}
+}
+
+func TestGatewayCrossSite(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+	server := httptest.NewServer(setupGateway(s))
+	defer server.Close()
+
+	for _, c := range []struct {
+		method, path, contentType string
+		status                    int
+	}{
+		{http.MethodGet, "GetCurrentTournament", "", http.StatusOK},
+		{http.MethodGet, "AbortRace", "", http.StatusMethodNotAllowed},
+		{http.MethodPost, "AbortRace", "text/plain", http.StatusBadRequest},
+		{http.MethodPost, "AbortRace", "application/x-www-form-urlencoded", http.StatusBadRequest},
+		{http.MethodPost, "DeleteTournament", "", http.StatusBadRequest},
+	} {
+		req, _ := http.NewRequest(c.method, server.URL+gatewayPrefix+c.path, nil)
+		if c.contentType != "" {
+			req.Header.Set("Content-Type", c.contentType)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != c.status {
+			t.Errorf("%s %s as %q should respond with %d, not %s", c.method, c.path, c.contentType, c.status, resp.Status)
+		}
+	}
+}
+
+func TestGatewayQuery(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+	newStoredTournament(t, s, "stored")
+	server := httptest.NewServer(setupGateway(s))
+	defer server.Close()
+
+	for _, c := range []struct {
+		query   string
+		status  int
+		results int
+	}{
+		{"tournament_name=stored&gender=MALE", http.StatusOK, 1},
+		{"tournamentName=stored&gender=0&last=1", http.StatusOK, 1},
+		{"tournament_name=stored&gender=FEMALE", http.StatusOK, 0},
+		{"gender=MALE", http.StatusOK, 0},
+		{"tournament_name=stored&last=many", http.StatusBadRequest, 0},
+		{"tournament_name=stored&tournament_name=other", http.StatusBadRequest, 0},
+		{"nonsense=1", http.StatusBadRequest, 0},
+	} {
+		resp, err := http.Get(server.URL + gatewayPrefix + "GetResults?" + c.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != c.status {
+			t.Errorf("%s should respond with %d, not %s", c.query, c.status, resp.Status)
+		} else if results := strings.Count(string(body), "\n"); c.status == http.StatusOK && results != c.results {
+			t.Errorf("%s should respond with %d results, got %d", c.query, c.results, results)
+		}
+	}
+}
+
+func TestGatewayAuth(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+	s.auth = testAuthenticator(t, core.ServerConfig{ViewerToken: "viewer"})
+	server := httptest.NewServer(setupGateway(s))
+	defer server.Close()
+
+	for _, c := range []struct {
+		method, token string
+		status        int
+	}{
+		{"GetCurrentTournament", "", http.StatusUnauthorized},
+		{"GetCurrentTournament", "viewer", http.StatusOK},
+		{"StartRace", "viewer", http.StatusForbidden},
+	} {
+		if resp := gatewayCall(t, server.URL, c.method, c.token, ""); resp.StatusCode != c.status {
+			t.Errorf("%s with %q token should respond with %d, not %s", c.method, c.token, c.status, resp.Status)
+		}
+	}
+}
+
+// TestOpenAPIDescription keeps the committed description in sync with the
+// service; run with -update_openapi after changing proto/sprints.proto
+func TestOpenAPIDescription(t *testing.T) {
+	g := setupGateway(&Sprints{})
+
+	if *updateOpenAPI {
+		if err := ioutil.WriteFile(openAPIFile, g.openAPI, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	committed, err := ioutil.ReadFile(openAPIFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(committed) != string(g.openAPI) {
+		t.Errorf("%s is out of date; run the test with -update_openapi", openAPIFile)
+	}
+	for name := range g.methods {
+		if !strings.Contains(string(g.openAPI), `"`+gatewayPrefix+name+`"`) {
+			t.Errorf("%s is not described", name)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kkoralsky/gosprints/core"
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+// ghostDeviceType is reported as the device type of ghost results
+const ghostDeviceType = "GHOST"
+
+// setupGhosts finds recorded performances the ghosts of the race should
+// repeat and appends a lane for every ghost after the players' lanes; ghosts
+// race without handicaps
+func (s *Sprints) setupGhosts(race *pb.Race) error {
+	s.ghosts = nil
+	if len(race.Ghosts) == 0 {
+		return nil
+	}
+	if s.tournament == nil {
+		return errNoTournament
+	}
+	if s.tournament.Mode == pb.Tournament_RELAY {
+		return errors.New("ghosts can't race in relays")
+	}
+	if len(race.Players) == 0 {
+		return errors.New("ghost needs someone to race against")
+	}
+
+	var ghosts []*pb.Result
+	for _, ghost := range race.Ghosts {
+		result, err := s.findGhost(ghost, race)
+		if err != nil {
+			return err
+		}
+		if len(result.Trace) == 0 {
+			return fmt.Errorf("performance of %s has no speed trace recorded", result.Player.Name)
+		}
+		last := result.Trace[len(result.Trace)-1]
+		if s.tournament.Mode != pb.Tournament_TIME && last.Distance < race.DestValue {
+			return fmt.Errorf("speed trace of %s doesn't reach the finish", result.Player.Name)
+		}
+		ghosts = append(ghosts, result)
+	}
+	for _, result := range ghosts {
+		race.Players = append(race.Players, &pb.Player{
+			Name:   fmt.Sprintf("%s (ghost)", result.Player.Name),
+			Gender: result.Player.Gender,
+		})
+		if len(race.Handicap) > 0 {
+			race.Handicap = append(race.Handicap, &pb.Handicap{})
+		}
+	}
+	s.ghosts = ghosts
+	return nil
+}
+
+// findGhost returns the recorded result the ghost is going to repeat
+func (s *Sprints) findGhost(ghost *pb.Ghost, race *pb.Race) (*pb.Result, error) {
+	var (
+		best   *pb.Result
+		gender = race.Players[0].Gender
+		better = func(result *pb.Result) {
+			if result.DestValue == race.DestValue && !isHandicapped(result) &&
+				(best == nil || core.IsBetterResult(s.tournament.Mode, result, best)) {
+				best = result
+			}
+		}
+	)
+	switch ghost.Source {
+	case pb.Ghost_PERSONAL_BEST:
+		playerName := ghost.PlayerName
+		if playerName == "" {
+			playerName = race.Players[0].Name
+		}
+		for _, record := range s.records.PersonalBests(playerName) {
+			if record.Mode == s.tournament.Mode {
+				better(record.Result)
+			}
+		}
+	case pb.Ghost_LEADER:
+		for _, result := range s.results[gender] {
+			better(result)
+		}
+	case pb.Ghost_RECORD:
+		for _, record := range s.records.AllTime(gender, "") {
+			if record.Mode == s.tournament.Mode {
+				better(record.Result)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown ghost source: %v", ghost.Source)
+	}
+	if best == nil {
+		return nil, fmt.Errorf("no %s performance to race against", ghost.Source)
+	}
+	return best, nil
+}
+
+// ghostTrace returns speed trace driving the given lane; nil if the lane
+// belongs to a player
+func (s *Sprints) ghostTrace(playerNum int) []*pb.TracePoint {
+	if i := playerNum - (len(s.curRace.Players) - len(s.ghosts)); i >= 0 && i < len(s.ghosts) {
+		return s.ghosts[i].Trace
+	}
+	return nil
+}
+
+// ghostDistance interpolates distance of the speed trace at the given time
+// since the start
+func ghostDistance(trace []*pb.TracePoint, at time.Duration) uint {
+	var (
+		ms   = float64(at) / float64(time.Millisecond)
+		prev = &pb.TracePoint{}
+	)
+	for _, point := range trace {
+		if float64(point.Time) >= ms {
+			if point.Time == prev.Time {
+				return uint(point.Distance)
+			}
+			ratio := (ms - float64(prev.Time)) / float64(point.Time-prev.Time)
+			return uint(float64(prev.Distance) + ratio*(float64(point.Distance)-float64(prev.Distance)))
+		}
+		prev = point
+	}
+	return uint(prev.Distance)
+}
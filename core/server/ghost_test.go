@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+func TestGhostDistance(t *testing.T) {
+	trace := []*pb.TracePoint{{Time: 1000, Distance: 10}, {Time: 2000, Distance: 30}}
+	for _, c := range []struct {
+		at       time.Duration
+		distance uint
+	}{
+		{0, 0},
+		{500 * time.Millisecond, 5},
+		{1500 * time.Millisecond, 20},
+		{2 * time.Second, 30},
+		{3 * time.Second, 30},
+	} {
+		if dist := ghostDistance(trace, c.at); dist != c.distance {
+			t.Errorf("ghost should be at %d after %v, not %d", c.distance, c.at, dist)
+		}
+	}
+}
+
+func TestSetupGhosts(t *testing.T) {
+	var (
+		tournament = &pb.Tournament{Name: "ghosts", Mode: pb.Tournament_DISTANCE, DestValue: 400}
+		rider      = &pb.Player{Name: "rider"}
+		trace      = []*pb.TracePoint{{Time: 20000, Distance: 400}}
+	)
+	tournament.Result = []*pb.Result{
+		{Player: rider, Result: 25000, DestValue: 400, Trace: trace},
+		{Player: rider, Result: 20000, DestValue: 400, Trace: trace},
+		{Player: rider, Result: 10000, DestValue: 200},
+	}
+	s := &Sprints{
+		tournament: tournament,
+		records:    SetupRecords([]*pb.Tournament{tournament}),
+		results:    map[pb.Gender][]*pb.Result{pb.Gender_MALE: tournament.Result},
+	}
+
+	race := &pb.Race{
+		Players:   []*pb.Player{rider},
+		DestValue: 400,
+		Ghosts:    []*pb.Ghost{{Source: pb.Ghost_PERSONAL_BEST}, {Source: pb.Ghost_LEADER}},
+	}
+	if err := s.setupGhosts(race); err != nil {
+		t.Fatal(err)
+	}
+	if len(race.Players) != 3 || len(s.ghosts) != 2 {
+		t.Fatalf("race should have 2 ghost lanes, got %d players", len(race.Players))
+	}
+	s.curRace = race
+	if s.ghostTrace(0) != nil || s.ghostTrace(1) == nil || s.ghostTrace(2) == nil {
+		t.Error("ghosts should drive the lanes after the rider")
+	}
+	if s.ghosts[0].Result != 20000 {
+		t.Errorf("ghost should repeat the personal best, not %.0f", s.ghosts[0].Result)
+	}
+
+	race = &pb.Race{
+		Players:   []*pb.Player{rider},
+		DestValue: 200,
+		Ghosts:    []*pb.Ghost{{Source: pb.Ghost_RECORD}},
+	}
+	if err := s.setupGhosts(race); err == nil {
+		t.Error("performance without speed trace shouldn't be raced")
+	}
+}
+
+func TestGhostsWithHandicaps(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+
+	s.tournament.Result = append(s.tournament.Result, &pb.Result{
+		Player:    &pb.Player{Name: "first"},
+		Result:    100,
+		DestValue: 50,
+		Trace:     []*pb.TracePoint{{Time: 100, Distance: 50}},
+	})
+	s.groupResults()
+	s.reloadRecords()
+
+	newRace := func(handicaps ...*pb.Handicap) *pb.Race {
+		race := newTestRace(50)
+		race.Ghosts = []*pb.Ghost{{Source: pb.Ghost_PERSONAL_BEST, PlayerName: "first"}}
+		race.Handicap = handicaps
+		return race
+	}
+	if _, err := s.NewRace(context.Background(), newRace(&pb.Handicap{}, &pb.Handicap{}, &pb.Handicap{})); err == nil {
+		t.Error("handicaps should be given for players only")
+	}
+	race := newRace(&pb.Handicap{DistanceBonus: 10}, nil)
+	if _, err := s.NewRace(context.Background(), race); err != nil {
+		t.Fatal(err)
+	}
+	if len(race.Players) != 3 || len(race.Handicap) != 3 || isHandicapped(&pb.Result{Handicap: race.Handicap[2]}) {
+		t.Fatalf("ghost lane should be added without handicap: %v %v", race.Players, race.Handicap)
+	}
+	if _, err := s.StartRace(context.Background(), &pb.Empty{}); err != nil {
+		t.Fatal(err)
+	}
+	waitForRace(t, s)
+
+	results := s.tournament.Result[1:]
+	if len(results) != 2 || results[0].Handicap.GetDistanceBonus() != 10 {
+		t.Errorf("players should race with their handicaps: %v", results)
+	}
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"fmt"
+
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+// setupHandicaps validates handicaps given with the race or derives them from
+// personal bests of the players
+func (s *Sprints) setupHandicaps(race *pb.Race) error {
+	if race.HandicapFromPersonalBests {
+		race.Handicap = s.personalBestHandicaps(race)
+	}
+	if len(race.Handicap) == 0 {
+		return nil
+	}
+	if s.tournament.Mode == pb.Tournament_ELIMINATION || s.tournament.Mode == pb.Tournament_PURSUIT {
+		return fmt.Errorf("handicaps are not supported in %s races", s.tournament.Mode)
+	}
+	if len(race.Handicap) != len(race.Players) {
+		return fmt.Errorf("%d handicaps given for %d players", len(race.Handicap), len(race.Players))
+	}
+	for i, handicap := range race.Handicap {
+		if handicap == nil {
+			race.Handicap[i] = &pb.Handicap{}
+		} else if s.tournament.Mode != pb.Tournament_TIME && handicap.DistanceBonus >= race.DestValue {
+			return fmt.Errorf("distance bonus of %s exceeds the race distance", race.Players[i].Name)
+		} else if s.tournament.Mode == pb.Tournament_TIME && handicap.StartDelay >= race.DestValue*1000 {
+			return fmt.Errorf("start delay of %s exceeds the race time", race.Players[i].Name)
+		}
+	}
+	return nil
+}
+
+// personalBestHandicaps evens out chances of the players: in distance
+// constrained and relay races faster players start later by the difference of their
+// personal bests, in time constrained ones slower players get the difference
+// of distances ahead; players without personal best get no handicap
+func (s *Sprints) personalBestHandicaps(race *pb.Race) []*pb.Handicap {
+	var (
+		handicaps = make([]*pb.Handicap, len(race.Players))
+		bests     = make([]float32, len(race.Players))
+		reference float32
+	)
+	for i, player := range race.Players {
+		for _, record := range s.records.PersonalBests(player.Name) {
+			if record.Mode == s.tournament.Mode && record.Result.DestValue == race.DestValue {
+				bests[i] = record.Result.Result
+			}
+		}
+		if bests[i] > reference {
+			reference = bests[i]
+		}
+	}
+	for i, best := range bests {
+		handicaps[i] = &pb.Handicap{}
+		if best == 0 {
+			continue
+		}
+		if s.tournament.Mode != pb.Tournament_TIME {
+			handicaps[i].StartDelay = uint32(reference - best)
+		} else if s.distFactor > 0 {
+			handicaps[i].DistanceBonus = uint32((reference - best) * 100 / float32(s.distFactor))
+		}
+	}
+	return handicaps
+}
+
+// getHandicap returns handicap of the player of the current race
+func (s *Sprints) getHandicap(playerNum int) *pb.Handicap {
+	if playerNum < len(s.curRace.Handicap) {
+		return s.curRace.Handicap[playerNum]
+	}
+	return &pb.Handicap{}
+}
+
+func isHandicapped(result *pb.Result) bool {
+	return result.Handicap != nil && (result.Handicap.StartDelay > 0 || result.Handicap.DistanceBonus > 0)
+}
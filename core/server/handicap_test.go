@@ -0,0 +1,47 @@
+package server
+
+import (
+	"testing"
+
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+func TestPersonalBestHandicaps(t *testing.T) {
+	var (
+		tournament = &pb.Tournament{Name: "handicaps", Mode: pb.Tournament_DISTANCE, DestValue: 400}
+		fast       = &pb.Player{Name: "fast"}
+		slow       = &pb.Player{Name: "slow"}
+		newbie     = &pb.Player{Name: "newbie"}
+	)
+	tournament.Result = []*pb.Result{
+		{Player: fast, Result: 20000, DestValue: 400},
+		{Player: slow, Result: 26000, DestValue: 400},
+		{Player: slow, Result: 25000, DestValue: 400},
+		{Player: fast, Result: 10000, DestValue: 200},
+	}
+	s := &Sprints{tournament: tournament, records: SetupRecords([]*pb.Tournament{tournament})}
+
+	race := &pb.Race{
+		Players:                   []*pb.Player{fast, slow, newbie},
+		DestValue:                 400,
+		HandicapFromPersonalBests: true,
+	}
+	if err := s.setupHandicaps(race); err != nil {
+		t.Fatal(err)
+	}
+	for i, delay := range []uint32{5000, 0, 0} {
+		if race.Handicap[i].StartDelay != delay {
+			t.Errorf("start delay of %s should be %d, not %d", race.Players[i].Name, delay,
+				race.Handicap[i].StartDelay)
+		}
+	}
+
+	race = &pb.Race{
+		Players:   []*pb.Player{fast, slow},
+		DestValue: 400,
+		Handicap:  []*pb.Handicap{{DistanceBonus: 400}, nil},
+	}
+	if err := s.setupHandicaps(race); err == nil {
+		t.Error("distance bonus exceeding the race distance should be rejected")
+	}
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+
+	"github.com/gobuffalo/packr"
+	"github.com/kkoralsky/gosprints/core"
+)
+
+// HTTPServer serves JSON gateway of the Sprints service for browsers and
+// scripts along with the web control panel and the live feed
+type HTTPServer struct {
+	port     uint
+	server   *http.Server
+	listener net.Listener
+	Sprints  *Sprints
+	Feed     *LiveFeed
+}
+
+func (h *HTTPServer) Run() {
+	if err := h.server.Serve(h.listener); err != nil && err != http.ErrServerClosed {
+		core.ErrorLogger.Printf("http server: %v", err)
+	}
+}
+
+func (h *HTTPServer) Stop() {
+	h.server.Close()
+}
+
+// SetupHTTPServer listens on the port; with tlsConfig given HTTPS is served
+func SetupHTTPServer(port uint, sprints *Sprints, tlsConfig *tls.Config) (*HTTPServer, error) {
+	var (
+		h = &HTTPServer{
+			port:    port,
+			Sprints: sprints,
+			Feed:    NewLiveFeed(),
+		}
+		mux = http.NewServeMux()
+		err error
+	)
+	mux.Handle(gatewayPrefix, setupGateway(sprints))
+	mux.Handle(liveFeedPath, h.Feed.handler(sprints))
+	mux.Handle("/", http.FileServer(packr.NewBox("./web")))
+	h.server = &http.Server{Handler: mux}
+	sprints.visMux.AddVisual(h.Feed)
+
+	if h.listener, err = net.ListenTCP("tcp", &net.TCPAddr{Port: int(port)}); err != nil {
+		return h, err
+	}
+	if tlsConfig != nil {
+		h.listener = tls.NewListener(h.listener, tlsConfig)
+	}
+	return h, nil
+}
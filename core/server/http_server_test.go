@@ -0,0 +1,45 @@
+package server
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWebUI(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+	h, err := SetupHTTPServer(0, s, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.listener.Close()
+	server := httptest.NewServer(h.server.Handler)
+	defer server.Close()
+
+	for path, content := range map[string]string{
+		"/":             "<script src=\"app.js\">",
+		"/app.js":       "GetCurrentTournament",
+		"/style.css":    "body",
+		"/overlay.html": liveFeedPath,
+	} {
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), content) {
+			t.Errorf("%s should be served, got %s", path, resp.Status)
+		}
+	}
+	resp, err := http.Post(server.URL+gatewayPrefix+"GetCurrentTournament", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("gateway should be served along the panel, got %s", resp.Status)
+	}
+}
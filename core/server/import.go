@@ -0,0 +1,220 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kkoralsky/gosprints/core"
+	"github.com/kkoralsky/gosprints/core/importer"
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+func (s *Sprints) ImportPlayers(_ context.Context, importSpec *pb.ImportSpec) (*pb.ImportReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tournament, err := s.getImportTournament(importSpec.TournamentName)
+	if err != nil {
+		return nil, err
+	}
+	players, errs := importer.Players(bytes.NewReader(importSpec.Content), importSpec.Format)
+	report := importPlayers(tournament, players, errs, importSpec.DryRun)
+
+	return report, s.saveImport(tournament, report)
+}
+
+func (s *Sprints) ImportResults(_ context.Context, importSpec *pb.ImportSpec) (*pb.ImportReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tournament, err := s.getImportTournament(importSpec.TournamentName)
+	if err != nil {
+		return nil, err
+	}
+	results, errs := importer.Results(bytes.NewReader(importSpec.Content), importSpec.Format, tournament)
+	report := importResults(tournament, results, errs, importSpec.DryRun)
+
+	return report, s.saveImport(tournament, report)
+}
+
+func (s *Sprints) getImportTournament(name string) (*pb.Tournament, error) {
+	tournament, err := s.getTournament(name)
+	if err != nil {
+		return nil, err
+	}
+	if tournament == s.tournament && s.curRace != nil {
+		return nil, errors.New("race in progress")
+	}
+	return tournament, checkLegacyResults(tournament)
+}
+
+func (s *Sprints) saveImport(tournament *pb.Tournament, report *pb.ImportReport) error {
+	if report.DryRun || report.Imported == 0 {
+		return nil
+	}
+	return s.saveCorrection(tournament)
+}
+
+// Import reads players and/or historic results from the files into the
+// tournament stored in the database. It's refused while the server uses the
+// database, since the server would overwrite the import with its next save;
+// ImportPlayers and ImportResults of the running server are there instead.
+func Import(cfg core.ImportConfig) error {
+	var tournament *pb.Tournament
+
+	dbLock, err := lockDb(cfg.DbPath)
+	if err != nil {
+		return err
+	}
+	defer dbLock.Close()
+
+	sprintsDb, err := SetupSprintsDb(cfg.DbBackend, cfg.DbPath, 0)
+	if err != nil {
+		return err
+	}
+	defer sprintsDb.Close()
+
+	if cfg.Tournament == "" {
+		tournament, err = sprintsDb.GetLastTournament()
+	} else {
+		tournament, err = sprintsDb.GetTournament(cfg.Tournament)
+	}
+	if err != nil {
+		return err
+	}
+	if err = checkLegacyResults(tournament); err != nil {
+		return err
+	}
+
+	var imported uint32
+	for _, file := range []struct {
+		path    string
+		results bool
+	}{{cfg.Players, false}, {cfg.Results, true}} {
+		if file.path == "" {
+			continue
+		}
+		report, err := importFile(tournament, file.path, cfg.Format, file.results, cfg.DryRun)
+		if err != nil {
+			return err
+		}
+		logImportReport(tournament, file.path, report)
+		if len(report.Error) > 0 {
+			return fmt.Errorf("%s: %d invalid entries; nothing imported", file.path, len(report.Error))
+		}
+		imported += report.Imported
+	}
+	if cfg.DryRun || imported == 0 {
+		return nil
+	}
+	return sprintsDb.SaveTournament(tournament)
+}
+
+func importFile(tournament *pb.Tournament, path, format string, historic, dryRun bool) (*pb.ImportReport, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+	if historic {
+		results, errs := importer.Results(f, format, tournament)
+		return importResults(tournament, results, errs, dryRun), nil
+	}
+	players, errs := importer.Players(f, format)
+	return importPlayers(tournament, players, errs, dryRun), nil
+}
+
+// importPlayers registers new players in the tournament unless there are any
+// invalid entries or it's a dry run
+func importPlayers(tournament *pb.Tournament, players []*pb.Player, errs []error, dryRun bool) *pb.ImportReport {
+	added, duplicates := importer.NewPlayers(tournament, players)
+	report := newImportReport(uint32(len(added)), duplicates, errs, dryRun)
+
+	if !dryRun && report.Imported > 0 {
+		tournament.Player = append(tournament.Player, added...)
+		tournament.Audit = append(tournament.Audit, &pb.AuditEntry{
+			Timestamp: time.Now().Unix(),
+			Action:    "import",
+			Details:   fmt.Sprintf("%d players, %d duplicates skipped", len(added), len(duplicates)),
+		})
+	}
+	return report
+}
+
+// setupCategories gives players and relay riders of the race the categories
+// they're registered with, unless the race gives them any
+func (s *Sprints) setupCategories(race *pb.Race) {
+	if len(s.tournament.Player) == 0 {
+		return
+	}
+	var categories = make(map[string]string, len(s.tournament.Player))
+	for _, player := range s.tournament.Player {
+		categories[playerKey(player.Name)] = player.Category
+	}
+	var players = append([]*pb.Player{}, race.Players...)
+	for _, team := range race.Teams {
+		players = append(players, team.Riders...)
+	}
+	for _, player := range players {
+		if player.Category == "" {
+			player.Category = categories[playerKey(player.Name)]
+		}
+	}
+}
+
+// importResults appends new results to the tournament unless there are any
+// invalid entries or it's a dry run; imported results don't belong to any race
+func importResults(tournament *pb.Tournament, results []*pb.Result, errs []error, dryRun bool) *pb.ImportReport {
+	added, duplicates := importer.NewResults(tournament, results)
+	report := newImportReport(uint32(len(added)), duplicates, errs, dryRun)
+
+	if !dryRun && report.Imported > 0 {
+		for _, result := range added {
+			tournament.LastResultId++
+			result.Id = tournament.LastResultId
+			tournament.Result = append(tournament.Result, result)
+		}
+		tournament.Audit = append(tournament.Audit, &pb.AuditEntry{
+			Timestamp: time.Now().Unix(),
+			Action:    "import",
+			Details:   fmt.Sprintf("%d results, %d duplicates skipped", len(added), len(duplicates)),
+		})
+	}
+	return report
+}
+
+func newImportReport(imported uint32, duplicates []string, errs []error, dryRun bool) *pb.ImportReport {
+	var report = &pb.ImportReport{Duplicate: duplicates, DryRun: dryRun}
+
+	for _, err := range errs {
+		report.Error = append(report.Error, err.Error())
+	}
+	if len(errs) == 0 {
+		report.Imported = imported
+	}
+	return report
+}
+
+func logImportReport(tournament *pb.Tournament, path string, report *pb.ImportReport) {
+	for _, duplicate := range report.Duplicate {
+		core.InfoLogger.Printf("%s: duplicate skipped: %s", path, duplicate)
+	}
+	for _, err := range report.Error {
+		core.ErrorLogger.Printf("%s: %s", path, err)
+	}
+	if report.DryRun {
+		core.InfoLogger.Printf("%s: %d entries would be imported into %s", path, report.Imported, tournament.Name)
+	} else {
+		core.InfoLogger.Printf("%s: %d entries imported into %s", path, report.Imported, tournament.Name)
+	}
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kkoralsky/gosprints/core"
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+func TestImportPlayers(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+
+	var spec = &pb.ImportSpec{
+		Format:  "csv",
+		Content: []byte("name,gender,category\nFirst,male,junior\nthird,female,\n"),
+		DryRun:  true,
+	}
+	if report, err := s.ImportPlayers(context.Background(), spec); err != nil || report.Imported != 2 {
+		t.Fatalf("2 players should be imported on dry run, got %v: %v", report, err)
+	}
+	if len(s.tournament.Player) != 0 || len(s.tournament.Audit) != 0 {
+		t.Errorf("dry run shouldn't change the tournament: %v", s.tournament)
+	}
+
+	spec.DryRun = false
+	if _, err := s.ImportPlayers(context.Background(), spec); err != nil {
+		t.Fatal(err)
+	}
+	if report, err := s.ImportPlayers(context.Background(), spec); err != nil || len(report.Duplicate) != 2 {
+		t.Fatalf("players shouldn't be registered twice, got %v: %v", report, err)
+	}
+	stored, _ := s.sprintsDb.GetTournament("concurrent")
+	if len(stored.Player) != 2 || len(stored.Audit) != 1 || stored.Audit[0].Action != "import" {
+		t.Errorf("import should be audited once: %v", stored)
+	}
+
+	if _, err := s.NewRace(context.Background(), newTestRace(50)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.StartRace(context.Background(), &pb.Empty{}); err != nil {
+		t.Fatal(err)
+	}
+	waitForRace(t, s)
+
+	var categories = make(map[string]string)
+	for _, result := range s.tournament.Result {
+		categories[result.Player.Name] = result.Player.Category
+	}
+	if len(categories) != 2 || categories["first"] != "junior" || categories["second"] != "" {
+		t.Errorf("results should be given the registered categories, got %v", categories)
+	}
+}
+
+func TestImportRefusesDbInUse(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gosprints-import")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var cfg = core.ImportConfig{
+		DbPath:    filepath.Join(dir, "sprints.pb"),
+		DbBackend: ProtoBackend,
+		Players:   filepath.Join(dir, "players.csv"),
+	}
+	if err = ioutil.WriteFile(cfg.Players, []byte("name,gender\nfirst,male\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sprintsDb, err := SetupSprintsDb(cfg.DbBackend, cfg.DbPath, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = sprintsDb.SaveTournament(&pb.Tournament{Name: "stored"}); err != nil {
+		t.Fatal(err)
+	}
+	sprintsDb.Close()
+
+	// as if the server was running
+	dbLock, err := lockDb(cfg.DbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = Import(cfg); err == nil {
+		t.Error("import shouldn't write into the db in use")
+	}
+	dbLock.Close()
+
+	if err = Import(cfg); err != nil {
+		t.Fatal(err)
+	}
+	sprintsDb, _ = SetupSprintsDb(cfg.DbBackend, cfg.DbPath, 0)
+	if tournament, _ := sprintsDb.GetTournament("stored"); len(tournament.Player) != 1 {
+		t.Errorf("player should be imported once the db is released: %v", tournament)
+	}
+}
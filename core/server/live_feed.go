@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/kkoralsky/gosprints/core"
+	"github.com/kkoralsky/gosprints/core/feed"
+	pb "github.com/kkoralsky/gosprints/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// liveFeedPath is the WebSocket endpoint publishing what happens on the
+// visuals for overlays and leaderboards; see package feed for the schema.
+// Viewer token is required when auth is enabled; browsers can't set headers
+// on WebSocket so it may be given as the token query parameter
+const (
+	liveFeedPath = "/live"
+	// liveFeedMethod is checked against roles of the Sprints methods
+	liveFeedMethod = "LiveFeed"
+)
+
+// LiveFeed is the visual forwarding race events to the WebSocket clients
+type LiveFeed struct {
+	*feed.Feed
+}
+
+func NewLiveFeed() *LiveFeed {
+	return &LiveFeed{feed.New()}
+}
+
+// handler authorizes the client before upgrading the connection
+func (f *LiveFeed) handler(sprints *Sprints) http.Handler {
+	var ws = f.Handler()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			ctx   = r.Context()
+			token = r.Header.Get("Authorization")
+		)
+		if token == "" {
+			token = r.URL.Query().Get("token")
+		}
+		if token != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(core.AuthMetadataKey, token))
+		}
+		if err := sprints.auth.authorize(ctx, sprintsService+liveFeedMethod); err != nil {
+			writeError(w, err)
+			return
+		}
+		ws.ServeHTTP(w, r)
+	})
+}
+
+func (f *LiveFeed) NewTournament(_ context.Context, tournament *pb.Tournament, _ ...grpc.CallOption) (*pb.Empty, error) {
+	f.Send(feed.Tournament, tournament)
+	return &pb.Empty{}, nil
+}
+
+func (f *LiveFeed) NewRace(_ context.Context, race *pb.Race, _ ...grpc.CallOption) (*pb.Empty, error) {
+	f.Send(feed.Race, race)
+	return &pb.Empty{}, nil
+}
+
+func (f *LiveFeed) StartRace(_ context.Context, starter *pb.Starter, _ ...grpc.CallOption) (*pb.Empty, error) {
+	f.Send(feed.Countdown, starter)
+	return &pb.Empty{}, nil
+}
+
+func (f *LiveFeed) AbortRace(_ context.Context, abortMessage *pb.AbortMessage, _ ...grpc.CallOption) (*pb.Empty, error) {
+	f.Send(feed.Abort, abortMessage)
+	return &pb.Empty{}, nil
+}
+
+func (f *LiveFeed) UpdateRace(context.Context, ...grpc.CallOption) (pb.Visual_UpdateRaceClient, error) {
+	return &liveRacer{feed: f}, nil
+}
+
+func (f *LiveFeed) SwapRiders(_ context.Context, swap *pb.Swap, _ ...grpc.CallOption) (*pb.Empty, error) {
+	f.Send(feed.Swap, swap)
+	return &pb.Empty{}, nil
+}
+
+func (f *LiveFeed) EliminateRider(_ context.Context, elimination *pb.Elimination, _ ...grpc.CallOption) (*pb.Empty, error) {
+	f.Send(feed.Elimination, elimination)
+	return &pb.Empty{}, nil
+}
+
+func (f *LiveFeed) FinishRace(_ context.Context, results *pb.Results, _ ...grpc.CallOption) (*pb.Empty, error) {
+	f.Send(feed.Finish, results)
+	return &pb.Empty{}, nil
+}
+
+func (f *LiveFeed) ShowResults(_ context.Context, results *pb.Results, _ ...grpc.CallOption) (*pb.Empty, error) {
+	f.Send(feed.Results, results)
+	return &pb.Empty{}, nil
+}
+
+// ConfigureVis and StopVis concern the screens only
+func (f *LiveFeed) ConfigureVis(context.Context, *pb.VisConfiguration, ...grpc.CallOption) (*pb.Empty, error) {
+	return &pb.Empty{}, nil
+}
+
+func (f *LiveFeed) StopVis(context.Context, *pb.Empty, ...grpc.CallOption) (*pb.Empty, error) {
+	return &pb.Empty{}, nil
+}
+
+// GetConfiguration isn't asked for as the feed isn't dialed
+func (f *LiveFeed) GetConfiguration(context.Context, *pb.Empty, ...grpc.CallOption) (*pb.VisConfiguration, error) {
+	return &pb.VisConfiguration{}, nil
+}
+
+// ShowPairingCode is never published as the feed is open to viewers
+func (f *LiveFeed) ShowPairingCode(context.Context, *pb.PairingCode, ...grpc.CallOption) (*pb.Empty, error) {
+	return &pb.Empty{}, nil
+}
+
+// liveRacer publishes the lane updates of the race
+type liveRacer struct {
+	grpc.ClientStream
+	feed *LiveFeed
+}
+
+func (r *liveRacer) Send(racer *pb.Racer) error {
+	r.feed.Send(feed.Update, racer)
+	return nil
+}
+
+func (r *liveRacer) CloseSend() error                 { return nil }
+func (r *liveRacer) CloseAndRecv() (*pb.Empty, error) { return &pb.Empty{}, nil }
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kkoralsky/gosprints/core"
+	pb "github.com/kkoralsky/gosprints/proto"
+	"golang.org/x/net/websocket"
+)
+
+func dialLiveFeed(t *testing.T, url, query string) (*websocket.Conn, error) {
+	return websocket.Dial(strings.Replace(url, "http", "ws", 1)+liveFeedPath+query, "", url)
+}
+
+type liveEvent struct {
+	Event string
+	Data  json.RawMessage
+}
+
+func receiveEvent(t *testing.T, ws *websocket.Conn) liveEvent {
+	var (
+		frame string
+		event liveEvent
+	)
+	if err := websocket.Message.Receive(ws, &frame); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(frame), &event); err != nil {
+		t.Fatal(err)
+	}
+	return event
+}
+
+func TestLiveFeed(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+	h, err := SetupHTTPServer(0, s, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.listener.Close()
+	server := httptest.NewServer(h.server.Handler)
+	defer server.Close()
+
+	ws, err := dialLiveFeed(t, server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+
+	if event := receiveEvent(t, ws); event.Event != "tournament" || !strings.Contains(string(event.Data), "concurrent") {
+		t.Errorf("current tournament should be sent first, got %s", event.Event)
+	}
+	if _, err := s.NewRace(context.Background(), newTestRace(50)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.StartRace(context.Background(), &pb.Empty{}); err != nil {
+		t.Fatal(err)
+	}
+
+	var events = make(map[string]int)
+	for events["finish"] == 0 {
+		events[receiveEvent(t, ws).Event]++
+	}
+	for _, event := range []string{"race", "countdown", "update"} {
+		if events[event] == 0 {
+			t.Errorf("%s event should be sent", event)
+		}
+	}
+	waitForRace(t, s)
+
+	// late client catches up with the tournament, race and its finish
+	late, err := dialLiveFeed(t, server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer late.Close()
+	for _, expected := range []string{"tournament", "race", "finish"} {
+		if event := receiveEvent(t, late); event.Event != expected {
+			t.Errorf("%s event should be repeated, got %s", expected, event.Event)
+		}
+	}
+}
+
+func TestLiveFeedAuth(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+	s.auth = testAuthenticator(t, core.ServerConfig{ViewerToken: "viewer"})
+	h, err := SetupHTTPServer(0, s, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.listener.Close()
+	server := httptest.NewServer(h.server.Handler)
+	defer server.Close()
+
+	if ws, err := dialLiveFeed(t, server.URL, ""); err == nil {
+		ws.Close()
+		t.Error("live feed shouldn't be open without token")
+	}
+	ws, err := dialLiveFeed(t, server.URL, "?token=viewer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ws.Close()
+}
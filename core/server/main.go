@@ -2,6 +2,7 @@ package server
 
 import (
 	"github.com/kkoralsky/gosprints/core"
+	"github.com/kkoralsky/gosprints/core/certs"
 	"github.com/kkoralsky/gosprints/core/device"
 )
 
@@ -16,24 +17,56 @@ func SprintsServer(cfg core.ServerConfig) {
 		panic(err)
 	}
 
-	visMux, err := SetupVisMux(cfg.OutputVisuals)
+	visTransport, err := certs.DialOption(cfg.TLSCA, cfg.TLSCert, cfg.TLSKey)
 	if err != nil {
 		panic(err)
 	}
+	visMux := SetupVisMux(cfg.OutputVisuals, visTransport)
 
-	sprintsDb, err := SetupSprintsDb(cfg.DbPath)
+	var clientCA string
+	if cfg.TLSClientAuth {
+		clientCA = cfg.TLSCA
+	}
+	tlsConfig, err := certs.ServerConfig(cfg.TLSCert, cfg.TLSKey, clientCA)
+	if err != nil {
+		panic(err)
+	}
+
+	dbLock, err := lockDb(cfg.DbPath)
+	if err != nil {
+		panic(err)
+	}
+	defer dbLock.Close()
+	sprintsDb, err := SetupSprintsDb(cfg.DbBackend, cfg.DbPath, cfg.DbBackups)
+	if err != nil {
+		panic(err)
+	}
 	defer sprintsDb.Close()
+	sprints, err := SetupSprints(devicePoller, visMux, sprintsDb, cfg)
 	if err != nil {
 		panic(err)
 	}
-	cmdServer, err := SetupCmdServer(
-		cfg.Port, cfg.GrpcDebug,
-		SetupSprints(devicePoller, visMux, sprintsDb, cfg.CountDownTime),
-	)
+	cmdServer, err := SetupCmdServer(cfg.Port, cfg.GrpcDebug, sprints, certs.ServerOptions(tlsConfig)...)
 	if err != nil {
 		panic(err)
 	}
 
+	var httpServer *HTTPServer
+	if cfg.HTTPPort > 0 {
+		if httpServer, err = SetupHTTPServer(cfg.HTTPPort, cmdServer.Sprints, tlsConfig); err != nil {
+			panic(err)
+		}
+		go httpServer.Run()
+	}
+
+	var discovery *Discovery
+	if cfg.DiscoveryPort > 0 {
+		if discovery, err = SetupDiscovery(cfg.DiscoveryPort, visMux, cmdServer.Sprints.auth); err != nil {
+			panic(err)
+		}
+		go discovery.Run()
+	}
+
 	if err := devicePoller.Start(); err != nil {
 		panic(err)
 	}
@@ -42,6 +75,12 @@ func SprintsServer(cfg core.ServerConfig) {
 		devicePoller.Close()
 		sprintsDb.Close()
 		cmdServer.Stop()
+		if httpServer != nil {
+			httpServer.Stop()
+		}
+		if discovery != nil {
+			discovery.Stop()
+		}
 	})
 
 	cmdServer.Run()
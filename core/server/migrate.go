@@ -0,0 +1,74 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/kkoralsky/gosprints/core"
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+// Migrate copies all the tournaments between databases of possibly different
+// backends; tournaments already present in the destination are replaced.
+// Given the distance factor, results of time constrained tournaments stored
+// in device units get converted into meters on the way; the source database
+// is left untouched as the backup.
+func Migrate(cfg core.MigrateConfig) error {
+	from, err := setupStorage(cfg.FromBackend, cfg.From, 0)
+	if err != nil {
+		return err
+	}
+	defer from.Close()
+
+	to, err := setupStorage(cfg.ToBackend, cfg.To, 0)
+	if err != nil {
+		return err
+	}
+	defer to.Close()
+
+	tournaments, err := from.GetTournaments()
+	if err != nil {
+		return err
+	}
+	for _, tournament := range tournaments {
+		if legacyResults(tournament) {
+			if cfg.DistFactor > 0 {
+				convertToMeters(tournament, cfg.DistFactor)
+			} else {
+				core.ErrorLogger.Printf("%s: results left in device units; give -dist_factor to convert them",
+					tournament.Name)
+			}
+		}
+		if err = to.SaveTournament(tournament); err != nil {
+			return err
+		}
+		core.InfoLogger.Printf("%s: %d results migrated", tournament.Name, len(tournament.Result))
+	}
+	core.InfoLogger.Printf("%d tournaments migrated from %s to %s", len(tournaments), cfg.From, cfg.To)
+	return nil
+}
+
+// legacyResults tells whether results of the time constrained tournament are
+// stored in device units as they used to be
+func legacyResults(tournament *pb.Tournament) bool {
+	return tournament.Mode == pb.Tournament_TIME && !tournament.ResultsInMeters
+}
+
+// checkLegacyResults returns error for tournament with results which need to
+// be converted before they're used along with the others
+func checkLegacyResults(tournament *pb.Tournament) error {
+	if legacyResults(tournament) {
+		return fmt.Errorf("results of %s are in device units; convert them with migrate -dist_factor",
+			tournament.Name)
+	}
+	return nil
+}
+
+// convertToMeters converts results of time constrained tournament stored in
+// device units into meters
+func convertToMeters(tournament *pb.Tournament, distFactor uint) {
+	for _, result := range tournament.Result {
+		result.Result = core.Meters(result.Result, distFactor)
+	}
+	tournament.ResultsInMeters = true
+	core.InfoLogger.Printf("%s: %d results converted into meters", tournament.Name, len(tournament.Result))
+}
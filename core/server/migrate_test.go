@@ -0,0 +1,115 @@
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/kkoralsky/gosprints/core"
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+func TestMigrate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gosprints-migrate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var (
+		pbFile   = filepath.Join(dir, "sprints.pb")
+		boltFile = filepath.Join(dir, "sprints.db")
+		backFile = filepath.Join(dir, "back.pb")
+		original = []*pb.Tournament{
+			{Name: "sprint", Mode: pb.Tournament_DISTANCE, DestValue: 400,
+				Result: []*pb.Result{testResult("first", 20000), testResult("second", 21000)}},
+			{Name: "timed", Mode: pb.Tournament_TIME, DestValue: 30000, Archived: true,
+				Result: []*pb.Result{testResult("third", 250)}},
+		}
+	)
+	from, err := setupStorage(ProtoBackend, pbFile, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tournament := range original {
+		if err = from.SaveTournament(tournament); err != nil {
+			t.Fatal(err)
+		}
+	}
+	from.Close()
+
+	// there and back again
+	if err = Migrate(core.MigrateConfig{From: pbFile, FromBackend: ProtoBackend, To: boltFile, ToBackend: BoltBackend}); err != nil {
+		t.Fatal(err)
+	}
+	if err = Migrate(core.MigrateConfig{From: boltFile, FromBackend: BoltBackend, To: backFile, ToBackend: ProtoBackend}); err != nil {
+		t.Fatal(err)
+	}
+
+	back, err := setupStorage(ProtoBackend, backFile, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tournaments, _ := back.GetTournaments()
+	if len(tournaments) != len(original) {
+		t.Fatalf("%d tournaments should be migrated, got %d", len(original), len(tournaments))
+	}
+	for i, tournament := range tournaments {
+		if !proto.Equal(tournament, original[i]) {
+			t.Errorf("tournament should be migrated as it is:\n%v\n%v", tournament, original[i])
+		}
+	}
+}
+
+func TestMigrateConvertsLegacyResults(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gosprints-migrate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var (
+		legacyFile    = filepath.Join(dir, "legacy.pb")
+		convertedFile = filepath.Join(dir, "converted.pb")
+	)
+	legacy, err := setupStorage(ProtoBackend, legacyFile, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tournament := range []*pb.Tournament{
+		{Name: "legacy", Mode: pb.Tournament_TIME, DestValue: 30, Result: []*pb.Result{testResult("a", 240)}},
+		{Name: "converted", Mode: pb.Tournament_TIME, DestValue: 30, ResultsInMeters: true,
+			Result: []*pb.Result{testResult("b", 250)}},
+	} {
+		if err = legacy.SaveTournament(tournament); err != nil {
+			t.Fatal(err)
+		}
+	}
+	legacy.Close()
+
+	err = Migrate(core.MigrateConfig{
+		From: legacyFile, FromBackend: ProtoBackend,
+		To: convertedFile, ToBackend: ProtoBackend,
+		DistFactor: 125,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	converted, _ := setupStorage(ProtoBackend, convertedFile, 0)
+	for name, result := range map[string]float32{"legacy": 300, "converted": 250} {
+		tournament, err := converted.GetTournament(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !tournament.ResultsInMeters || tournament.Result[0].Result != result {
+			t.Errorf("%s should have result of %.0fm, got %v", name, result, tournament)
+		}
+	}
+	legacy, _ = setupStorage(ProtoBackend, legacyFile, 0)
+	if tournament, _ := legacy.GetTournament("legacy"); tournament.ResultsInMeters || tournament.Result[0].Result != 240 {
+		t.Errorf("source tournament should be left as it is: %v", tournament)
+	}
+}
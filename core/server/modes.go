@@ -0,0 +1,159 @@
+package server
+
+import (
+	"errors"
+	"time"
+
+	"github.com/kkoralsky/gosprints/core"
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+// setupRaceMode validates the race against rules of elimination and pursuit
+// tournaments
+func (s *Sprints) setupRaceMode(race *pb.Race) error {
+	switch s.tournament.Mode {
+	case pb.Tournament_ELIMINATION:
+		if len(race.Players) < 2 {
+			return errors.New("elimination race needs at least 2 players")
+		}
+		if race.DestValue == 0 {
+			return errors.New("interval between eliminations not given")
+		}
+	case pb.Tournament_PURSUIT:
+		if len(race.Players) != 2 {
+			return errors.New("pursuit race is for 2 players")
+		}
+		if s.tournament.StartOffset == 0 || s.tournament.StartOffset >= race.DestValue {
+			return errors.New("start offset of the pursuit should be within the race distance")
+		}
+	}
+	return nil
+}
+
+// raceOutcome keeps places and eliminations decided during the race
+type raceOutcome struct {
+	places     map[int]uint32
+	eliminated map[int]bool
+}
+
+func newRaceOutcome() *raceOutcome {
+	return &raceOutcome{
+		places:     make(map[int]uint32),
+		eliminated: make(map[int]bool),
+	}
+}
+
+// eliminate knocks the player out of the race at the given place
+func (s *Sprints) eliminate(outcome *raceOutcome, playerNum int, place, ms uint32, caught bool) {
+	outcome.places[playerNum] = place
+	outcome.eliminated[playerNum] = true
+	core.DebugLogger.Printf("player #%d eliminated at %d ms", playerNum, ms)
+	s.visMux.EliminateRider(&pb.Elimination{
+		PlayerNum: uint32(playerNum),
+		Player:    s.curRace.Players[playerNum],
+		Place:     place,
+		Time:      ms,
+		Caught:    caught,
+	})
+}
+
+// doEliminationRace knocks out the rider with the shortest distance every
+// interval until only one is left; results are miliseconds the riders stayed
+// in the race
+func (s *Sprints) doEliminationRace(start time.Time, abort <-chan struct{}, getDistance func(int) uint,
+	recorder *raceRecorder, outcome *raceOutcome) map[int]float32 {
+	var (
+		playersCount    = len(s.curRace.Players)
+		interval        = time.Duration(s.curRace.DestValue) * time.Second
+		nextElimination = start.Add(interval)
+		dists           = make(map[int]uint, playersCount)
+		stayed          = make(map[int]float32, playersCount)
+	)
+	for remaining := playersCount; remaining > 1; {
+		if aborted(abort) {
+			return nil
+		}
+		for i := 0; i < playersCount; i++ {
+			if !outcome.eliminated[i] {
+				dists[i] = getDistance(i)
+			}
+		}
+		if now := time.Now(); !now.Before(nextElimination) {
+			var (
+				hindmost = -1
+				ms       = milliseconds(now.Sub(start))
+			)
+			// on a tie the one who got to the distance later is out
+			for i := 0; i < playersCount; i++ {
+				if outcome.eliminated[i] {
+					continue
+				}
+				if hindmost < 0 || dists[i] < dists[hindmost] ||
+					(dists[i] == dists[hindmost] && recorder.lastPulse(i) > recorder.lastPulse(hindmost)) {
+					hindmost = i
+				}
+			}
+			stayed[hindmost] = float32(ms)
+			recorder.finish(hindmost, dists[hindmost], uint(ms))
+			s.eliminate(outcome, hindmost, uint32(remaining), ms, false)
+			remaining--
+			nextElimination = nextElimination.Add(interval)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	var ms = milliseconds(time.Now().Sub(start))
+	for i := 0; i < playersCount; i++ {
+		if !outcome.eliminated[i] {
+			stayed[i] = float32(ms)
+			outcome.places[i] = 1
+			recorder.finish(i, dists[i], uint(ms))
+		}
+	}
+	return stayed
+}
+
+// doPursuitRace lets the riders chase each other from the opposite sides of
+// the track: the one who makes up the start offset catches the other and wins
+// immediately, otherwise it's a race to the destination distance; results are
+// times of the finish or the catch
+func (s *Sprints) doPursuitRace(start time.Time, abort <-chan struct{}, getDistance func(int) uint,
+	recorder *raceRecorder, outcome *raceOutcome) map[int]float32 {
+	var (
+		wholeDistance = uint(s.curRace.DestValue)
+		offset        = uint(s.tournament.StartOffset)
+		dists         [2]uint
+		times         = make(map[int]float32, 2)
+	)
+	for len(times) < 2 {
+		if aborted(abort) {
+			return nil
+		}
+		for i := range dists {
+			if _, finished := times[i]; !finished {
+				dists[i] = getDistance(i)
+			}
+		}
+		var ms = milliseconds(time.Now().Sub(start))
+		for i := range dists {
+			var other = 1 - i
+			if _, finished := times[i]; finished {
+				continue
+			}
+			if _, finished := times[other]; !finished && dists[i] >= dists[other]+offset {
+				times[i], times[other] = float32(ms), float32(ms)
+				outcome.places[i] = 1
+				recorder.finish(i, dists[i], uint(ms))
+				recorder.finish(other, dists[other], uint(ms))
+				s.eliminate(outcome, other, 2, ms, true)
+				break
+			} else if dists[i] >= wholeDistance {
+				times[i] = float32(ms)
+				outcome.places[i] = uint32(len(times))
+				recorder.finish(i, wholeDistance, uint(ms))
+			}
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return times
+}
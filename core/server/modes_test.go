@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kkoralsky/gosprints/core"
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+func TestSetupRaceMode(t *testing.T) {
+	var (
+		players = []*pb.Player{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+		s       = &Sprints{tournament: &pb.Tournament{Mode: pb.Tournament_PURSUIT, StartOffset: 200}}
+	)
+	if err := s.setupRaceMode(&pb.Race{Players: players, DestValue: 1000}); err == nil {
+		t.Error("pursuit of 3 players should be rejected")
+	}
+	if err := s.setupRaceMode(&pb.Race{Players: players[:2], DestValue: 200}); err == nil {
+		t.Error("start offset not shorter than the distance should be rejected")
+	}
+	if err := s.setupRaceMode(&pb.Race{Players: players[:2], DestValue: 1000}); err != nil {
+		t.Error(err)
+	}
+
+	s.tournament = &pb.Tournament{Mode: pb.Tournament_ELIMINATION}
+	if err := s.setupRaceMode(&pb.Race{Players: players[:1], DestValue: 30}); err == nil {
+		t.Error("elimination of a single player should be rejected")
+	}
+	if err := s.setupRaceMode(&pb.Race{Players: players}); err == nil {
+		t.Error("elimination without interval should be rejected")
+	}
+}
+
+func TestDuplicatePursuitTournament(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+
+	_, err := s.NewTournament(context.Background(), &pb.Tournament{
+		Name: "pursuit", Mode: pb.Tournament_PURSUIT, DestValue: 1000, StartOffset: 200,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	duplicate, err := s.DuplicateTournament(context.Background(), &pb.TournamentRename{Name: "pursuit", NewName: "pursuit 2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if duplicate.StartOffset != 200 {
+		t.Errorf("start offset should be duplicated, got %d", duplicate.StartOffset)
+	}
+}
+
+func TestSortModeResults(t *testing.T) {
+	var (
+		winner = &pb.Result{Player: &pb.Player{Name: "winner"}, Result: 30000, Place: 1}
+		second = &pb.Result{Player: &pb.Player{Name: "second"}, Result: 20000, Place: 2}
+		caught = &pb.Result{Player: &pb.Player{Name: "caught"}, Result: 25000, Place: 2, Eliminated: true}
+		early  = &pb.Result{Player: &pb.Player{Name: "early"}, Result: 10000, Place: 2, Eliminated: true}
+		slow   = &pb.Result{Player: &pb.Player{Name: "slow"}, Result: 40000, Place: 2}
+	)
+	results := []*pb.Result{second, winner}
+	core.SortResults(results, pb.Tournament_ELIMINATION)
+	if results[0] != winner {
+		t.Errorf("longest stay should win elimination, got %s", results[0].Player.Name)
+	}
+
+	results = []*pb.Result{early, caught, slow, winner}
+	core.SortResults(results, pb.Tournament_PURSUIT)
+	for i, expected := range []*pb.Result{winner, slow, caught, early} {
+		if results[i] != expected {
+			t.Errorf("%s should be at %d. place in pursuit, not %s", expected.Player.Name, i+1,
+				results[i].Player.Name)
+		}
+	}
+}
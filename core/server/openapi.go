@@ -0,0 +1,148 @@
+package server
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// openAPIDescription describes the gateway methods and the messages they
+// take and return in OpenAPI 3 format
+func openAPIDescription(methods map[string]*gatewayMethod) map[string]interface{} {
+	var (
+		paths   = make(map[string]interface{})
+		schemas = make(map[string]interface{})
+	)
+	for name, m := range methods {
+		responseType := "application/json"
+		if m.stream != nil {
+			responseType = ndjsonType
+		}
+		paths[gatewayPrefix+name] = map[string]interface{}{
+			"post": map[string]interface{}{
+				"operationId": name,
+				"requestBody": map[string]interface{}{
+					"content": map[string]interface{}{
+						"application/json": map[string]interface{}{"schema": messageRef(m.request, schemas)},
+					},
+				},
+				"responses": map[string]interface{}{
+					"200": map[string]interface{}{
+						"description": m.response.Elem().Name(),
+						"content": map[string]interface{}{
+							responseType: map[string]interface{}{"schema": messageRef(m.response, schemas)},
+						},
+					},
+					"default": map[string]interface{}{
+						"description": "error",
+						"content": map[string]interface{}{
+							"application/json": map[string]interface{}{"schema": ref("Error")},
+						},
+					},
+				},
+			},
+		}
+	}
+	schemas["Error"] = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"error": map[string]interface{}{"type": "string"},
+			"code":  map[string]interface{}{"type": "integer", "description": "gRPC status code"},
+		},
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.0",
+		"info": map[string]interface{}{
+			"title":       "gosprints",
+			"version":     "v1",
+			"description": "JSON gateway of the Sprints gRPC service; server streams are sent as newline delimited JSON",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+			"securitySchemes": map[string]interface{}{
+				"token": map[string]interface{}{"type": "http", "scheme": "bearer"},
+			},
+		},
+		"security": []interface{}{map[string]interface{}{"token": []string{}}},
+	}
+}
+
+func ref(name string) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
+
+// messageRef describes the message among the schemas unless it's there
+// already and refers to it
+func messageRef(t reflect.Type, schemas map[string]interface{}) map[string]interface{} {
+	var name = t.Elem().Name()
+	if _, ok := schemas[name]; ok {
+		return ref(name)
+	}
+	var properties = make(map[string]interface{})
+	schemas[name] = map[string]interface{}{"type": "object", "properties": properties}
+
+	for _, prop := range proto.GetProperties(t.Elem()).Prop {
+		if strings.HasPrefix(prop.Name, "XXX_") {
+			continue
+		}
+		var (
+			field, _ = t.Elem().FieldByName(prop.Name)
+			jsonName = prop.JSONName
+		)
+		if jsonName == "" {
+			// same as jsonpb: protoc leaves it out when equal to field name
+			jsonName = prop.OrigName
+		}
+		properties[jsonName] = fieldSchema(field.Type, prop, schemas)
+	}
+	return ref(name)
+}
+
+func fieldSchema(t reflect.Type, prop *proto.Properties, schemas map[string]interface{}) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return messageRef(t, schemas)
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": fieldSchema(t.Elem(), prop, schemas)}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int32:
+		if prop.Enum != "" {
+			return enumSchema(prop.Enum)
+		}
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case reflect.Uint32:
+		return map[string]interface{}{"type": "integer", "format": "uint32"}
+	case reflect.Int64, reflect.Uint64:
+		// 64 bit integers are quoted in JSON mapping of protocol buffers
+		return map[string]interface{}{"type": "string", "format": "int64"}
+	case reflect.Float32:
+		return map[string]interface{}{"type": "number", "format": "float"}
+	case reflect.Float64:
+		return map[string]interface{}{"type": "number", "format": "double"}
+	}
+	return map[string]interface{}{}
+}
+
+// enumSchema lists names of the enum values ordered by their numbers
+func enumSchema(enum string) map[string]interface{} {
+	var (
+		values = proto.EnumValueMap(enum)
+		names  = make([]string, 0, len(values))
+	)
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return values[names[i]] < values[names[j]] })
+
+	return map[string]interface{}{"type": "string", "enum": names}
+}
@@ -0,0 +1,304 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/golang/protobuf/proto"
+	"github.com/kkoralsky/gosprints/core"
+	pb "github.com/kkoralsky/gosprints/proto"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	dbFileFlags    = os.O_CREATE | os.O_TRUNC | os.O_WRONLY
+	dbFileMode     = 0666
+	tmpSuffix      = ".tmp"
+	corruptSuffix  = ".corrupt"
+	backupInterval = 10 * time.Minute
+)
+
+// protoStorage keeps all the tournaments in the single protobuf file. Every save
+// writes the whole database into the temporary file which then atomically
+// replaces the previous one, so the file is always complete. Previous
+// versions are kept as rotating backups: <file>.1 (newest) ... <file>.N
+type protoStorage struct {
+	fileName    string
+	backups     uint
+	lastBackup  time.Time
+	tournaments *pb.Tournaments
+}
+
+func setupProtoStorage(fileName string, backups uint) (s *protoStorage, err error) {
+	var b []byte
+
+	s = &protoStorage{
+		fileName:    fileName,
+		backups:     backups,
+		tournaments: &pb.Tournaments{},
+	}
+	b, err = ioutil.ReadFile(fileName)
+	if os.IsNotExist(err) {
+		if s.recoverFromBackups() {
+			return s, s.save()
+		}
+		core.InfoLogger.Printf("sprints db %s will be created\n", fileName)
+		return s, nil
+	} else if err != nil {
+		return
+	}
+
+	if len(b) == 0 {
+		core.InfoLogger.Printf("sprints db %s left empty\n", fileName)
+	} else if err = proto.Unmarshal(b, s.tournaments); err != nil {
+		core.ErrorLogger.Printf("sprints db %s is corrupted: %v", fileName, err)
+		if err = os.Rename(fileName, fileName+corruptSuffix); err != nil {
+			return
+		}
+		s.tournaments = salvageTournaments(b)
+		core.InfoLogger.Printf("salvaged %d tournaments; corrupted db kept in %s",
+			len(s.tournaments.Tournament), fileName+corruptSuffix)
+		s.recoverFromBackups()
+		return s, s.save()
+	} else {
+		core.InfoLogger.Printf("loaded %d previous tournaments\n", len(s.tournaments.Tournament))
+	}
+	// startup makes a backup of what's changed since the last one
+	return s, s.rotateBackups()
+}
+
+// salvageTournaments decodes consecutive tournaments until it encounters
+// corrupted or truncated data
+func salvageTournaments(b []byte) *pb.Tournaments {
+	var (
+		tournaments = &pb.Tournaments{}
+		buf         = proto.NewBuffer(b)
+	)
+	for {
+		// field 1 of Tournaments, length delimited
+		if key, err := buf.DecodeVarint(); err != nil || key != 1<<3|proto.WireBytes {
+			break
+		}
+		tournament := &pb.Tournament{}
+		if err := buf.DecodeMessage(tournament); err != nil {
+			break
+		}
+		tournaments.Tournament = append(tournaments.Tournament, tournament)
+	}
+	return tournaments
+}
+
+// recoverFromBackups adds tournaments missing in the db from the newest
+// readable backup; it returns true if any tournament was recovered
+func (s *protoStorage) recoverFromBackups() bool {
+	var backup = &pb.Tournaments{}
+
+	for i := uint(1); i <= s.backups; i++ {
+		b, err := ioutil.ReadFile(s.backupName(i))
+		if err != nil {
+			continue
+		}
+		if err = proto.Unmarshal(b, backup); err != nil {
+			core.ErrorLogger.Printf("backup %s is corrupted: %v", s.backupName(i), err)
+			continue
+		}
+		recovered := 0
+		for _, tournament := range backup.Tournament {
+			if s.getTournamentIndex(tournament.Name) == -1 {
+				s.tournaments.Tournament = append(s.tournaments.Tournament, tournament)
+				recovered++
+			}
+		}
+		core.InfoLogger.Printf("recovered %d tournaments from %s", recovered, s.backupName(i))
+		return recovered > 0
+	}
+	return false
+}
+
+func (s *protoStorage) backupName(i uint) string {
+	return fmt.Sprintf("%s.%d", s.fileName, i)
+}
+
+// rotateBackups shifts existing backups and copies current db file as the
+// newest one unless it's the same already; that way restarting over and over
+// doesn't push out the older backups. Copies rather than links keep the
+// backups intact when the db file gets damaged in place.
+func (s *protoStorage) rotateBackups() error {
+	if s.backups == 0 {
+		return nil
+	}
+	if _, err := os.Stat(s.fileName); os.IsNotExist(err) {
+		return nil
+	}
+	if s.backedUp() {
+		s.lastBackup = time.Now()
+		return nil
+	}
+	for i := s.backups; i > 1; i-- {
+		if err := os.Rename(s.backupName(i-1), s.backupName(i)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	b, err := ioutil.ReadFile(s.fileName)
+	if err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(s.backupName(1), b, dbFileMode); err != nil {
+		return err
+	}
+	s.lastBackup = time.Now()
+	return nil
+}
+
+// backedUp tells whether the newest backup has the content of the db file
+func (s *protoStorage) backedUp() bool {
+	current, err := os.Stat(s.fileName)
+	if err != nil {
+		return false
+	}
+	newest, err := os.Stat(s.backupName(1))
+	if err != nil || current.Size() != newest.Size() {
+		return false
+	}
+	a, err := ioutil.ReadFile(s.fileName)
+	if err != nil {
+		return false
+	}
+	b, err := ioutil.ReadFile(s.backupName(1))
+	return err == nil && bytes.Equal(a, b)
+}
+
+func (s *protoStorage) SaveTournament(tournament *pb.Tournament) error {
+	var i = s.getTournamentIndex(tournament.Name)
+
+	if i == -1 {
+		s.tournaments.Tournament = append(s.tournaments.Tournament, tournament)
+	} else {
+		s.tournaments.Tournament[i] = tournament
+	}
+
+	// core.DebugLogger.Printf("len: %d i: %d, %v", len(s.tournaments.Tournament), i, s.tournaments.Tournament)
+
+	return s.save()
+}
+
+func (s *protoStorage) RenameTournament(name, newName string) error {
+	var i = s.getTournamentIndex(name)
+	if i == -1 {
+		return errNotFound
+	}
+	if s.getTournamentIndex(newName) != -1 {
+		return fmt.Errorf("%s already exists", newName)
+	}
+	s.tournaments.Tournament[i].Name = newName
+	return s.save()
+}
+
+func (s *protoStorage) DeleteTournament(name string) error {
+	var i = s.getTournamentIndex(name)
+	if i == -1 {
+		return errNotFound
+	}
+	s.tournaments.Tournament = append(s.tournaments.Tournament[:i], s.tournaments.Tournament[i+1:]...)
+	return s.save()
+}
+
+func (s *protoStorage) save() error {
+	var (
+		tmpFileName = s.fileName + tmpSuffix
+		tmpFile     *os.File
+		dir         *os.File
+		b           []byte
+		err         error
+	)
+	b, err = proto.Marshal(s.tournaments)
+	if err != nil {
+		return err
+	}
+	if len(b) == 0 && len(s.tournaments.Tournament) > 0 {
+		return errors.New("Nothing written to database")
+	}
+	if tmpFile, err = os.OpenFile(tmpFileName, dbFileFlags, dbFileMode); err != nil {
+		return err
+	}
+	if _, err = tmpFile.Write(b); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err = tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err = tmpFile.Close(); err != nil {
+		return err
+	}
+
+	if time.Since(s.lastBackup) > backupInterval {
+		if err = s.rotateBackups(); err != nil {
+			core.ErrorLogger.Printf("couldnt rotate backups: %v", err)
+		}
+	}
+	if err = os.Rename(tmpFileName, s.fileName); err != nil {
+		return err
+	}
+
+	// make the rename itself durable
+	if dir, err = os.Open(filepath.Dir(s.fileName)); err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
+
+func (s *protoStorage) getTournamentIndex(name string) int {
+	for i, tournament := range s.tournaments.Tournament {
+		if tournament.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *protoStorage) GetTournament(name string) (*pb.Tournament, error) {
+	var i = s.getTournamentIndex(name)
+	if i >= 0 {
+		return s.tournaments.Tournament[i], nil
+	}
+	return nil, errNotFound
+}
+
+func (s *protoStorage) GetTournaments() ([]*pb.Tournament, error) {
+	return s.tournaments.Tournament, nil
+}
+
+func (s *protoStorage) ListTournaments() ([]*pb.Tournament, error) {
+	return s.tournaments.Tournament, nil
+}
+
+func (s *protoStorage) AddResult(tournament *pb.Tournament, _ *pb.Result) error {
+	return s.SaveTournament(tournament)
+}
+
+func (s *protoStorage) GetPlayerResults(playerName string) (records []*pb.Record, err error) {
+	var key = playerKey(playerName)
+
+	for _, tournament := range s.tournaments.Tournament {
+		for _, result := range tournament.Result {
+			if result.Player != nil && playerKey(result.Player.Name) == key {
+				records = append(records, &pb.Record{
+					Result:         result,
+					Mode:           tournament.Mode,
+					TournamentName: tournament.Name,
+				})
+			}
+		}
+	}
+	return
+}
+
+func (s *protoStorage) Close() error {
+	return nil
+}
@@ -0,0 +1,159 @@
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+func setupTestProtoStorage(t *testing.T, backups uint) (*protoStorage, func()) {
+	dir, err := ioutil.TempDir("", "gosprints-pb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := setupProtoStorage(filepath.Join(dir, "sprints.pb"), backups)
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return s, func() { os.RemoveAll(dir) }
+}
+
+func tournamentNames(s *protoStorage) (names []string) {
+	for _, tournament := range s.tournaments.Tournament {
+		names = append(names, tournament.Name)
+	}
+	return
+}
+
+func TestProtoStorageSave(t *testing.T) {
+	s, cleanup := setupTestProtoStorage(t, 0)
+	defer cleanup()
+
+	for _, name := range []string{"first", "second"} {
+		if err := s.SaveTournament(&pb.Tournament{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(s.fileName + tmpSuffix); !os.IsNotExist(err) {
+		t.Error("temporary file should be renamed over the db")
+	}
+	reloaded, err := setupProtoStorage(s.fileName, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := tournamentNames(reloaded); len(names) != 2 || names[1] != "second" {
+		t.Errorf("saved tournaments should be loaded, got %v", names)
+	}
+}
+
+func TestProtoStorageSalvage(t *testing.T) {
+	s, cleanup := setupTestProtoStorage(t, 0)
+	defer cleanup()
+
+	for _, name := range []string{"first", "second", "third"} {
+		s.tournaments.Tournament = append(s.tournaments.Tournament, &pb.Tournament{Name: name, DestValue: 400})
+	}
+	if err := s.save(); err != nil {
+		t.Fatal(err)
+	}
+	b, _ := ioutil.ReadFile(s.fileName)
+	// cut in the middle of the last tournament
+	if err := ioutil.WriteFile(s.fileName, b[:len(b)-3], dbFileMode); err != nil {
+		t.Fatal(err)
+	}
+
+	salvaged, err := setupProtoStorage(s.fileName, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := tournamentNames(salvaged); len(names) != 2 || names[0] != "first" || names[1] != "second" {
+		t.Errorf("complete tournaments should be salvaged, got %v", names)
+	}
+	if corrupted, _ := ioutil.ReadFile(s.fileName + corruptSuffix); len(corrupted) != len(b)-3 {
+		t.Error("corrupted db should be kept aside")
+	}
+	if reloaded, err := setupProtoStorage(s.fileName, 0); err != nil || len(reloaded.tournaments.Tournament) != 2 {
+		t.Errorf("salvaged tournaments should be saved: %v", err)
+	}
+}
+
+func TestProtoStorageRecoverFromBackups(t *testing.T) {
+	s, cleanup := setupTestProtoStorage(t, 2)
+	defer cleanup()
+
+	for _, name := range []string{"first", "second"} {
+		if err := s.SaveTournament(&pb.Tournament{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// startup backs the db up
+	if _, err := setupProtoStorage(s.fileName, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	// corrupted beyond salvage
+	if err := ioutil.WriteFile(s.fileName, []byte("garbage"), dbFileMode); err != nil {
+		t.Fatal(err)
+	}
+	recovered, err := setupProtoStorage(s.fileName, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := tournamentNames(recovered); len(names) != 2 {
+		t.Errorf("tournaments should be recovered from the backup, got %v", names)
+	}
+
+	// lost altogether
+	if err = os.Remove(s.fileName); err != nil {
+		t.Fatal(err)
+	}
+	if recovered, err = setupProtoStorage(s.fileName, 2); err != nil {
+		t.Fatal(err)
+	}
+	if names := tournamentNames(recovered); len(names) != 2 {
+		t.Errorf("missing db should be recovered from the backup, got %v", names)
+	}
+}
+
+func TestProtoStorageBackupsRotateOnChange(t *testing.T) {
+	s, cleanup := setupTestProtoStorage(t, 3)
+	defer cleanup()
+
+	if err := s.SaveTournament(&pb.Tournament{Name: "first"}); err != nil {
+		t.Fatal(err)
+	}
+	// restarting over and over keeps the single backup
+	for i := 0; i < 5; i++ {
+		if _, err := setupProtoStorage(s.fileName, 3); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(s.backupName(2)); !os.IsNotExist(err) {
+		t.Error("unchanged db shouldn't be backed up again")
+	}
+
+	restarted, err := setupProtoStorage(s.fileName, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = restarted.SaveTournament(&pb.Tournament{Name: "second"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = setupProtoStorage(s.fileName, 3); err != nil {
+		t.Fatal(err)
+	}
+	newest, older := &pb.Tournaments{}, &pb.Tournaments{}
+	b, _ := ioutil.ReadFile(s.backupName(1))
+	proto.Unmarshal(b, newest)
+	b, _ = ioutil.ReadFile(s.backupName(2))
+	proto.Unmarshal(b, older)
+	if len(newest.Tournament) != 2 || len(older.Tournament) != 1 {
+		t.Errorf("changed db should be backed up, got %d and %d tournaments",
+			len(newest.Tournament), len(older.Tournament))
+	}
+}
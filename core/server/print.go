@@ -0,0 +1,100 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/kkoralsky/gosprints/core"
+	"github.com/kkoralsky/gosprints/core/pdf"
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+func (s *Sprints) PrintResults(_ context.Context, printSpec *pb.PrintSpec) (*pb.ExportedFile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var buf bytes.Buffer
+
+	tournament, err := s.getTournament(printSpec.TournamentName)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkLegacyResults(tournament); err != nil {
+		return nil, err
+	}
+	err = printTournament(&buf, tournament, printSpec.Title, printSpec.Date, s.distFactor,
+		printSpec.Certificates, printSpec.PlayerName)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ExportedFile{
+		FileName: pdf.FileName(tournament, printSpec.Certificates),
+		MimeType: pdf.MimeType,
+		Content:  buf.Bytes(),
+	}, nil
+}
+
+// Pdf prints result sheet or certificates of the tournament stored in the
+// database into the file or standard output
+func Pdf(cfg core.PdfConfig) error {
+	var (
+		out        io.Writer = os.Stdout
+		tournament *pb.Tournament
+	)
+	sprintsDb, err := SetupSprintsDb(cfg.DbBackend, cfg.DbPath, 0)
+	if err != nil {
+		return err
+	}
+	defer sprintsDb.Close()
+
+	if cfg.Tournament == "" {
+		tournament, err = sprintsDb.GetLastTournament()
+	} else {
+		tournament, err = sprintsDb.GetTournament(cfg.Tournament)
+	}
+	if err != nil {
+		return err
+	}
+	if err = checkLegacyResults(tournament); err != nil {
+		return err
+	}
+
+	if cfg.Out != "-" {
+		if cfg.Out == "" {
+			cfg.Out = pdf.FileName(tournament, cfg.Certificates)
+		}
+		f, err := os.Create(cfg.Out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	err = printTournament(out, tournament, cfg.Title, cfg.Date, cfg.DistFactor, cfg.Certificates, cfg.Player)
+	if err != nil {
+		return err
+	}
+	if cfg.Out != "-" {
+		core.InfoLogger.Printf("%s printed to %s", tournament.Name, cfg.Out)
+	}
+	return nil
+}
+
+func printTournament(w io.Writer, tournament *pb.Tournament, title, date string, distFactor uint,
+	certificates bool, playerName string) error {
+	var event = pdf.Event{Title: title, Date: date, DistFactor: distFactor}
+
+	if event.Title == "" {
+		event.Title = tournament.Name
+	}
+	if event.Date == "" {
+		event.Date = time.Now().Format("2006-01-02")
+	}
+	if certificates {
+		return pdf.Certificates(w, tournament, event, playerName)
+	}
+	return pdf.ResultSheet(w, tournament, event)
+}
@@ -0,0 +1,46 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/kkoralsky/gosprints/core/pdf"
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+func TestPrintResults(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+
+	if _, err := s.NewRace(context.Background(), newTestRace(20)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.StartRace(context.Background(), &pb.Empty{}); err != nil {
+		t.Fatal(err)
+	}
+	waitForRace(t, s)
+
+	for _, spec := range []*pb.PrintSpec{
+		{},
+		{Certificates: true},
+		{Certificates: true, PlayerName: "first", Title: "event", Date: "today"},
+	} {
+		printout, err := s.PrintResults(context.Background(), spec)
+		if err != nil {
+			t.Fatalf("%v: %v", spec, err)
+		}
+		if printout.MimeType != pdf.MimeType || !bytes.HasPrefix(printout.Content, []byte("%PDF-")) {
+			t.Errorf("%v: printout should be PDF, got %s %q", spec, printout.MimeType, printout.Content[:10])
+		}
+		if printout.FileName != pdf.FileName(s.tournament, spec.Certificates) {
+			t.Errorf("%v: unexpected file name %s", spec, printout.FileName)
+		}
+	}
+	if _, err := s.PrintResults(context.Background(), &pb.PrintSpec{Certificates: true, PlayerName: "nobody"}); err == nil {
+		t.Error("certificate of rider without result shouldn't be printed")
+	}
+	if _, err := s.PrintResults(context.Background(), &pb.PrintSpec{TournamentName: "missing"}); err == nil {
+		t.Error("missing tournament shouldn't be printed")
+	}
+}
@@ -0,0 +1,197 @@
+package server
+
+import (
+	"time"
+
+	"github.com/kkoralsky/gosprints/core"
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+// recordingConfig says how often splits and speed trace samples are taken
+type recordingConfig struct {
+	// device units; 0 disables splits in distance constrained and relay races
+	splitDistance uint
+	// 0 disables splits in time constrained races
+	splitTime     time.Duration
+	traceInterval time.Duration
+	distFactor    uint
+}
+
+func setupRecordingConfig(cfg core.ServerConfig) recordingConfig {
+	var recording = recordingConfig{
+		splitTime:     time.Duration(cfg.SplitTime) * time.Second,
+		traceInterval: time.Duration(cfg.TraceInterval) * time.Millisecond,
+		distFactor:    cfg.DistFactor,
+	}
+	if cfg.DistFactor > 0 {
+		recording.splitDistance = cfg.SplitDistance * 100 / cfg.DistFactor
+	}
+	return recording
+}
+
+// raceRecorder collects split times and downsampled speed trace of every
+// player during the race
+type raceRecorder struct {
+	recordingConfig
+	mode      pb.Tournament_TournamentMode
+	destValue uint
+	start     time.Time
+	splits    map[int][]*pb.Split
+	trace     map[int][]*pb.TracePoint
+	finished  map[int]bool
+	// the last two changes of distance of every player
+	pulses map[int][2]pulse
+	// distance every player starts with
+	offsets map[int]uint
+}
+
+type pulse struct {
+	at   time.Duration
+	dist uint
+}
+
+func (r recordingConfig) newRecorder(mode pb.Tournament_TournamentMode, destValue uint32, start time.Time) *raceRecorder {
+	return &raceRecorder{
+		recordingConfig: r,
+		mode:            mode,
+		destValue:       uint(destValue),
+		start:           start,
+		splits:          make(map[int][]*pb.Split),
+		trace:           make(map[int][]*pb.TracePoint),
+		finished:        make(map[int]bool),
+		pulses:          make(map[int][2]pulse),
+		offsets:         make(map[int]uint),
+	}
+}
+
+// offset sets distance the player starts with; splits are taken only beyond
+// it
+func (r *raceRecorder) offset(playerNum int, dist uint) {
+	r.offsets[playerNum] = dist
+	r.pulses[playerNum] = [2]pulse{{dist: dist}, {dist: dist}}
+}
+
+// record takes distance of the player polled at the given moment
+func (r *raceRecorder) record(playerNum int, dist uint, now time.Time) {
+	if r.finished[playerNum] {
+		return
+	}
+	var elapsed = now.Sub(r.start)
+
+	if p := r.pulses[playerNum]; dist != p[1].dist {
+		r.pulses[playerNum] = [2]pulse{p[1], {at: elapsed, dist: dist}}
+	}
+	if r.splitsByDistance() {
+		var skipped = r.offsets[playerNum] / r.splitDistance
+		for next := (uint(len(r.splits[playerNum])+1) + skipped) * r.splitDistance; next < r.destValue && dist >= next; next += r.splitDistance {
+			r.splits[playerNum] = append(r.splits[playerNum], &pb.Split{
+				Distance: uint32(next),
+				Time:     milliseconds(elapsed),
+			})
+		}
+	} else if r.splitsByTime() {
+		var raceDuration = time.Duration(r.destValue) * time.Second
+		for next := time.Duration(len(r.splits[playerNum])+1) * r.splitTime; next < raceDuration && elapsed >= next; next += r.splitTime {
+			r.splits[playerNum] = append(r.splits[playerNum], &pb.Split{
+				Distance: uint32(dist),
+				Time:     milliseconds(next),
+			})
+		}
+	}
+
+	if r.traceInterval > 0 {
+		var last = r.lastTracePoint(playerNum)
+		if elapsed-time.Duration(last.Time)*time.Millisecond >= r.traceInterval {
+			r.addTracePoint(playerNum, last, dist, milliseconds(elapsed))
+		}
+	}
+}
+
+// finish closes splits and trace of the player with the final result
+func (r *raceRecorder) finish(playerNum int, dist uint, ms uint) {
+	if r.finished[playerNum] {
+		return
+	}
+	r.finished[playerNum] = true
+
+	if r.splitsByDistance() || r.splitsByTime() {
+		r.splits[playerNum] = append(r.splits[playerNum], &pb.Split{
+			Distance: uint32(dist),
+			Time:     uint32(ms),
+		})
+	}
+	if last := r.lastTracePoint(playerNum); r.traceInterval > 0 && uint32(ms) > last.Time {
+		r.addTracePoint(playerNum, last, dist, uint32(ms))
+	}
+}
+
+// distanceAt estimates distance in device units covered by the player at the
+// given moment adding partial revolution based on the pace of the last pulses
+func (r *raceRecorder) distanceAt(playerNum int, at time.Duration) float32 {
+	var last, prev = r.pulses[playerNum][1], r.pulses[playerNum][0]
+
+	if last.dist == 0 || last.dist < prev.dist || last.at <= prev.at || at <= last.at {
+		return float32(last.dist)
+	}
+	partial := float64(at-last.at) / float64(last.at-prev.at) * float64(last.dist-prev.dist)
+	// next pulse hasn't come so it's less than a single unit
+	if partial > 1 {
+		partial = 1
+	}
+	return float32(float64(last.dist) + partial)
+}
+
+// lastPulse returns when the player's distance changed for the last time
+func (r *raceRecorder) lastPulse(playerNum int) time.Duration {
+	return r.pulses[playerNum][1].at
+}
+
+// reachedAt returns when the player reached distance of the result in time
+// constrained race
+func (r *raceRecorder) reachedAt(playerNum int) uint32 {
+	if r.mode != pb.Tournament_TIME {
+		return 0
+	}
+	return milliseconds(r.pulses[playerNum][1].at)
+}
+
+// splitsByDistance tells whether splits are taken at fixed distances which
+// makes sense for races to the destination distance
+func (r *raceRecorder) splitsByDistance() bool {
+	return r.splitDistance > 0 && (r.mode == pb.Tournament_DISTANCE || r.mode == pb.Tournament_RELAY ||
+		r.mode == pb.Tournament_PURSUIT)
+}
+
+func (r *raceRecorder) splitsByTime() bool {
+	return r.splitTime > 0 && r.mode == pb.Tournament_TIME
+}
+
+func (r *raceRecorder) lastTracePoint(playerNum int) *pb.TracePoint {
+	if trace := r.trace[playerNum]; len(trace) > 0 {
+		return trace[len(trace)-1]
+	}
+	return &pb.TracePoint{Distance: uint32(r.offsets[playerNum])}
+}
+
+// addTracePoint appends the point unless the distance went backwards, e.g.
+// the counter of the device got reset, or the time didn't move on; ghosts
+// repeat the trace so it has to be monotonic
+func (r *raceRecorder) addTracePoint(playerNum int, last *pb.TracePoint, dist uint, ms uint32) {
+	if uint32(dist) < last.Distance || ms <= last.Time {
+		core.DebugLogger.Printf("player #%d: trace point of %d at %d ms skipped", playerNum, dist, ms)
+		return
+	}
+	var (
+		meters  = core.Meters(float32(uint32(dist)-last.Distance), r.distFactor)
+		seconds = float32(ms-last.Time) / 1000
+	)
+	r.trace[playerNum] = append(r.trace[playerNum], &pb.TracePoint{
+		Time:     ms,
+		Distance: uint32(dist),
+		Speed:    meters / seconds * 3.6,
+	})
+}
+
+func milliseconds(d time.Duration) uint32 {
+	return uint32(d / time.Millisecond)
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kkoralsky/gosprints/core"
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+var testRecordingConfig = setupRecordingConfig(core.ServerConfig{
+	DistFactor:    100, // 1 device unit is 1 meter
+	SplitDistance: 100,
+	SplitTime:     1,
+	TraceInterval: 500,
+})
+
+func TestDistanceRaceSplits(t *testing.T) {
+	var (
+		start    = time.Now()
+		recorder = testRecordingConfig.newRecorder(pb.Tournament_DISTANCE, 250, start)
+	)
+	recorder.record(0, 50, start.Add(500*time.Millisecond))
+	recorder.record(0, 210, start.Add(2*time.Second))
+	recorder.finish(0, 250, 2500)
+	recorder.record(0, 300, start.Add(3*time.Second))
+
+	splits := recorder.splits[0]
+	if len(splits) != 3 {
+		t.Fatalf("there should be 3 splits: %v", splits)
+	}
+	for i, expected := range []pb.Split{{Distance: 100, Time: 2000}, {Distance: 200, Time: 2000},
+		{Distance: 250, Time: 2500}} {
+		if *splits[i] != expected {
+			t.Errorf("split #%d should be %v, not %v", i, expected, *splits[i])
+		}
+	}
+
+	trace := recorder.trace[0]
+	if len(trace) != 3 {
+		t.Fatalf("there should be 3 trace points: %v", trace)
+	}
+	if trace[0].Speed != 360 || trace[2].Speed != 288 {
+		t.Errorf("unexpected speeds: %v", trace)
+	}
+}
+
+func TestTimeRaceSplits(t *testing.T) {
+	var (
+		start    = time.Now()
+		recorder = testRecordingConfig.newRecorder(pb.Tournament_TIME, 3, start)
+	)
+	recorder.record(1, 10, start.Add(1100*time.Millisecond))
+	recorder.record(1, 30, start.Add(2900*time.Millisecond))
+	recorder.finish(1, 31, 3000)
+
+	splits := recorder.splits[1]
+	if len(splits) != 3 || splits[0].Distance != 10 || splits[1].Time != 2000 || splits[2].Distance != 31 {
+		t.Errorf("unexpected splits: %v", splits)
+	}
+}
+
+func TestDistanceAt(t *testing.T) {
+	var (
+		start    = time.Now()
+		recorder = testRecordingConfig.newRecorder(pb.Tournament_TIME, 3, start)
+	)
+	recorder.record(0, 8, start.Add(2000*time.Millisecond))
+	recorder.record(0, 10, start.Add(2800*time.Millisecond))
+	recorder.record(0, 10, start.Add(2900*time.Millisecond))
+
+	if dist := recorder.distanceAt(0, 3*time.Second); dist != 10.5 {
+		t.Errorf("distance should be interpolated to 10.5, not %f", dist)
+	}
+	if reachedAt := recorder.reachedAt(0); reachedAt != 2800 {
+		t.Errorf("distance should be reached at 2800, not %d", reachedAt)
+	}
+	recorder.record(1, 1, start.Add(100*time.Millisecond))
+	if dist := recorder.distanceAt(1, 3*time.Second); dist != 2 {
+		t.Errorf("partial revolution should be at most 1, not %f", dist-1)
+	}
+}
+
+func TestTraceGoingBackwards(t *testing.T) {
+	var (
+		start    = time.Now()
+		recorder = testRecordingConfig.newRecorder(pb.Tournament_DISTANCE, 250, start)
+	)
+	recorder.record(0, 100, start.Add(500*time.Millisecond))
+	// counter of the device got reset
+	recorder.record(0, 20, start.Add(1000*time.Millisecond))
+	if dist := recorder.distanceAt(0, 1200*time.Millisecond); dist != 20 {
+		t.Errorf("distance shouldn't be interpolated when it went backwards, got %f", dist)
+	}
+	recorder.record(0, 150, start.Add(1500*time.Millisecond))
+	recorder.finish(0, 250, 2000)
+
+	trace := recorder.trace[0]
+	if len(trace) != 3 {
+		t.Fatalf("point going backwards should be skipped: %v", trace)
+	}
+	for i, point := range trace {
+		if i > 0 && point.Distance < trace[i-1].Distance {
+			t.Errorf("trace should be monotonic: %v", trace)
+		}
+		if point.Speed < 0 || point.Speed > 720 {
+			t.Errorf("speed of trace point #%d is off: %v", i, point)
+		}
+	}
+	if trace[1].Distance != 150 || trace[1].Speed != 180 {
+		t.Errorf("speed should be taken since the last point kept: %v", trace[1])
+	}
+}
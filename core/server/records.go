@@ -0,0 +1,153 @@
+package server
+
+import (
+	"sort"
+
+	"github.com/kkoralsky/gosprints/core"
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+// category groups results which are comparable with each other; results of
+// players in a category compete for its record as well as the overall one
+// (with empty playerCategory)
+type category struct {
+	mode           pb.Tournament_TournamentMode
+	destValue      uint32
+	gender         pb.Gender
+	playerCategory string
+}
+
+type personalCategory struct {
+	playerName string
+	mode       pb.Tournament_TournamentMode
+	destValue  uint32
+}
+
+// Records keeps personal bests of every player and all-time records of
+// every category across all the stored tournaments
+type Records struct {
+	personalBests map[personalCategory]*pb.Record
+	allTime       map[category]*pb.Record
+}
+
+func SetupRecords(tournaments []*pb.Tournament) *Records {
+	var r = &Records{}
+	r.Load(tournaments)
+	return r
+}
+
+// Load (re)computes all the records from scratch and flags the results anew,
+// so corrected results don't keep stale personal best and record flags; it
+// returns tournaments whose results got flagged differently
+func (r *Records) Load(tournaments []*pb.Tournament) (changed []*pb.Tournament) {
+	r.personalBests = make(map[personalCategory]*pb.Record)
+	r.allTime = make(map[category]*pb.Record)
+
+	for _, tournament := range tournaments {
+		var flagged bool
+		for _, result := range tournament.Result {
+			personalBest, record := r.Update(tournament, result)
+			if result.PersonalBest != personalBest || result.Record != record {
+				result.PersonalBest, result.Record = personalBest, record
+				flagged = true
+			}
+		}
+		if flagged {
+			changed = append(changed, tournament)
+		}
+	}
+	return
+}
+
+// Update takes the result into account and reports whether it has improved
+// personal best of the player or all-time record of its category. First
+// result of the player or in the category doesn't count as an improvement;
+// disqualified and handicapped results are ignored as well as the ones stored
+// in device units.
+func (r *Records) Update(tournament *pb.Tournament, result *pb.Result) (personalBest, record bool) {
+	if result.Player == nil || result.Result <= 0 || result.Disqualified || isHandicapped(result) ||
+		legacyResults(tournament) {
+		return
+	}
+	var (
+		newRecord = &pb.Record{
+			Result:         result,
+			Mode:           tournament.Mode,
+			TournamentName: tournament.Name,
+		}
+		pc = personalCategory{
+			playerName: result.Player.Name,
+			mode:       tournament.Mode,
+			destValue:  result.DestValue,
+		}
+		c = category{
+			mode:      tournament.Mode,
+			destValue: result.DestValue,
+			gender:    result.Player.Gender,
+		}
+	)
+
+	if prev, ok := r.personalBests[pc]; !ok {
+		r.personalBests[pc] = newRecord
+	} else if core.IsBetterResult(tournament.Mode, result, prev.Result) {
+		r.personalBests[pc] = newRecord
+		personalBest = true
+	}
+	record = r.updateAllTime(c, newRecord)
+	if result.Player.Category != "" {
+		c.playerCategory = result.Player.Category
+		categoryRecord := &pb.Record{
+			Result:         result,
+			Mode:           tournament.Mode,
+			TournamentName: tournament.Name,
+			Category:       c.playerCategory,
+		}
+		record = r.updateAllTime(c, categoryRecord) || record
+	}
+	return
+}
+
+// updateAllTime reports whether the record has beaten the previous one of
+// the category
+func (r *Records) updateAllTime(c category, record *pb.Record) bool {
+	if prev, ok := r.allTime[c]; !ok {
+		r.allTime[c] = record
+	} else if core.IsBetterResult(c.mode, record.Result, prev.Result) {
+		r.allTime[c] = record
+		return true
+	}
+	return false
+}
+
+// PersonalBests returns best results of the given player for every
+// distance/time the player has raced
+func (r *Records) PersonalBests(playerName string) (records []*pb.Record) {
+	for pc, record := range r.personalBests {
+		if pc.playerName == playerName {
+			records = append(records, record)
+		}
+	}
+	sortRecords(records)
+	return
+}
+
+// AllTime returns all-time records of every category for the given gender;
+// overall ones unless playerCategory is given
+func (r *Records) AllTime(gender pb.Gender, playerCategory string) (records []*pb.Record) {
+	for c, record := range r.allTime {
+		if c.gender == gender && c.playerCategory == playerCategory {
+			records = append(records, record)
+		}
+	}
+	sortRecords(records)
+	return
+}
+
+func sortRecords(records []*pb.Record) {
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Mode == records[j].Mode {
+			return records[i].Result.DestValue < records[j].Result.DestValue
+		}
+		return records[i].Mode < records[j].Mode
+	})
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"testing"
+
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+func categoryResult(name, playerCategory string, result float32) *pb.Result {
+	r := raceResult(0, name, pb.Gender_MALE, result)
+	r.Player.Category = playerCategory
+	return r
+}
+
+func TestRecordsUpdate(t *testing.T) {
+	var (
+		r          = SetupRecords(nil)
+		tournament = &pb.Tournament{Name: "records", Mode: pb.Tournament_DISTANCE}
+		handicap   = categoryResult("b", "", 10000)
+		dq         = categoryResult("b", "", 10000)
+	)
+	handicap.Handicap = &pb.Handicap{StartDelay: 1000}
+	dq.Disqualified = true
+
+	for i, c := range []struct {
+		result               *pb.Result
+		personalBest, record bool
+	}{
+		{categoryResult("a", "", 20000), false, false},
+		{categoryResult("a", "", 21000), false, false},
+		{categoryResult("a", "", 19000), true, true},
+		{categoryResult("b", "", 19500), false, false},
+		{categoryResult("b", "", 18000), true, true},
+		{handicap, false, false},
+		{dq, false, false},
+		// first junior sets the category record without beating the overall one
+		{categoryResult("j", "junior", 25000), false, false},
+		{categoryResult("k", "junior", 24000), false, true},
+		{categoryResult("j", "junior", 17000), true, true},
+	} {
+		personalBest, record := r.Update(tournament, c.result)
+		if personalBest != c.personalBest || record != c.record {
+			t.Errorf("%d. %s %.0f: personal best %t, record %t; want %t, %t", i, c.result.Player.Name,
+				c.result.Result, personalBest, record, c.personalBest, c.record)
+		}
+	}
+
+	if bests := r.PersonalBests("a"); len(bests) != 1 || bests[0].Result.Result != 19000 {
+		t.Errorf("personal best of a should be 19000, got %v", bests)
+	}
+	if records := r.AllTime(pb.Gender_MALE, ""); len(records) != 1 || records[0].Result.Player.Name != "j" {
+		t.Errorf("j should hold the overall record, got %v", records)
+	}
+	records := r.AllTime(pb.Gender_MALE, "junior")
+	if len(records) != 1 || records[0].Result.Result != 17000 || records[0].Category != "junior" {
+		t.Errorf("j should hold the junior record, got %v", records)
+	}
+	if records = r.AllTime(pb.Gender_FEMALE, ""); len(records) != 0 {
+		t.Errorf("there should be no female records, got %v", records)
+	}
+}
+
+func TestRecordsLoad(t *testing.T) {
+	var (
+		first  = &pb.Tournament{Name: "first", Mode: pb.Tournament_DISTANCE}
+		second = &pb.Tournament{Name: "second", Mode: pb.Tournament_DISTANCE}
+		r      = SetupRecords(nil)
+	)
+	for _, result := range []*pb.Result{categoryResult("a", "", 20000), categoryResult("b", "", 19000)} {
+		first.Result = append(first.Result, result)
+		result.PersonalBest, result.Record = r.Update(first, result)
+	}
+	result := categoryResult("a", "", 18000)
+	second.Result = append(second.Result, result)
+	result.PersonalBest, result.Record = r.Update(second, result)
+
+	// loading what has been updated one by one changes nothing
+	if changed := r.Load([]*pb.Tournament{first, second}); len(changed) != 0 {
+		t.Errorf("flags of %d tournaments changed", len(changed))
+	}
+
+	// records are reloaded after correction of the first tournament
+	first.Result = first.Result[1:]
+	if changed := r.Load([]*pb.Tournament{first, second}); len(changed) != 2 {
+		t.Fatalf("both tournaments should be flagged anew, got %v", changed)
+	}
+	if first.Result[0].Record {
+		t.Errorf("first result of the category shouldn't be record: %v", first.Result[0])
+	}
+	if result.PersonalBest || !result.Record {
+		t.Errorf("18000 should be record of a but not a personal best anymore: %v", result)
+	}
+	if bests := r.PersonalBests("a"); len(bests) != 1 || bests[0].TournamentName != "second" {
+		t.Errorf("personal best of a should come from the second tournament, got %v", bests)
+	}
+}
@@ -0,0 +1,173 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/kkoralsky/gosprints/core"
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+// setupRelay validates teams of the relay race and fills players of the race
+// with the teams so that every lane is a team
+func (s *Sprints) setupRelay(race *pb.Race) error {
+	if s.tournament.Mode != pb.Tournament_RELAY {
+		if len(race.Teams) > 0 {
+			return errors.New("teams are allowed in relay races only")
+		}
+		return nil
+	}
+	if len(race.Teams) == 0 {
+		return errors.New("teams of the relay race not given")
+	}
+	var legs = len(race.Teams[0].Riders)
+
+	if len(s.tournament.SwapDistance) > 0 && len(s.tournament.SwapDistance)+1 != legs {
+		return fmt.Errorf("every team should have %d riders", len(s.tournament.SwapDistance)+1)
+	}
+	for i, swapDistance := range s.tournament.SwapDistance {
+		if swapDistance >= race.DestValue || (i > 0 && swapDistance <= s.tournament.SwapDistance[i-1]) {
+			return errors.New("swap distances should be ascending and within the race distance")
+		}
+	}
+	race.Players = nil
+	for _, team := range race.Teams {
+		if team.Name == "" {
+			return errors.New("team name not given")
+		}
+		if len(team.Riders) == 0 || len(team.Riders) != legs {
+			return fmt.Errorf("every team should have the same number of riders: %d", legs)
+		}
+		race.Players = append(race.Players, &pb.Player{Name: team.Name, Gender: teamGender(team)})
+	}
+	return nil
+}
+
+// teamGender is gender of all the riders or OTHER for mixed teams
+func teamGender(team *pb.Team) pb.Gender {
+	for _, rider := range team.Riders {
+		if rider.Gender != team.Riders[0].Gender {
+			return pb.Gender_OTHER
+		}
+	}
+	return team.Riders[0].Gender
+}
+
+// relayTracker follows legs of every team during the race
+type relayTracker struct {
+	teams     []*pb.Team
+	swaps     []uint32
+	destValue uint32
+	legs      map[int][]*pb.Leg
+}
+
+// newRelayTracker returns nil unless the current race is a relay
+func (s *Sprints) newRelayTracker() *relayTracker {
+	if s.tournament.Mode != pb.Tournament_RELAY || len(s.curRace.Teams) == 0 {
+		return nil
+	}
+	var r = &relayTracker{
+		teams:     s.curRace.Teams,
+		swaps:     s.tournament.SwapDistance,
+		destValue: s.curRace.DestValue,
+		legs:      make(map[int][]*pb.Leg, len(s.curRace.Teams)),
+	}
+	if len(r.swaps) == 0 {
+		legs := uint32(len(r.teams[0].Riders))
+		for k := uint32(1); k < legs; k++ {
+			r.swaps = append(r.swaps, r.destValue*k/legs)
+		}
+	}
+	return r
+}
+
+// start returns swaps introducing first riders of every team
+func (r *relayTracker) start() (swaps []*pb.Swap) {
+	for i, team := range r.teams {
+		swaps = append(swaps, &pb.Swap{PlayerNum: uint32(i), Rider: team.Riders[0]})
+	}
+	return
+}
+
+// update closes legs of the team which distance has been covered and
+// returns swaps which took place
+func (r *relayTracker) update(playerNum int, dist uint, ms uint32) (swaps []*pb.Swap) {
+	for leg := len(r.legs[playerNum]); leg < len(r.swaps) && dist >= uint(r.swaps[leg]); leg++ {
+		r.finishLeg(playerNum, ms)
+		swaps = append(swaps, &pb.Swap{
+			PlayerNum: uint32(playerNum),
+			Leg:       uint32(leg + 1),
+			Rider:     r.teams[playerNum].Riders[leg+1],
+			Distance:  r.swaps[leg],
+		})
+	}
+	return
+}
+
+// finish closes all the remaining legs of the team
+func (r *relayTracker) finish(playerNum int, ms uint32) {
+	r.update(playerNum, uint(r.destValue), ms)
+	if len(r.legs[playerNum]) == len(r.swaps) {
+		r.finishLeg(playerNum, ms)
+	}
+}
+
+func (r *relayTracker) finishLeg(playerNum int, ms uint32) {
+	var (
+		leg        = len(r.legs[playerNum])
+		legStart   uint32
+		legEnd     = r.destValue
+		prevFinish uint32
+	)
+	if leg > 0 {
+		legStart = r.swaps[leg-1]
+		prevFinish = r.legs[playerNum][leg-1].Time
+	}
+	if leg < len(r.swaps) {
+		legEnd = r.swaps[leg]
+	}
+	r.legs[playerNum] = append(r.legs[playerNum], &pb.Leg{
+		Rider:    r.teams[playerNum].Riders[leg],
+		Distance: legEnd - legStart,
+		Time:     ms,
+		LegTime:  float32(ms - prevFinish),
+	})
+}
+
+// GetLegResults ranks individual legs ridden in relay races of the tournament
+func (s *Sprints) GetLegResults(resultSpec *pb.ResultSpec, stream pb.Sprints_GetLegResultsServer) error {
+	var results []*pb.Result
+
+	s.mu.Lock()
+	tournament, err := s.getTournament(resultSpec.TournamentName)
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	for _, result := range lastRaces(tournament.Result, resultSpec.Last) {
+		if result.Disqualified || (resultSpec.RaceId > 0 && result.RaceId != resultSpec.RaceId) {
+			continue
+		}
+		for _, leg := range result.Legs {
+			if resultSpec.RaceId == 0 && leg.Rider.Gender != resultSpec.Gender {
+				continue
+			}
+			results = append(results, &pb.Result{
+				Player:    proto.Clone(leg.Rider).(*pb.Player),
+				Result:    leg.LegTime,
+				DestValue: leg.Distance,
+				RaceId:    result.RaceId,
+			})
+		}
+	}
+	core.SortResults(results, tournament.Mode)
+	s.mu.Unlock()
+
+	for _, result := range pageResults(results, resultSpec) {
+		if err := stream.Send(result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
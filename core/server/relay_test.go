@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+func TestRelayLegs(t *testing.T) {
+	var (
+		a1, a2 = &pb.Player{Name: "a1"}, &pb.Player{Name: "a2", Gender: pb.Gender_FEMALE}
+		race   = &pb.Race{
+			DestValue: 400,
+			Teams:     []*pb.Team{{Name: "a", Riders: []*pb.Player{a1, a2}}},
+		}
+		s = &Sprints{tournament: &pb.Tournament{Mode: pb.Tournament_RELAY}, curRace: race}
+	)
+	if err := s.setupRelay(race); err != nil {
+		t.Fatal(err)
+	}
+	if len(race.Players) != 1 || race.Players[0].Name != "a" || race.Players[0].Gender != pb.Gender_OTHER {
+		t.Errorf("lane should be taken by mixed team: %v", race.Players)
+	}
+
+	relay := s.newRelayTracker()
+	if swaps := relay.update(0, 150, 15000); len(swaps) != 0 {
+		t.Errorf("there should be no swap yet: %v", swaps)
+	}
+	if swaps := relay.update(0, 210, 20000); len(swaps) != 1 || swaps[0].Rider != a2 || swaps[0].Leg != 1 {
+		t.Errorf("a2 should take over: %v", swaps)
+	}
+	relay.finish(0, 50000)
+
+	legs := relay.legs[0]
+	if len(legs) != 2 || legs[0].LegTime != 20000 || legs[1].LegTime != 30000 || legs[1].Distance != 200 {
+		t.Errorf("unexpected legs: %v", legs)
+	}
+
+	race.Teams = append(race.Teams, &pb.Team{Name: "b", Riders: []*pb.Player{a1}})
+	if err := s.setupRelay(race); err == nil {
+		t.Error("teams with different number of riders should be rejected")
+	}
+}
+
+func TestDuplicateRelayTournament(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+
+	_, err := s.NewTournament(context.Background(), &pb.Tournament{
+		Name: "relay", Mode: pb.Tournament_RELAY, DestValue: 600, SwapDistance: []uint32{200, 400},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	duplicate, err := s.DuplicateTournament(context.Background(), &pb.TournamentRename{Name: "relay", NewName: "relay 2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(duplicate.SwapDistance) != 2 || duplicate.SwapDistance[1] != 400 {
+		t.Errorf("swap distances should be duplicated, got %v", duplicate.SwapDistance)
+	}
+}
@@ -4,73 +4,161 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/golang/protobuf/proto"
 	"github.com/kkoralsky/gosprints/core"
 	"github.com/kkoralsky/gosprints/core/device"
 	pb "github.com/kkoralsky/gosprints/proto"
-	"sort"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sync"
 	"time"
 )
 
+// errNoTournament is returned by handlers which need the current tournament
+var errNoTournament = status.Error(codes.FailedPrecondition, "No tournament loaded")
+
+// Sprints serves concurrent clients: exported handlers hold the mutex while
+// touching the state and unexported methods expect it to be held already. The
+// race goroutine reads the current race and tournament without the mutex
+// since they can't be replaced while the race is in progress.
 type Sprints struct {
+	mu          sync.Mutex
 	inputDevice device.InputDevice
 	visMux      *VisMux
 	starter     *pb.Starter
 	tournament  *pb.Tournament
 	curRace     *pb.Race
+	ghosts      []*pb.Result
+	falseStarts map[int]uint32
 	results     map[pb.Gender][]*pb.Result
 	abortRace   chan struct{}
 	sprintsDb   *SprintsDb
+	records     *Records
+	distFactor  uint
+	recording   recordingConfig
+	auth        *authenticator
 }
 
-func SetupSprints(device device.InputDevice, visMux *VisMux, sprintsDb *SprintsDb, countDownTime uint) (s *Sprints) {
-	var (
-		err        error
-		tournament *pb.Tournament
-	)
+func SetupSprints(device device.InputDevice, visMux *VisMux, sprintsDb *SprintsDb, cfg core.ServerConfig) (s *Sprints, err error) {
+	var auth *authenticator
+
+	if auth, err = setupAuthenticator(cfg); err != nil {
+		return nil, err
+	}
 	s = &Sprints{
 		inputDevice: device,
 		visMux:      visMux,
 		sprintsDb:   sprintsDb,
 		results:     make(map[pb.Gender][]*pb.Result, 3),
-		starter:     &pb.Starter{CountdownTime: uint32(countDownTime)},
+		starter:     &pb.Starter{CountdownTime: uint32(cfg.CountDownTime)},
+		records:     SetupRecords(nil),
+		distFactor:  cfg.DistFactor,
+		recording:   setupRecordingConfig(cfg),
+		auth:        auth,
 	}
-	tournament, err = s.sprintsDb.GetLastTournament()
+	s.warnLegacyResults()
+	s.numberLegacyResults()
+	s.reloadRecords()
+	if err = s.startTournament(); err != nil {
+		return nil, fmt.Errorf("couldnt load any tournament: %v", err)
+	}
+
+	return s, nil
+}
+
+// startTournament loads the newest tournament which can be raced, i.e. not
+// archived and without results in device units; otherwise the default
+// tournament is loaded, or created if it doesn't exist yet
+func (s *Sprints) startTournament() error {
+	tournaments, err := s.sprintsDb.ListTournaments()
 	if err != nil {
-		s.NewTournament(context.Background(), &core.DefultTournament)
-	} else {
+		return err
+	}
+	for i := len(tournaments) - 1; i >= 0; i-- {
+		if tournaments[i].Archived || legacyResults(tournaments[i]) {
+			continue
+		}
+		tournament, err := s.sprintsDb.GetTournament(tournaments[i].Name)
+		if err != nil {
+			return err
+		}
 		s.loadTournament(tournament)
+		return nil
 	}
-
-	return s
+	if tournament, err := s.sprintsDb.GetTournament(core.DefultTournament.Name); err == nil {
+		if err = checkLegacyResults(tournament); err != nil {
+			return err
+		}
+		s.loadTournament(tournament)
+		return nil
+	}
+	_, err = s.NewTournament(context.Background(), cloneTournament(&core.DefultTournament))
+	return err
 }
 
 func (s *Sprints) NewTournament(ctx context.Context, tournament *pb.Tournament) (*pb.Tournament, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tournament.Name == "" {
+		return nil, errors.New("tournament name not given")
+	}
+	if _, err := s.sprintsDb.GetTournament(tournament.Name); err == nil && !tournament.Overwrite {
+		return nil, fmt.Errorf("tournament %s already exists", tournament.Name)
+	}
+	if s.curRace != nil {
+		return nil, errors.New("race in progress")
+	}
+	tournament.Overwrite = false
+	tournament.ResultsInMeters = true
 	s.tournament = tournament
 	s.results[pb.Gender_MALE] = []*pb.Result{}
 	s.results[pb.Gender_FEMALE] = []*pb.Result{}
 	s.results[pb.Gender_OTHER] = []*pb.Result{}
 
-	s.sprintsDb.SaveTournament(s.tournament)
+	if err := s.sprintsDb.SaveTournament(s.tournament); err != nil {
+		return nil, err
+	}
+	s.reloadRecords()
 
-	return tournament, s.visMux.NewTournament(tournament)
+	return cloneTournament(tournament), s.visMux.NewTournament(cloneTournament(tournament))
 }
 
 func (s *Sprints) LoadTournament(ctx context.Context, tournamentSpec *pb.TournamentSpec) (*pb.Tournament, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.curRace != nil {
+		return nil, errors.New("race in progress")
+	}
 	tournament, err := s.sprintsDb.GetTournament(tournamentSpec.Name)
 	if err != nil {
 		return nil, err
 	}
+	if err = checkLegacyResults(tournament); err != nil {
+		return nil, err
+	}
 	s.loadTournament(tournament)
-	return tournament, nil
+	return cloneTournament(tournament), nil
 }
 
 func (s *Sprints) loadTournament(tournament *pb.Tournament) {
 	s.tournament = tournament
+	s.groupResults()
+	s.visMux.NewTournament(cloneTournament(tournament))
+}
+
+// groupResults splits results of the current tournament by gender leaving out
+// the disqualified ones
+func (s *Sprints) groupResults() {
 	s.results[pb.Gender_MALE] = make([]*pb.Result, 0)
 	s.results[pb.Gender_FEMALE] = make([]*pb.Result, 0)
 	s.results[pb.Gender_OTHER] = make([]*pb.Result, 0)
 
 	for _, result := range s.tournament.Result {
+		if result.Disqualified {
+			continue
+		}
 		s.results[result.Player.Gender] = append(s.results[result.Player.Gender], result)
 		core.DebugLogger.Printf(
 			"%s (%s): %.3f loaded", result.Player.Name,
@@ -78,15 +166,105 @@ func (s *Sprints) loadTournament(tournament *pb.Tournament) {
 			result.Result,
 		)
 	}
-	s.visMux.NewTournament(tournament)
+}
+
+// reloadRecords recomputes records from all the stored tournaments and saves
+// the ones whose results got flagged differently
+func (s *Sprints) reloadRecords() {
+	tournaments, err := s.sprintsDb.GetTournaments()
+	if err != nil {
+		core.ErrorLogger.Printf("couldnt load tournaments for records: %v", err)
+		return
+	}
+	// backends may return copies; flag the results of the current tournament
+	for i, tournament := range tournaments {
+		if s.tournament != nil && tournament.Name == s.tournament.Name {
+			tournaments[i] = s.tournament
+		}
+	}
+	for _, tournament := range s.records.Load(tournaments) {
+		if err = s.sprintsDb.SaveTournament(tournament); err != nil {
+			core.ErrorLogger.Printf("couldnt save record flags of %s: %v", tournament.Name, err)
+		}
+	}
+}
+
+// numberLegacyResults gives ids to results stored before ids were introduced
+// so they can be corrected
+func (s *Sprints) numberLegacyResults() {
+	tournaments, err := s.sprintsDb.GetTournaments()
+	if err != nil {
+		core.ErrorLogger.Printf("couldnt load tournaments for numbering results: %v", err)
+		return
+	}
+	for _, tournament := range tournaments {
+		var numbered int
+		for _, result := range tournament.Result {
+			if result.Id == 0 {
+				tournament.LastResultId++
+				result.Id = tournament.LastResultId
+				numbered++
+			}
+		}
+		if numbered == 0 {
+			continue
+		}
+		if err = s.sprintsDb.SaveTournament(tournament); err != nil {
+			core.ErrorLogger.Printf("couldnt number results of %s: %v", tournament.Name, err)
+			continue
+		}
+		core.InfoLogger.Printf("%s: %d results numbered", tournament.Name, numbered)
+	}
+}
+
+// warnLegacyResults points out tournaments whose results need converting
+// before they can be loaded
+func (s *Sprints) warnLegacyResults() {
+	tournaments, err := s.sprintsDb.ListTournaments()
+	if err != nil {
+		core.ErrorLogger.Printf("couldnt list tournaments: %v", err)
+		return
+	}
+	for _, tournament := range tournaments {
+		if err = checkLegacyResults(tournament); err != nil {
+			core.ErrorLogger.Println(err)
+		}
+	}
+}
+
+// getTournament returns either current tournament or the stored one if its
+// name is given
+func (s *Sprints) getTournament(name string) (*pb.Tournament, error) {
+	if name == "" || (s.tournament != nil && s.tournament.Name == name) {
+		if s.tournament == nil {
+			return nil, errNoTournament
+		}
+		return s.tournament, nil
+	}
+	tournament, err := s.sprintsDb.GetTournament(name)
+	if err != nil {
+		return nil, fmt.Errorf("tournament %s: %v", name, err)
+	}
+	return tournament, nil
 }
 
 func (s *Sprints) GetTournamentNames(context.Context, *pb.Empty) (*pb.TournamentNames, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	var tournamentNames = &pb.TournamentNames{Name: []string{}}
 
-	if s.sprintsDb != nil && s.sprintsDb.tournaments != nil {
-		for _, tournament := range s.sprintsDb.tournaments.Tournament {
-			tournamentNames.Name = append(tournamentNames.Name, tournament.Name)
+	if s.sprintsDb != nil {
+		tournaments, err := s.sprintsDb.ListTournaments()
+		if err != nil {
+			return nil, err
+		}
+		for _, tournament := range tournaments {
+			if tournament.Archived {
+				tournamentNames.Archived = append(tournamentNames.Archived, tournament.Name)
+			} else {
+				tournamentNames.Name = append(tournamentNames.Name, tournament.Name)
+			}
 		}
 		return tournamentNames, nil
 	}
@@ -94,52 +272,114 @@ func (s *Sprints) GetTournamentNames(context.Context, *pb.Empty) (*pb.Tournament
 }
 
 func (s *Sprints) GetCurrentTournament(context.Context, *pb.Empty) (*pb.Tournament, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if s.tournament != nil {
-		return s.tournament, nil
+		return cloneTournament(s.tournament), nil
 	}
-	return nil, errors.New("No tournament loaded")
-
+	return nil, errNoTournament
 }
 
 func (s *Sprints) ShowResults(_ context.Context, resultSpec *pb.ResultSpec) (*pb.Empty, error) {
-	if s.tournament != nil {
-		s.visMux.ShowResults(&pb.Results{Result: s.results[resultSpec.Gender]})
-		return nil, nil
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results, err := s.getResults(resultSpec)
+	if err != nil {
+		return nil, err
 	}
-	return nil, errors.New("No tournament loaded")
+	s.visMux.ShowResults(&pb.Results{Result: cloneResults(results)})
+	return &pb.Empty{}, nil
 }
 
 func (s *Sprints) NewRace(ctx context.Context, race *pb.Race) (*pb.Empty, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tournament == nil {
+		return nil, errNoTournament
+	}
+	if s.abortRace != nil {
+		return nil, errors.New("race in progress")
+	}
+	if err := s.setupRelay(race); err != nil {
+		return nil, err
+	}
+	s.setupCategories(race)
+	// handicaps are given for the players only, before any ghost lanes
+	if err := s.setupHandicaps(race); err != nil {
+		return nil, err
+	}
+	if err := s.setupGhosts(race); err != nil {
+		return nil, err
+	}
+	if err := s.setupRaceMode(race); err != nil {
+		return nil, err
+	}
 	s.curRace = race
+	s.falseStarts = make(map[int]uint32, len(race.Players))
 	err := s.visMux.NewRace(race)
 	return &pb.Empty{}, err
 }
 
 func (s *Sprints) StartRace(_ context.Context, _ *pb.Empty) (*pb.Player, error) {
+	s.mu.Lock()
+	if s.tournament == nil {
+		s.mu.Unlock()
+		return &pb.Player{}, errNoTournament
+	}
 	if s.curRace == nil {
+		s.mu.Unlock()
 		return &pb.Player{}, errors.New("race is not established")
 	}
+	if s.abortRace != nil {
+		s.mu.Unlock()
+		return &pb.Player{}, errors.New("race already started")
+	}
+	var abort = make(chan struct{})
+	s.abortRace = abort
 	s.visMux.StartRace(s.starter)
 	s.inputDevice.Clean()
-	s.abortRace = make(chan struct{}, 10) // allow to up to 10 unhandled "aborts"
-	time.Sleep(time.Duration(s.starter.CountdownTime) * time.Millisecond)
+	countdown := time.Duration(s.starter.CountdownTime) * time.Millisecond
+	s.mu.Unlock()
+
+	// don't block other clients during the countdown
+	time.Sleep(countdown)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if aborted(abort) {
+		s.abortRace = nil
+		return &pb.Player{}, errors.New("race aborted during the countdown")
+	}
 	if playerNum, err := s.inputDevice.Check(); err != nil {
+		s.abortRace = nil
 		return &pb.Player{}, err
 	} else {
 		if playerNum >= 0 {
+			s.abortRace = nil
+			s.falseStarts[playerNum]++
 			s.visMux.AbortRace(&pb.AbortMessage{Message: fmt.Sprintf("%s false-started", s.curRace.Players[playerNum].Name)})
 			return s.curRace.Players[playerNum], nil
 		}
 	}
 
-	go s.doRace()
+	go s.doRace(abort)
 
 	return &pb.Player{}, nil
 }
 
 func (s *Sprints) AbortRace(_ context.Context, abortMessage *pb.AbortMessage) (*pb.Empty, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if s.curRace != nil {
-		s.abortRace <- struct{}{}
+		// the channel is closed only once; aborting again is a no-op
+		if s.abortRace != nil && !aborted(s.abortRace) {
+			close(s.abortRace)
+		}
 		s.visMux.AbortRace(abortMessage)
 	}
 
@@ -147,29 +387,17 @@ func (s *Sprints) AbortRace(_ context.Context, abortMessage *pb.AbortMessage) (*
 }
 
 func (s *Sprints) ConfigureVis(_ context.Context, visCfg *pb.VisConfiguration) (*pb.Empty, error) {
-	s.visMux.ConfigureVis(visCfg)
-
-	return &pb.Empty{}, nil
+	return &pb.Empty{}, s.visMux.ConfigureVis(visCfg)
 }
 
 func (s *Sprints) GetResults(resultSpec *pb.ResultSpec, stream pb.Sprints_GetResultsServer) error {
-	var results = make([]*pb.Result, len(s.results[resultSpec.Gender]))
-	copy(results, s.results[resultSpec.Gender])
-	sort.Slice(results, func(i, j int) bool {
-		if s.tournament.Mode == pb.Tournament_TIME {
-			if results[i].DestValue == results[j].DestValue {
-				return results[i].Result > results[j].Result
-			} else {
-				return results[i].DestValue < results[j].DestValue
-			}
-		} else {
-			if results[i].DestValue == results[j].DestValue {
-				return results[i].Result < results[j].Result
-			} else {
-				return results[i].DestValue > results[j].DestValue
-			}
-		}
-	})
+	s.mu.Lock()
+	results, err := s.getResults(resultSpec)
+	results = cloneResults(results)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
 
 	core.DebugLogger.Printf("sending %d sorted results", len(results))
 
@@ -181,40 +409,162 @@ func (s *Sprints) GetResults(resultSpec *pb.ResultSpec, stream pb.Sprints_GetRes
 	return nil
 }
 
-func (s *Sprints) doRace() {
+// getResults returns sorted results of the tournament given in resultSpec (or
+// the current one) narrowed down to the requested page
+func (s *Sprints) getResults(resultSpec *pb.ResultSpec) ([]*pb.Result, error) {
+	var results []*pb.Result
+
+	tournament, err := s.getTournament(resultSpec.TournamentName)
+	if err != nil {
+		return nil, err
+	}
+	if resultSpec.RaceId > 0 {
+		for _, result := range tournament.Result {
+			if result.RaceId == resultSpec.RaceId && !result.Disqualified {
+				results = append(results, result)
+			}
+		}
+	} else if tournament == s.tournament && resultSpec.Last == 0 {
+		results = make([]*pb.Result, len(s.results[resultSpec.Gender]))
+		copy(results, s.results[resultSpec.Gender])
+	} else {
+		for _, result := range lastRaces(tournament.Result, resultSpec.Last) {
+			if result.Player.Gender == resultSpec.Gender && !result.Disqualified {
+				results = append(results, result)
+			}
+		}
+	}
+
+	core.SortResults(results, tournament.Mode)
+
+	return pageResults(results, resultSpec), nil
+}
+
+// lastRaces leaves only results of the last n races (all of them if n is 0)
+// out of the results given in the order they were raced; results from before
+// races had ids count as races of their own
+func lastRaces(results []*pb.Result, n uint32) []*pb.Result {
+	if n == 0 {
+		return results
+	}
+	var i = len(results)
+	for races := uint32(0); i > 0; i-- {
+		raceId := results[i-1].RaceId
+		if raceId == 0 || i == len(results) || raceId != results[i].RaceId {
+			if races == n {
+				break
+			}
+			races++
+		}
+	}
+	return results[i:]
+}
+
+// pageResults narrows down sorted results to the page given in resultSpec
+func pageResults(results []*pb.Result, resultSpec *pb.ResultSpec) []*pb.Result {
+	if int(resultSpec.Offset) >= len(results) {
+		return []*pb.Result{}
+	}
+	results = results[resultSpec.Offset:]
+	if resultSpec.Top > 0 && int(resultSpec.Top) < len(results) {
+		results = results[:resultSpec.Top]
+	}
+	return results
+}
+
+func (s *Sprints) GetPersonalBests(player *pb.Player, stream pb.Sprints_GetPersonalBestsServer) error {
+	s.mu.Lock()
+	records := cloneRecords(s.records.PersonalBests(player.Name))
+	s.mu.Unlock()
+
+	for _, record := range records {
+		if err := stream.Send(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Sprints) GetRecords(recordSpec *pb.RecordSpec, stream pb.Sprints_GetRecordsServer) error {
+	s.mu.Lock()
+	records := cloneRecords(s.records.AllTime(recordSpec.Gender, recordSpec.Category))
+	s.mu.Unlock()
+
+	for _, record := range records {
+		if err := stream.Send(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// doRace runs the race until it's finished or the abort channel gets closed
+func (s *Sprints) doRace(abort <-chan struct{}) {
 	var (
 		playersCount = len(s.curRace.Players)
 		playersDists = make(map[int]uint, playersCount)
-		results      map[int]uint
+		startDists   = make(map[int]uint, playersCount)
+		results      map[int]float32
+		start        time.Time
+		recorder     *raceRecorder
+		relay        *relayTracker
+		outcome      = newRaceOutcome()
 		getDistance  = func(i int) uint {
-			if dist, err := s.inputDevice.GetDist(uint(i)); err != nil {
+			var (
+				now      = time.Now()
+				handicap = s.getHandicap(i)
+				trace    = s.ghostTrace(i)
+				dist     uint
+				err      error
+			)
+			// ghost lanes repeat the recorded speed trace
+			if trace != nil {
+				dist = ghostDistance(trace, now.Sub(start))
+			} else {
+				dist, err = s.inputDevice.GetDist(uint(i))
+			}
+			if err != nil {
 				core.DebugLogger.Printf(err.Error())
-			} else if dist != playersDists[i] {
-				playersDists[i] = dist
-				s.visMux.SendRaceUpdate(uint32(i), uint32(dist))
+			} else {
+				// distance counts from the player's own start and includes
+				// distance bonus
+				if now.Before(start.Add(time.Duration(handicap.StartDelay)*time.Millisecond)) || dist < startDists[i] {
+					startDists[i] = dist
+				}
+				if dist = dist - startDists[i] + uint(handicap.DistanceBonus); dist != playersDists[i] {
+					playersDists[i] = dist
+					s.visMux.SendRaceUpdate(uint32(i), uint32(dist))
+				}
 			}
+			recorder.record(i, playersDists[i], now)
 			return playersDists[i]
 		}
-		doDistanceRace = func() (playersTimes map[int]uint) {
-			var (
-				wholeDistance = uint(s.curRace.DestValue)
-				start         = time.Now()
-			)
-			playersTimes = make(map[int]uint, playersCount)
+		doDistanceRace = func() (playersTimes map[int]float32) {
+			var wholeDistance = uint(s.curRace.DestValue)
+
+			playersTimes = make(map[int]float32, playersCount)
 
 			for playersFinished := 0; playersFinished < playersCount; {
 				for i := 0; i < playersCount; i++ {
 					select {
-					case _, ok := <-s.abortRace:
-						if ok {
-							close(s.abortRace)
-						}
+					case <-abort:
 						return
 					default:
-						if getDistance(i) >= wholeDistance {
+						dist := getDistance(i)
+						if relay != nil && playersTimes[i] == 0 {
+							for _, swap := range relay.update(i, dist, milliseconds(time.Now().Sub(start))) {
+								s.visMux.SwapRiders(swap)
+							}
+						}
+						if dist >= wholeDistance {
 							if playersTimes[i] == 0 {
+								ms := uint(time.Now().Sub(start) / time.Millisecond)
 								playersFinished++
-								playersTimes[i] = uint(time.Now().Sub(start) / time.Millisecond)
+								playersTimes[i] = float32(ms)
+								recorder.finish(i, wholeDistance, ms)
+								if relay != nil {
+									relay.finish(i, uint32(ms))
+								}
 								core.DebugLogger.Printf("player #%d finished", i)
 							}
 						}
@@ -224,18 +574,17 @@ func (s *Sprints) doRace() {
 			}
 			return
 		}
-		doTimedRace = func() map[int]uint {
+		doTimedRace = func() map[int]float32 {
 			var (
-				start        = time.Now()
-				raceDuration = time.Duration(s.curRace.DestValue) * time.Second
-				finish       = start.Add(raceDuration)
+				raceDuration  = time.Duration(s.curRace.DestValue) * time.Second
+				finish        = start.Add(raceDuration)
+				playersMeters = make(map[int]float32, playersCount)
 			)
 
 			for now := time.Now(); now.Before(finish); now = time.Now() {
 				for i := 0; i < playersCount; i++ {
 					select {
-					case <-s.abortRace:
-						close(s.abortRace)
+					case <-abort:
 						return nil
 					default:
 						getDistance(i)
@@ -243,32 +592,112 @@ func (s *Sprints) doRace() {
 				}
 				time.Sleep(time.Millisecond)
 			}
-			return playersDists
+			for i := 0; i < playersCount; i++ {
+				recorder.finish(i, playersDists[i], uint(raceDuration/time.Millisecond))
+				playersMeters[i] = core.Meters(recorder.distanceAt(i, raceDuration), s.distFactor)
+			}
+			return playersMeters
 		}
-		finishRace = func(results map[int]uint) {
+		finishRace = func(results map[int]float32) {
 			var protoResults []*pb.Result
 
-			for playerNum, result := range results {
-				playerGender := s.curRace.Players[playerNum].Gender
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			s.tournament.LastRaceId++
+			// keep lane order so that visuals match results with colors
+			for playerNum := 0; playerNum < playersCount; playerNum++ {
+				result, ok := results[playerNum]
+				if !ok {
+					continue
+				}
+				var (
+					handicap  = s.getHandicap(playerNum)
+					rawResult = result
+				)
+				if s.tournament.Mode != pb.Tournament_TIME {
+					rawResult -= float32(handicap.StartDelay)
+				} else {
+					rawResult -= core.Meters(float32(handicap.DistanceBonus), s.distFactor)
+				}
 				resultPb := &pb.Result{
-					DestValue: s.curRace.DestValue,
-					Player:    s.curRace.Players[playerNum],
-					Result:    float32(result),
+					DestValue:      s.curRace.DestValue,
+					Player:         s.curRace.Players[playerNum],
+					Result:         result,
+					RaceId:         s.tournament.LastRaceId,
+					StartTimestamp: start.UnixNano() / int64(time.Millisecond),
+					Lane:           uint32(playerNum),
+					Opponents:      s.opponents(playerNum),
+					CountdownTime:  s.starter.CountdownTime,
+					FalseStarts:    s.falseStarts[playerNum],
+					DeviceType:     s.inputDevice.Type(),
+					Split:          recorder.splits[playerNum],
+					Trace:          recorder.trace[playerNum],
+					ReachedAt:      recorder.reachedAt(playerNum),
+					RawResult:      rawResult,
+				}
+				if len(s.curRace.Handicap) > 0 {
+					resultPb.Handicap = handicap
+				}
+				if relay != nil {
+					resultPb.Legs = relay.legs[playerNum]
+				}
+				if playerNum < len(s.tournament.Color) {
+					resultPb.Color = s.tournament.Color[playerNum]
+				}
+				if s.ghostTrace(playerNum) != nil {
+					resultPb.Ghost = true
+					resultPb.DeviceType = ghostDeviceType
+				}
+				if place, ok := outcome.places[playerNum]; ok {
+					resultPb.Place = place
+					resultPb.Eliminated = outcome.eliminated[playerNum]
 				}
 				protoResults = append(protoResults, resultPb)
+			}
+			if len(outcome.places) == 0 {
+				ranking := append([]*pb.Result(nil), protoResults...)
+				core.SortResults(ranking, s.tournament.Mode)
+				for i, resultPb := range ranking {
+					resultPb.Place = uint32(i + 1)
+				}
+			}
+			for _, resultPb := range protoResults {
+				if resultPb.Ghost {
+					continue
+				}
+				playerGender := resultPb.Player.Gender
 				s.results[playerGender] = append(s.results[playerGender], resultPb)
 				s.persistResult(resultPb)
 			}
 			s.curRace = nil
-			s.visMux.FinishRace(&pb.Results{Result: protoResults})
+			s.ghosts = nil
+			s.abortRace = nil
+			s.visMux.FinishRace(&pb.Results{Result: cloneResults(protoResults)})
 		}
 	)
 
 	s.visMux.SetupRacers()
+	start = time.Now()
+	recorder = s.recording.newRecorder(s.tournament.Mode, s.curRace.DestValue, start)
+	for i := 0; i < playersCount; i++ {
+		playersDists[i] = uint(s.getHandicap(i).DistanceBonus)
+		recorder.offset(i, playersDists[i])
+	}
+	if relay = s.newRelayTracker(); relay != nil {
+		for _, swap := range relay.start() {
+			s.visMux.SwapRiders(swap)
+		}
+	}
 
-	if s.tournament.Mode == pb.Tournament_TIME {
+	switch s.tournament.Mode {
+	case pb.Tournament_TIME:
 		results = doTimedRace()
-	} else if s.tournament.Mode == pb.Tournament_DISTANCE {
+	case pb.Tournament_ELIMINATION:
+		results = s.doEliminationRace(start, abort, getDistance, recorder, outcome)
+	case pb.Tournament_PURSUIT:
+		results = s.doPursuitRace(start, abort, getDistance, recorder, outcome)
+	default:
 		results = doDistanceRace()
 	}
 
@@ -276,9 +705,57 @@ func (s *Sprints) doRace() {
 	finishRace(results)
 }
 
+// opponents returns all the other players of the current race
+func (s *Sprints) opponents(playerNum int) (opponents []*pb.Player) {
+	for i, player := range s.curRace.Players {
+		if i != playerNum {
+			opponents = append(opponents, player)
+		}
+	}
+	return
+}
+
 func (s *Sprints) persistResult(resultPb *pb.Result) {
+	s.tournament.LastResultId++
+	resultPb.Id = s.tournament.LastResultId
+	resultPb.PersonalBest, resultPb.Record = s.records.Update(s.tournament, resultPb)
+	if resultPb.Record {
+		core.InfoLogger.Printf("%s set a new record: %.3f", resultPb.Player.Name, resultPb.Result)
+	}
 	s.tournament.Result = append(s.tournament.Result, resultPb)
-	if err := s.sprintsDb.SaveTournament(s.tournament); err != nil {
+	if err := s.sprintsDb.AddResult(s.tournament, resultPb); err != nil {
 		core.ErrorLogger.Fatalf("error while saving tournament: %v", err)
 	}
 }
+
+// aborted tells whether the abort channel of the race has been closed
+func aborted(abort <-chan struct{}) bool {
+	select {
+	case <-abort:
+		return true
+	default:
+		return false
+	}
+}
+
+// cloneTournament copies the tournament so that it can be sent to clients
+// while the original one changes
+func cloneTournament(tournament *pb.Tournament) *pb.Tournament {
+	return proto.Clone(tournament).(*pb.Tournament)
+}
+
+func cloneResults(results []*pb.Result) []*pb.Result {
+	var clones = make([]*pb.Result, len(results))
+	for i, result := range results {
+		clones[i] = proto.Clone(result).(*pb.Result)
+	}
+	return clones
+}
+
+func cloneRecords(records []*pb.Record) []*pb.Record {
+	var clones = make([]*pb.Record, len(records))
+	for i, record := range records {
+		clones[i] = proto.Clone(record).(*pb.Record)
+	}
+	return clones
+}
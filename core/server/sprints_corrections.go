@@ -0,0 +1,149 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/kkoralsky/gosprints/core"
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+func (s *Sprints) EditResult(_ context.Context, resultEdit *pb.ResultEdit) (*pb.Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if resultEdit.Player == nil || resultEdit.Player.Name == "" {
+		return nil, errors.New("player not given")
+	}
+	tournament, i, err := s.findResult(resultEdit)
+	if err != nil {
+		return nil, err
+	}
+	var result = tournament.Result[i]
+
+	s.audit(tournament, "edit", result.Id, fmt.Sprintf("player: %s (%s) -> %s (%s)",
+		result.Player.Name, result.Player.Gender, resultEdit.Player.Name, resultEdit.Player.Gender))
+	result.Player = resultEdit.Player
+
+	return proto.Clone(result).(*pb.Result), s.saveCorrection(tournament)
+}
+
+func (s *Sprints) DisqualifyResult(_ context.Context, resultEdit *pb.ResultEdit) (*pb.Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tournament, i, err := s.findResult(resultEdit)
+	if err != nil {
+		return nil, err
+	}
+	var result = tournament.Result[i]
+
+	if result.Disqualified {
+		return nil, fmt.Errorf("result %d already disqualified", result.Id)
+	}
+	result.Disqualified = true
+	result.DisqualificationReason = resultEdit.Reason
+	s.audit(tournament, "disqualify", result.Id, fmt.Sprintf("%s: %s", result.Player.Name, resultEdit.Reason))
+
+	return proto.Clone(result).(*pb.Result), s.saveCorrection(tournament)
+}
+
+func (s *Sprints) DeleteResult(_ context.Context, resultEdit *pb.ResultEdit) (*pb.Empty, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tournament, i, err := s.findResult(resultEdit)
+	if err != nil {
+		return nil, err
+	}
+	var result = tournament.Result[i]
+
+	tournament.Result = append(tournament.Result[:i], tournament.Result[i+1:]...)
+	s.audit(tournament, "delete", result.Id, fmt.Sprintf("%s: %.3f %s", result.Player.Name,
+		result.Result, resultEdit.Reason))
+
+	return &pb.Empty{}, s.saveCorrection(tournament)
+}
+
+func (s *Sprints) UndoLastRace(_ context.Context, tournamentSpec *pb.TournamentSpec) (*pb.Results, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		lastRaceId uint32
+		kept       []*pb.Result
+		undone     = &pb.Results{}
+	)
+	tournament, err := s.getTournament(tournamentSpec.Name)
+	if err != nil {
+		return nil, err
+	}
+	if tournament == s.tournament && s.curRace != nil {
+		return nil, errors.New("race in progress")
+	}
+
+	for _, result := range tournament.Result {
+		if result.RaceId > lastRaceId {
+			lastRaceId = result.RaceId
+		}
+	}
+	if lastRaceId == 0 {
+		return nil, errors.New("no race to undo")
+	}
+	for _, result := range tournament.Result {
+		if result.RaceId == lastRaceId {
+			undone.Result = append(undone.Result, result)
+			s.audit(tournament, "undo", result.Id, fmt.Sprintf("race #%d %s: %.3f",
+				lastRaceId, result.Player.Name, result.Result))
+		} else {
+			kept = append(kept, result)
+		}
+	}
+	tournament.Result = kept
+
+	return undone, s.saveCorrection(tournament)
+}
+
+// findResult looks up the result by its id in the tournament given by the
+// resultEdit
+func (s *Sprints) findResult(resultEdit *pb.ResultEdit) (*pb.Tournament, int, error) {
+	tournament, err := s.getTournament(resultEdit.TournamentName)
+	if err != nil {
+		return nil, -1, err
+	}
+	if tournament == s.tournament && s.curRace != nil {
+		return nil, -1, errors.New("race in progress")
+	}
+	for i, result := range tournament.Result {
+		if result.Id == resultEdit.ResultId {
+			return tournament, i, nil
+		}
+	}
+	return nil, -1, fmt.Errorf("result %d not found in %s", resultEdit.ResultId, tournament.Name)
+}
+
+func (s *Sprints) audit(tournament *pb.Tournament, action string, resultId uint32, details string) {
+	core.InfoLogger.Printf("%s: %s result %d: %s", tournament.Name, action, resultId, details)
+	tournament.Audit = append(tournament.Audit, &pb.AuditEntry{
+		Timestamp: time.Now().Unix(),
+		Action:    action,
+		ResultId:  resultId,
+		Details:   details,
+	})
+}
+
+// saveCorrection persists corrected tournament and recomputes everything
+// derived from its results
+func (s *Sprints) saveCorrection(tournament *pb.Tournament) error {
+	if err := s.sprintsDb.SaveTournament(tournament); err != nil {
+		return err
+	}
+	if tournament == s.tournament {
+		s.groupResults()
+	}
+	s.reloadRecords()
+	return nil
+}
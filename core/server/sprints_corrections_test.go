@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+// storedResults reads results of the tournament back from the db file
+func storedResults(t *testing.T, s *Sprints, name string) map[uint32]*pb.Result {
+	storage, err := setupProtoStorage(s.sprintsDb.Storage.(*protoStorage).fileName, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tournament, err := storage.GetTournament(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var results = make(map[uint32]*pb.Result)
+	for _, result := range tournament.Result {
+		results[result.Id] = result
+	}
+	return results
+}
+
+func TestCorrections(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+
+	// stored before results had ids
+	err := s.sprintsDb.SaveTournament(&pb.Tournament{
+		Name:      "stored",
+		Mode:      pb.Tournament_DISTANCE,
+		DestValue: 400,
+		Result: []*pb.Result{
+			raceResult(1, "a", pb.Gender_MALE, 20000),
+			raceResult(1, "b", pb.Gender_MALE, 21000),
+			raceResult(2, "a", pb.Gender_MALE, 19000),
+			raceResult(3, "c", pb.Gender_MALE, 18000),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.numberLegacyResults()
+	s.reloadRecords()
+
+	stored := storedResults(t, s, "stored")
+	if len(stored) != 4 || stored[4] == nil || stored[4].Player.Name != "c" {
+		t.Fatalf("legacy results should be numbered and saved, got %v", stored)
+	}
+	if !stored[3].PersonalBest || !stored[3].Record || stored[4].PersonalBest || !stored[4].Record {
+		t.Errorf("results should be flagged: %v %v", stored[3], stored[4])
+	}
+
+	edit := func(id uint32) *pb.ResultEdit {
+		return &pb.ResultEdit{TournamentName: "stored", ResultId: id, Reason: "test"}
+	}
+	if _, err = s.EditResult(context.Background(), edit(4)); err == nil {
+		t.Error("result shouldn't be edited without player")
+	}
+	correction := edit(4)
+	correction.Player = &pb.Player{Name: "a", Gender: pb.Gender_MALE}
+	if _, err = s.EditResult(context.Background(), correction); err != nil {
+		t.Fatal(err)
+	}
+	if stored = storedResults(t, s, "stored"); stored[4].Player.Name != "a" || !stored[4].PersonalBest {
+		t.Errorf("edited result should be personal best of a: %v", stored[4])
+	}
+
+	if _, err = s.DisqualifyResult(context.Background(), edit(1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.DisqualifyResult(context.Background(), edit(1)); err == nil {
+		t.Error("result shouldn't be disqualified twice")
+	}
+	if stored = storedResults(t, s, "stored"); !stored[1].Disqualified || stored[3].PersonalBest || !stored[3].Record {
+		t.Errorf("disqualified result shouldn't count: %v %v", stored[1], stored[3])
+	}
+
+	if _, err = s.DeleteResult(context.Background(), edit(3)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.DeleteResult(context.Background(), edit(3)); err == nil {
+		t.Error("deleted result shouldn't be found")
+	}
+	if stored = storedResults(t, s, "stored"); stored[3] != nil || stored[4].PersonalBest || !stored[4].Record {
+		t.Errorf("deleted result shouldn't count: %v", stored)
+	}
+
+	undone, err := s.UndoLastRace(context.Background(), &pb.TournamentSpec{Name: "stored"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(undone.Result) != 1 || undone.Result[0].Id != 4 {
+		t.Errorf("last race should be undone, got %v", undone.Result)
+	}
+	if stored = storedResults(t, s, "stored"); len(stored) != 2 {
+		t.Errorf("2 results should be left, got %v", stored)
+	}
+	records := s.records.AllTime(pb.Gender_MALE, "")
+	if len(records) != 1 || records[0].Result.Player.Name != "b" {
+		t.Errorf("b should hold the record now, got %v", records)
+	}
+
+	tournament, _ := s.sprintsDb.GetTournament("stored")
+	var actions []string
+	for _, entry := range tournament.Audit {
+		actions = append(actions, entry.Action)
+	}
+	if len(actions) != 4 || actions[0] != "edit" || actions[3] != "undo" {
+		t.Errorf("every correction should be audited, got %v", actions)
+	}
+}
+
+func TestCorrectionDuringRace(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+
+	if _, err := s.NewRace(context.Background(), newTestRace(5000)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.StartRace(context.Background(), &pb.Empty{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.UndoLastRace(context.Background(), &pb.TournamentSpec{}); err == nil {
+		t.Error("race in progress shouldn't be undone")
+	}
+	if _, err := s.DisqualifyResult(context.Background(), &pb.ResultEdit{ResultId: 1}); err == nil {
+		t.Error("results shouldn't be corrected during the race")
+	}
+	s.AbortRace(context.Background(), &pb.AbortMessage{Message: "test"})
+	waitForRace(t, s)
+}
@@ -2,123 +2,98 @@ package server
 
 import (
 	"errors"
-	"github.com/golang/protobuf/proto"
-	"github.com/kkoralsky/gosprints/core"
-	pb "github.com/kkoralsky/gosprints/proto"
+	"fmt"
 	"os"
+	"strings"
+	"syscall"
+
+	pb "github.com/kkoralsky/gosprints/proto"
 )
 
 const (
-	dbFileFlags = os.O_EXCL | os.O_RDWR | os.O_SYNC
-	dbFileMode  = 0666
+	ProtoBackend = "pb"
+	BoltBackend  = "bolt"
 )
 
+const lockSuffix = ".lock"
+
+var errNotFound = errors.New("not found")
+
+// Storage is the persistence backend of SprintsDb
+type Storage interface {
+	// ListTournaments returns tournaments in the order of creation; their
+	// results and registered players don't have to be populated
+	ListTournaments() ([]*pb.Tournament, error)
+	// GetTournaments returns all the tournaments along with their results
+	GetTournaments() ([]*pb.Tournament, error)
+	GetTournament(name string) (*pb.Tournament, error)
+	SaveTournament(tournament *pb.Tournament) error
+	// AddResult persists result which has just been appended to the tournament
+	AddResult(tournament *pb.Tournament, result *pb.Result) error
+	RenameTournament(name, newName string) error
+	DeleteTournament(name string) error
+	// GetPlayerResults returns results of the player from all the tournaments;
+	// the name is matched regardless of case and surrounding spaces
+	GetPlayerResults(playerName string) ([]*pb.Record, error)
+	Close() error
+}
+
 type SprintsDb struct {
-	file        *os.File
-	tournaments *pb.Tournaments
+	Storage
 }
 
-func SetupSprintsDb(fileName string) (s *SprintsDb, err error) {
-	var (
-		b          []byte
-		n          int
-		dbFileInfo os.FileInfo
-	)
-	s = &SprintsDb{}
-	dbFileInfo, err = os.Stat(fileName)
-	if os.IsNotExist(err) {
-		s.file, err = os.OpenFile(fileName, dbFileFlags|os.O_CREATE, dbFileMode)
-	} else if err != nil {
-		return
-	} else {
-		b = make([]byte, dbFileInfo.Size())
-		s.file, err = os.OpenFile(fileName, dbFileFlags, dbFileMode)
-	}
+func SetupSprintsDb(backend, fileName string, backups uint) (*SprintsDb, error) {
+	storage, err := setupStorage(backend, fileName, backups)
 	if err != nil {
-		return
+		return nil, err
 	}
-	n, err = s.file.Read(b)
-	if err != nil {
-		return
-	}
-
-	s.tournaments = &pb.Tournaments{}
-	if n == 0 {
-		core.InfoLogger.Printf("sprints db %s created or left empty\n", fileName)
-	} else {
-		err = proto.Unmarshal(b, s.tournaments)
-		if err != nil {
-			return
-		}
-		core.InfoLogger.Printf("loaded %d previous tournaments\n", len(s.tournaments.Tournament))
-	}
-	return s, nil
+	return &SprintsDb{Storage: storage}, nil
 }
 
-func (s *SprintsDb) SaveTournament(tournament *pb.Tournament) error {
-	var (
-		i            = s.getTournamentIndex(tournament.Name)
-		err          error
-		b            []byte
-		bytesWritten int
-		// fInfo        os.FileInfo
-	)
-	if i == -1 {
-		s.tournaments.Tournament = append(s.tournaments.Tournament, tournament)
-	} else {
-		s.tournaments.Tournament[i] = tournament
+func setupStorage(backend, fileName string, backups uint) (Storage, error) {
+	switch backend {
+	case ProtoBackend:
+		return setupProtoStorage(fileName, backups)
+	case BoltBackend:
+		return setupBoltStorage(fileName)
+	default:
+		return nil, fmt.Errorf("unknown db backend: %s", backend)
 	}
+}
 
-	// core.DebugLogger.Printf("len: %d i: %d, %v", len(s.tournaments.Tournament), i, s.tournaments.Tournament)
-
-	b, err = proto.Marshal(s.tournaments)
-	if err != nil {
-		return err
-	}
-	err = s.file.Truncate(0)
-	if err != nil {
-		return err
-	}
-	bytesWritten, err = s.file.WriteAt(b, 0)
-	if err != nil {
-		return err
-	}
-	if bytesWritten == 0 {
-		return errors.New("Nothing written to database")
-	}
-	err = s.file.Sync()
+func (s *SprintsDb) GetLastTournament() (*pb.Tournament, error) {
+	tournaments, err := s.ListTournaments()
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	return nil
-}
-
-func (s *SprintsDb) getTournamentIndex(name string) int {
-	for i, tournament := range s.tournaments.Tournament {
-		if tournament.Name == name {
-			return i
+	for i := len(tournaments) - 1; i >= 0; i-- {
+		if !tournaments[i].Archived {
+			return s.GetTournament(tournaments[i].Name)
 		}
 	}
-	return -1
+	return nil, errors.New("no tournaments")
 }
 
-func (s *SprintsDb) GetTournament(name string) (*pb.Tournament, error) {
-	var i = s.getTournamentIndex(name)
-	if i >= 0 {
-		return s.tournaments.Tournament[i], nil
+// lockDb takes the lock which keeps the db to the process holding it until
+// the returned file is closed; the server holds it while running so offline
+// tools writing into the db don't overwrite each other's changes
+func lockDb(fileName string) (*os.File, error) {
+	lock, err := os.OpenFile(fileName+lockSuffix, os.O_CREATE|os.O_RDWR, dbFileMode)
+	if err != nil {
+		return nil, err
 	}
-	return nil, errors.New("not found")
-}
-
-func (s *SprintsDb) GetLastTournament() (*pb.Tournament, error) {
-	var tournamentsLen = len(s.tournaments.Tournament)
-	if tournamentsLen > 0 {
-		return s.tournaments.Tournament[tournamentsLen-1], nil
+	if err = syscall.Flock(int(lock.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		lock.Close()
+		if err == syscall.EWOULDBLOCK {
+			return nil, fmt.Errorf("%s is in use by another process", fileName)
+		}
+		return nil, err
 	}
-	return nil, errors.New("no tournaments")
+	return lock, nil
 }
 
-func (s *SprintsDb) Close() error {
-	return s.file.Close()
+// playerKey identifies player by the name as it's matched with the stored
+// results and registered players
+func playerKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
 }
@@ -1,40 +1,433 @@
 package server
 
 import (
-	"flag"
-	"github.com/golang/mock/gomock"
-	"github.com/kkoralsky/gosprints/core/server/mocks"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
-)
+	"time"
 
-var (
-	testSprints *Sprints
-	mockDevice  *server_mocks.MockInputDevice
-	mockVisMux  *VisMux
+	"github.com/kkoralsky/gosprints/core"
+	pb "github.com/kkoralsky/gosprints/proto"
+	"google.golang.org/grpc"
 )
 
-func TestMain(m *testing.M) {
-	flag.Parse()
+// fakeDevice moves every lane by one unit whenever its distance is read
+type fakeDevice struct {
+	mu    sync.Mutex
+	dists []uint
+}
+
+func (d *fakeDevice) Init(players []string, threshold uint, falseStart uint) error {
+	d.dists = make([]uint, len(players))
+	return nil
+}
+
+func (d *fakeDevice) Start() error { return nil }
+
+func (d *fakeDevice) GetDist(playerID uint) (uint, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if int(playerID) >= len(d.dists) {
+		return 0, errors.New("no such player")
+	}
+	d.dists[playerID]++
+	return d.dists[playerID], nil
+}
+
+func (d *fakeDevice) GetPlayerCount() uint { return uint(len(d.dists)) }
+
+func (d *fakeDevice) Clean() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 
-	// device := NewMockIn
+	for i := range d.dists {
+		d.dists[i] = 0
+	}
+	return nil
+}
+
+func (d *fakeDevice) Check() (int, error) { return -1, nil }
+func (d *fakeDevice) Close() error        { return nil }
+func (d *fakeDevice) Type() string        { return "FAKE" }
+
+// fakeVisual counts what it has been sent instead of drawing it
+type fakeVisual struct {
+	mu       sync.Mutex
+	finished int
+	updates  int
+}
+
+func (v *fakeVisual) NewTournament(context.Context, *pb.Tournament, ...grpc.CallOption) (*pb.Empty, error) {
+	return &pb.Empty{}, nil
+}
 
-	ctrl := gomock.NewController(nil)
-	defer ctrl.Finish()
-	mockDevice = server_mocks.NewMockInputDevice(ctrl)
-	mockVisMux = server_mocks.NewMockVisMuxInterface(ctrl)
+func (v *fakeVisual) NewRace(context.Context, *pb.Race, ...grpc.CallOption) (*pb.Empty, error) {
+	return &pb.Empty{}, nil
+}
 
-	testSprints = SetupSprints(mockDevice, mockVisMux)
+func (v *fakeVisual) StartRace(context.Context, *pb.Starter, ...grpc.CallOption) (*pb.Empty, error) {
+	return &pb.Empty{}, nil
+}
+
+func (v *fakeVisual) AbortRace(context.Context, *pb.AbortMessage, ...grpc.CallOption) (*pb.Empty, error) {
+	return &pb.Empty{}, nil
+}
 
-	m.Run()
+func (v *fakeVisual) UpdateRace(context.Context, ...grpc.CallOption) (pb.Visual_UpdateRaceClient, error) {
+	return &fakeRacer{visual: v}, nil
 }
 
-func Test_doRace(t *testing.T) {
-	testSprints.doRace()
+func (v *fakeVisual) SwapRiders(context.Context, *pb.Swap, ...grpc.CallOption) (*pb.Empty, error) {
+	return &pb.Empty{}, nil
 }
 
-func Test_GetResults(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	stream := server_mocks.NewMockSprints_GetTournamentsServer(ctrl)
-	defer ctrl.Finish()
+func (v *fakeVisual) EliminateRider(context.Context, *pb.Elimination, ...grpc.CallOption) (*pb.Empty, error) {
+	return &pb.Empty{}, nil
+}
+
+func (v *fakeVisual) FinishRace(_ context.Context, results *pb.Results, _ ...grpc.CallOption) (*pb.Empty, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.finished++
+	return &pb.Empty{}, nil
+}
+
+func (v *fakeVisual) ShowResults(context.Context, *pb.Results, ...grpc.CallOption) (*pb.Empty, error) {
+	return &pb.Empty{}, nil
+}
+
+func (v *fakeVisual) ConfigureVis(context.Context, *pb.VisConfiguration, ...grpc.CallOption) (*pb.Empty, error) {
+	return &pb.Empty{}, nil
+}
+
+func (v *fakeVisual) StopVis(context.Context, *pb.Empty, ...grpc.CallOption) (*pb.Empty, error) {
+	return &pb.Empty{}, nil
+}
+
+func (v *fakeVisual) ShowPairingCode(context.Context, *pb.PairingCode, ...grpc.CallOption) (*pb.Empty, error) {
+	return &pb.Empty{}, nil
+}
+
+func (v *fakeVisual) GetConfiguration(context.Context, *pb.Empty, ...grpc.CallOption) (*pb.VisConfiguration, error) {
+	return &pb.VisConfiguration{}, nil
+}
+
+type fakeRacer struct {
+	grpc.ClientStream
+	visual *fakeVisual
+}
+
+func (r *fakeRacer) Send(*pb.Racer) error {
+	r.visual.mu.Lock()
+	defer r.visual.mu.Unlock()
+
+	r.visual.updates++
+	return nil
+}
+
+func (r *fakeRacer) CloseSend() error                 { return nil }
+func (r *fakeRacer) CloseAndRecv() (*pb.Empty, error) { return &pb.Empty{}, nil }
+
+type fakeResultsStream struct {
+	grpc.ServerStream
+	results []*pb.Result
+}
+
+func (s *fakeResultsStream) Send(result *pb.Result) error {
+	s.results = append(s.results, result)
+	return nil
+}
+
+type fakeRecordsStream struct {
+	grpc.ServerStream
+	records []*pb.Record
+}
+
+func (s *fakeRecordsStream) Send(record *pb.Record) error {
+	s.records = append(s.records, record)
+	return nil
+}
+
+func setupTestSprints(t *testing.T) (*Sprints, *fakeVisual, func()) {
+	dir, err := ioutil.TempDir("", "gosprints")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sprintsDb, err := SetupSprintsDb(ProtoBackend, filepath.Join(dir, "sprints.db"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		inputDevice = &fakeDevice{}
+		visual      = &fakeVisual{}
+		visMux      = &VisMux{}
+	)
+	visMux.AddVisual(visual)
+	inputDevice.Init([]string{"0", "1"}, 0, 0)
+	s, err := SetupSprints(inputDevice, visMux, sprintsDb, core.ServerConfig{
+		DistFactor:    125,
+		SplitDistance: 10,
+		TraceInterval: 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.NewTournament(context.Background(), &pb.Tournament{
+		Name:      "concurrent",
+		Mode:      pb.Tournament_DISTANCE,
+		DestValue: 50,
+		Color:     []string{"blue", "red"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, visual, func() {
+		sprintsDb.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func newTestRace(destValue uint32) *pb.Race {
+	return &pb.Race{
+		Players:   []*pb.Player{{Name: "first"}, {Name: "second"}},
+		DestValue: destValue,
+	}
+}
+
+// waitForRace waits until the race goroutine finishes the current race
+func waitForRace(t *testing.T, s *Sprints) {
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); {
+		s.mu.Lock()
+		racing := s.curRace != nil
+		s.mu.Unlock()
+		if !racing {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("race hasn't finished in time")
+}
+
+func TestRace(t *testing.T) {
+	s, visual, cleanup := setupTestSprints(t)
+	defer cleanup()
+
+	if _, err := s.NewRace(context.Background(), newTestRace(50)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.StartRace(context.Background(), &pb.Empty{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.StartRace(context.Background(), &pb.Empty{}); err == nil {
+		t.Error("race shouldn't start twice")
+	}
+	if _, err := s.NewRace(context.Background(), newTestRace(50)); err == nil {
+		t.Error("new race shouldn't replace the running one")
+	}
+	waitForRace(t, s)
+
+	stream := &fakeResultsStream{}
+	if err := s.GetResults(&pb.ResultSpec{Gender: pb.Gender_MALE}, stream); err != nil {
+		t.Fatal(err)
+	}
+	if len(stream.results) != 2 {
+		t.Fatalf("race should have 2 results, got %d", len(stream.results))
+	}
+	for _, result := range stream.results {
+		if result.Place == 0 || result.DeviceType != "FAKE" {
+			t.Errorf("result of %s is incomplete: %v", result.Player.Name, result)
+		}
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	visual.mu.Lock()
+	defer visual.mu.Unlock()
+	if visual.finished != 1 || visual.updates == 0 {
+		t.Errorf("visual should follow the race: %d finished, %d updates", visual.finished, visual.updates)
+	}
+}
+
+func TestAbortRace(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+
+	if _, err := s.AbortRace(context.Background(), &pb.AbortMessage{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.NewRace(context.Background(), newTestRace(1000000)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.StartRace(context.Background(), &pb.Empty{}); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.AbortRace(context.Background(), &pb.AbortMessage{Message: "aborted"})
+		}()
+	}
+	wg.Wait()
+	waitForRace(t, s)
+
+	if _, err := s.AbortRace(context.Background(), &pb.AbortMessage{}); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestConcurrentClients lets several control clients race each other; it's
+// meant to be run with the race detector
+func TestConcurrentClients(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+
+	var wg sync.WaitGroup
+	for client := 0; client < 4; client++ {
+		wg.Add(1)
+		go func(client int) {
+			defer wg.Done()
+			for i := 0; i < 10; i++ {
+				s.NewRace(context.Background(), newTestRace(20))
+				s.StartRace(context.Background(), &pb.Empty{})
+				if client == 0 && i%3 == 0 {
+					s.AbortRace(context.Background(), &pb.AbortMessage{Message: "aborted"})
+				}
+				s.GetResults(&pb.ResultSpec{Gender: pb.Gender_MALE}, &fakeResultsStream{})
+				s.GetRecords(&pb.RecordSpec{Gender: pb.Gender_MALE}, &fakeRecordsStream{})
+				s.GetCurrentTournament(context.Background(), &pb.Empty{})
+				s.GetTournamentNames(context.Background(), &pb.Empty{})
+				time.Sleep(time.Millisecond)
+			}
+		}(client)
+	}
+	wg.Wait()
+	waitForRace(t, s)
+
+	tournament, err := s.GetCurrentTournament(context.Background(), &pb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		ids   = make(map[uint32]bool)
+		races = make(map[uint32]int)
+	)
+	for _, result := range tournament.Result {
+		if ids[result.Id] {
+			t.Errorf("result id %d is not unique", result.Id)
+		}
+		ids[result.Id] = true
+		races[result.RaceId]++
+	}
+	for raceId, count := range races {
+		if count > 2 {
+			t.Errorf("race #%d has %d results of 2 players", raceId, count)
+		}
+	}
+}
+
+func raceResult(raceId uint32, name string, gender pb.Gender, result float32) *pb.Result {
+	return &pb.Result{
+		Player:    &pb.Player{Name: name, Gender: gender},
+		Result:    result,
+		DestValue: 400,
+		RaceId:    raceId,
+	}
+}
+
+func resultNames(results []*pb.Result) (names []string) {
+	for _, result := range results {
+		names = append(names, result.Player.Name)
+	}
+	return
+}
+
+func TestGetResults(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+
+	disqualified := raceResult(2, "disqualified", pb.Gender_MALE, 18000)
+	disqualified.Disqualified = true
+	err := s.sprintsDb.SaveTournament(&pb.Tournament{
+		Name:       "stored",
+		Mode:       pb.Tournament_DISTANCE,
+		DestValue:  400,
+		LastRaceId: 3,
+		Result: []*pb.Result{
+			raceResult(1, "a", pb.Gender_MALE, 20000),
+			raceResult(1, "b", pb.Gender_MALE, 21000),
+			raceResult(2, "c", pb.Gender_MALE, 19000),
+			disqualified,
+			raceResult(2, "d", pb.Gender_FEMALE, 22000),
+			raceResult(3, "e", pb.Gender_FEMALE, 23000),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(tournamentName string) {
+		for _, c := range []struct {
+			spec  pb.ResultSpec
+			names []string
+		}{
+			{pb.ResultSpec{}, []string{"c", "a", "b"}},
+			{pb.ResultSpec{Gender: pb.Gender_FEMALE}, []string{"d", "e"}},
+			{pb.ResultSpec{Last: 1}, nil},
+			{pb.ResultSpec{Last: 2}, []string{"c"}},
+			{pb.ResultSpec{Last: 2, Gender: pb.Gender_FEMALE}, []string{"d", "e"}},
+			{pb.ResultSpec{Last: 10}, []string{"c", "a", "b"}},
+			{pb.ResultSpec{Top: 2}, []string{"c", "a"}},
+			{pb.ResultSpec{Top: 2, Offset: 1}, []string{"a", "b"}},
+			{pb.ResultSpec{Offset: 3}, nil},
+			{pb.ResultSpec{Last: 3, Offset: 1, Top: 1}, []string{"a"}},
+			{pb.ResultSpec{RaceId: 2}, []string{"c", "d"}},
+		} {
+			spec := c.spec
+			spec.TournamentName = tournamentName
+			stream := &fakeResultsStream{}
+			if err := s.GetResults(&spec, stream); err != nil {
+				t.Fatal(err)
+			}
+			if names := resultNames(stream.results); fmt.Sprint(names) != fmt.Sprint(c.names) {
+				t.Errorf("%q %v: results should be %v, got %v", tournamentName, c.spec, c.names, names)
+			}
+		}
+	}
+	check("stored")
+	if _, err = s.LoadTournament(context.Background(), &pb.TournamentSpec{Name: "stored"}); err != nil {
+		t.Fatal(err)
+	}
+	check("")
+
+	if err = s.GetResults(&pb.ResultSpec{TournamentName: "missing"}, &fakeResultsStream{}); err == nil {
+		t.Error("results of missing tournament shouldn't be found")
+	}
+}
 
+func TestLastRaces(t *testing.T) {
+	results := []*pb.Result{
+		raceResult(0, "legacy 1", pb.Gender_MALE, 20000),
+		raceResult(0, "legacy 2", pb.Gender_MALE, 20000),
+		raceResult(1, "a", pb.Gender_MALE, 20000),
+		raceResult(1, "b", pb.Gender_MALE, 20000),
+	}
+	for n, names := range map[uint32]string{
+		0: "[legacy 1 legacy 2 a b]",
+		1: "[a b]",
+		2: "[legacy 2 a b]",
+		5: "[legacy 1 legacy 2 a b]",
+	} {
+		if got := fmt.Sprint(resultNames(lastRaces(results, n))); got != names {
+			t.Errorf("last %d races should be %s, got %s", n, names, got)
+		}
+	}
 }
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/kkoralsky/gosprints/core"
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+func (s *Sprints) RenameTournament(_ context.Context, tournamentRename *pb.TournamentRename) (*pb.Tournament, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tournamentRename.NewName == "" {
+		return nil, errors.New("new tournament name not given")
+	}
+	if err := s.sprintsDb.RenameTournament(tournamentRename.Name, tournamentRename.NewName); err != nil {
+		return nil, fmt.Errorf("tournament %s: %v", tournamentRename.Name, err)
+	}
+	core.InfoLogger.Printf("tournament %s renamed to %s", tournamentRename.Name, tournamentRename.NewName)
+	if s.tournament != nil && s.tournament.Name == tournamentRename.Name {
+		s.tournament.Name = tournamentRename.NewName
+	}
+	s.reloadRecords()
+
+	tournament, err := s.sprintsDb.GetTournament(tournamentRename.NewName)
+	if err != nil {
+		return nil, err
+	}
+	return cloneTournament(tournament), nil
+}
+
+func (s *Sprints) DeleteTournament(_ context.Context, tournamentSpec *pb.TournamentSpec) (*pb.Empty, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tournament != nil && s.tournament.Name == tournamentSpec.Name {
+		return nil, errors.New("cannot delete current tournament; load another one first")
+	}
+	if err := s.sprintsDb.DeleteTournament(tournamentSpec.Name); err != nil {
+		return nil, fmt.Errorf("tournament %s: %v", tournamentSpec.Name, err)
+	}
+	core.InfoLogger.Printf("tournament %s deleted", tournamentSpec.Name)
+	s.reloadRecords()
+
+	return &pb.Empty{}, nil
+}
+
+// ArchiveTournament hides tournament from the tournament names or restores it
+// back; archived tournaments still count into records
+func (s *Sprints) ArchiveTournament(_ context.Context, tournamentSpec *pb.TournamentSpec) (*pb.Tournament, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tournamentSpec.Archived && s.tournament != nil && s.tournament.Name == tournamentSpec.Name {
+		return nil, errors.New("cannot archive current tournament; load another one first")
+	}
+	tournament, err := s.sprintsDb.GetTournament(tournamentSpec.Name)
+	if err != nil {
+		return nil, fmt.Errorf("tournament %s: %v", tournamentSpec.Name, err)
+	}
+	tournament.Archived = tournamentSpec.Archived
+	if err = s.sprintsDb.SaveTournament(tournament); err != nil {
+		return nil, err
+	}
+	return cloneTournament(tournament), nil
+}
+
+// DuplicateTournament creates new tournament with settings and registered
+// players of the given one but without its results
+func (s *Sprints) DuplicateTournament(_ context.Context, tournamentRename *pb.TournamentRename) (*pb.Tournament, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tournamentRename.NewName == "" {
+		return nil, errors.New("new tournament name not given")
+	}
+	tournament, err := s.sprintsDb.GetTournament(tournamentRename.Name)
+	if err != nil {
+		return nil, fmt.Errorf("tournament %s: %v", tournamentRename.Name, err)
+	}
+	if _, err = s.sprintsDb.GetTournament(tournamentRename.NewName); err == nil {
+		return nil, fmt.Errorf("tournament %s already exists", tournamentRename.NewName)
+	}
+	var duplicate = &pb.Tournament{
+		Name:            tournamentRename.NewName,
+		DestValue:       tournament.DestValue,
+		Mode:            tournament.Mode,
+		PlayerCount:     tournament.PlayerCount,
+		Color:           append([]string{}, tournament.Color...),
+		SwapDistance:    append([]uint32{}, tournament.SwapDistance...),
+		StartOffset:     tournament.StartOffset,
+		ResultsInMeters: true,
+	}
+	for _, player := range tournament.Player {
+		duplicate.Player = append(duplicate.Player, proto.Clone(player).(*pb.Player))
+	}
+	if err = s.sprintsDb.SaveTournament(duplicate); err != nil {
+		return nil, err
+	}
+	return cloneTournament(duplicate), nil
+}
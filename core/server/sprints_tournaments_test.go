@@ -0,0 +1,287 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kkoralsky/gosprints/core"
+	pb "github.com/kkoralsky/gosprints/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// restartSprints sets the server up anew on the db file of the given one
+func restartSprints(t *testing.T, s *Sprints) *Sprints {
+	sprintsDb, err := SetupSprintsDb(ProtoBackend, s.sprintsDb.Storage.(*protoStorage).fileName, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restarted, err := SetupSprints(&fakeDevice{}, &VisMux{}, sprintsDb, core.ServerConfig{DistFactor: 125})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return restarted
+}
+
+// newStoredTournament creates tournament with a single result and switches
+// back to the default one
+func newStoredTournament(t *testing.T, s *Sprints, name string) {
+	_, err := s.NewTournament(context.Background(), &pb.Tournament{
+		Name:      name,
+		Mode:      pb.Tournament_DISTANCE,
+		DestValue: 400,
+		Color:     []string{"blue", "red"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.tournament.Result = append(s.tournament.Result, raceResult(1, "a", pb.Gender_MALE, 20000))
+	s.tournament.Player = []*pb.Player{{Name: "a", Category: "junior"}}
+	if err = s.sprintsDb.SaveTournament(s.tournament); err != nil {
+		t.Fatal(err)
+	}
+	s.reloadRecords()
+	if _, err = s.LoadTournament(context.Background(), &pb.TournamentSpec{Name: "concurrent"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// tournamentListed tells whether the tournament is listed and whether it's
+// listed as archived
+func tournamentListed(t *testing.T, s *Sprints, name string) (listed, archived bool) {
+	names, err := s.GetTournamentNames(context.Background(), &pb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range names.Name {
+		listed = listed || n == name
+	}
+	for _, n := range names.Archived {
+		archived = archived || n == name
+	}
+	return listed || archived, archived
+}
+
+func TestNewTournamentOverwrite(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+	newStoredTournament(t, s, "stored")
+
+	if _, err := s.NewTournament(context.Background(), &pb.Tournament{Name: "stored"}); err == nil {
+		t.Error("existing tournament shouldn't be replaced")
+	}
+	if _, err := s.NewTournament(context.Background(), &pb.Tournament{}); err == nil {
+		t.Error("tournament without name shouldn't be created")
+	}
+	tournament, err := s.NewTournament(context.Background(), &pb.Tournament{Name: "stored", Overwrite: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tournament.Overwrite || len(tournament.Result) != 0 {
+		t.Errorf("tournament should be replaced with the empty one: %v", tournament)
+	}
+	if stored, _ := s.sprintsDb.GetTournament("stored"); stored.Overwrite || len(stored.Result) != 0 {
+		t.Errorf("replaced tournament should be stored: %v", stored)
+	}
+}
+
+func TestRenameTournament(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+	newStoredTournament(t, s, "stored")
+
+	for _, rename := range []*pb.TournamentRename{
+		{Name: "stored"},
+		{Name: "stored", NewName: "concurrent"},
+		{Name: "missing", NewName: "renamed"},
+	} {
+		if _, err := s.RenameTournament(context.Background(), rename); err == nil {
+			t.Errorf("%s shouldn't be renamed to %q", rename.Name, rename.NewName)
+		}
+	}
+	renamed, err := s.RenameTournament(context.Background(), &pb.TournamentRename{Name: "stored", NewName: "renamed"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if renamed.Name != "renamed" || len(renamed.Result) != 1 {
+		t.Errorf("tournament should be renamed along with its results: %v", renamed)
+	}
+	if bests := s.records.PersonalBests("a"); len(bests) != 1 || bests[0].TournamentName != "renamed" {
+		t.Errorf("records should refer to the new name, got %v", bests)
+	}
+
+	if _, err = s.RenameTournament(context.Background(), &pb.TournamentRename{Name: "concurrent", NewName: "current"}); err != nil {
+		t.Fatal(err)
+	}
+	if current, _ := s.GetCurrentTournament(context.Background(), &pb.Empty{}); current.Name != "current" {
+		t.Errorf("current tournament should be renamed, got %s", current.Name)
+	}
+}
+
+func TestDeleteTournament(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+	newStoredTournament(t, s, "stored")
+
+	if _, err := s.DeleteTournament(context.Background(), &pb.TournamentSpec{Name: "concurrent"}); err == nil {
+		t.Error("current tournament shouldn't be deleted")
+	}
+	if _, err := s.DeleteTournament(context.Background(), &pb.TournamentSpec{Name: "missing"}); err == nil {
+		t.Error("missing tournament shouldn't be deleted")
+	}
+	if _, err := s.DeleteTournament(context.Background(), &pb.TournamentSpec{Name: "stored"}); err != nil {
+		t.Fatal(err)
+	}
+	if listed, _ := tournamentListed(t, s, "stored"); listed {
+		t.Error("deleted tournament shouldn't be listed")
+	}
+	if bests := s.records.PersonalBests("a"); len(bests) != 0 {
+		t.Errorf("results of deleted tournament shouldn't count, got %v", bests)
+	}
+}
+
+func TestArchiveTournament(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+	newStoredTournament(t, s, "stored")
+
+	if _, err := s.ArchiveTournament(context.Background(), &pb.TournamentSpec{Name: "concurrent", Archived: true}); err == nil {
+		t.Error("current tournament shouldn't be archived")
+	}
+	if _, err := s.ArchiveTournament(context.Background(), &pb.TournamentSpec{Name: "stored", Archived: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, archived := tournamentListed(t, s, "stored"); !archived {
+		t.Error("stored tournament should be listed as archived")
+	}
+	if bests := s.records.PersonalBests("a"); len(bests) != 1 {
+		t.Errorf("archived results should still count, got %v", bests)
+	}
+
+	if _, err := s.ArchiveTournament(context.Background(), &pb.TournamentSpec{Name: "stored"}); err != nil {
+		t.Fatal(err)
+	}
+	if listed, archived := tournamentListed(t, s, "stored"); !listed || archived {
+		t.Error("stored tournament should be restored")
+	}
+}
+
+func TestDuplicateTournament(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+	newStoredTournament(t, s, "stored")
+
+	for _, rename := range []*pb.TournamentRename{
+		{Name: "stored"},
+		{Name: "stored", NewName: "concurrent"},
+		{Name: "missing", NewName: "duplicate"},
+	} {
+		if _, err := s.DuplicateTournament(context.Background(), rename); err == nil {
+			t.Errorf("%s shouldn't be duplicated as %q", rename.Name, rename.NewName)
+		}
+	}
+	duplicate, err := s.DuplicateTournament(context.Background(), &pb.TournamentRename{Name: "stored", NewName: "duplicate"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if duplicate.DestValue != 400 || len(duplicate.Color) != 2 || len(duplicate.Result) != 0 {
+		t.Errorf("settings should be duplicated without results: %v", duplicate)
+	}
+	if len(duplicate.Player) != 1 || duplicate.Player[0].Category != "junior" {
+		t.Errorf("registered players should be duplicated: %v", duplicate.Player)
+	}
+	if stored, _ := s.sprintsDb.GetTournament("stored"); len(stored.Result) != 1 {
+		t.Errorf("original tournament should keep its results: %v", stored)
+	}
+	if current, _ := s.GetCurrentTournament(context.Background(), &pb.Empty{}); current.Name != "concurrent" {
+		t.Errorf("duplicate shouldn't be loaded, got %s", current.Name)
+	}
+}
+
+func TestLegacyResultsTournament(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+
+	err := s.sprintsDb.SaveTournament(&pb.Tournament{
+		Name:      "legacy",
+		Mode:      pb.Tournament_TIME,
+		DestValue: 30,
+		Result:    []*pb.Result{raceResult(1, "a", pb.Gender_MALE, 240)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.reloadRecords()
+
+	if _, err = s.LoadTournament(context.Background(), &pb.TournamentSpec{Name: "legacy"}); err == nil {
+		t.Error("tournament with results in device units shouldn't be loaded")
+	}
+	if _, err = s.ExportResults(context.Background(), &pb.ExportSpec{TournamentName: "legacy"}); err == nil {
+		t.Error("results in device units shouldn't be exported")
+	}
+	if bests := s.records.PersonalBests("a"); len(bests) != 0 {
+		t.Errorf("results in device units shouldn't count into records, got %v", bests)
+	}
+	if tournament, _ := s.sprintsDb.GetTournament("legacy"); tournament.Result[0].Result != 240 {
+		t.Errorf("results shouldn't be converted behind the scenes: %v", tournament.Result[0])
+	}
+}
+
+func TestRestartWithLegacyResultsTournament(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+
+	s.tournament.Archived = true
+	if err := s.sprintsDb.SaveTournament(s.tournament); err != nil {
+		t.Fatal(err)
+	}
+	err := s.sprintsDb.SaveTournament(&pb.Tournament{
+		Name:      "legacy",
+		Mode:      pb.Tournament_TIME,
+		DestValue: 30,
+		Result:    []*pb.Result{raceResult(1, "a", pb.Gender_MALE, 240)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restarted := restartSprints(t, s)
+	if restarted.tournament == nil || restarted.tournament.Name != core.DefultTournament.Name {
+		t.Fatalf("existing default tournament should be loaded, got %v", restarted.tournament)
+	}
+	if _, err = restarted.NewRace(context.Background(), newTestRace(50)); err != nil {
+		t.Error(err)
+	}
+
+	var empty = &Sprints{}
+	if _, err = empty.NewRace(context.Background(), newTestRace(50)); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("race shouldn't be set up without tournament, got %v", err)
+	}
+	if _, err = empty.StartRace(context.Background(), &pb.Empty{}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("race shouldn't start without tournament, got %v", err)
+	}
+}
+
+func TestRestartWithDefaultTournament(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+
+	// neither of the tournaments can be loaded on its own
+	for _, name := range []string{"concurrent", core.DefultTournament.Name} {
+		tournament, err := s.sprintsDb.GetTournament(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tournament.Archived = true
+		if err = s.sprintsDb.SaveTournament(tournament); err != nil {
+			t.Fatal(err)
+		}
+	}
+	restarted := restartSprints(t, s)
+	if restarted.tournament == nil || restarted.tournament.Name != core.DefultTournament.Name {
+		t.Fatalf("existing default tournament should be loaded, got %v", restarted.tournament)
+	}
+	if tournaments, _ := restarted.sprintsDb.ListTournaments(); len(tournaments) != 2 {
+		t.Errorf("default tournament shouldn't be created again, got %d tournaments", len(tournaments))
+	}
+}
@@ -3,137 +3,449 @@ package server
 import (
 	"context"
 	"fmt"
+	"github.com/golang/protobuf/proto"
 	"github.com/kkoralsky/gosprints/core"
 	pb "github.com/kkoralsky/gosprints/proto"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
 	"strings"
+	"sync"
 	"time"
 )
 
-type VisMuxInterface interface {
-	NewTournament(*pb.Tournament)
-	NewRace(*pb.Race)
-	StartRace(*pb.Starter)
-	AbortRace(*pb.AbortMessage)
-	ConfigureVis(*pb.VisConfiguration)
-	connectionStateUpdater()
-	SetupRacers()
-	SendRaceUpdate(uint32, uint32)
-	CloseRacers()
-	FinishRace(*pb.Results)
-	ShowResults(*pb.Results)
+// visCallTimeout limits calls to the single visual the caller waits for
+const visCallTimeout = 5 * time.Second
+
+// racerBuffer is how many race updates wait for the visual before the oldest
+// ones are dropped
+const racerBuffer = 64
+
+// racerCloseTimeout limits waiting for the visuals to receive the updates
+// left when the race is over
+const racerCloseTimeout = time.Second
+
+// visRacer streams the race updates to a single visual on its own so that
+// the streams don't have to be sent to concurrently
+type visRacer struct {
+	stream  pb.Visual_UpdateRaceClient
+	cancel  context.CancelFunc
+	updates chan *pb.Racer
+	done    chan error
+}
+
+func newVisRacer(client pb.VisualClient) (*visRacer, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.UpdateRace(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	r := &visRacer{
+		stream:  stream,
+		cancel:  cancel,
+		updates: make(chan *pb.Racer, racerBuffer),
+		done:    make(chan error, 1),
+	}
+	go r.run()
+	return r, nil
 }
 
+// send drops the oldest update rather than waiting for the visual
+func (r *visRacer) send(update *pb.Racer) {
+	for {
+		select {
+		case r.updates <- update:
+			return
+		default:
+		}
+		select {
+		case <-r.updates:
+		default:
+		}
+	}
+}
+
+// run sends the updates until they're closed; the ones after the failed send
+// are discarded
+func (r *visRacer) run() {
+	var err error
+	for update := range r.updates {
+		if err == nil {
+			err = r.stream.Send(update)
+		}
+	}
+	if err == nil {
+		_, err = r.stream.CloseAndRecv()
+	}
+	r.done <- err
+}
+
+// muxVisual is the visual receiving the races; in-process ones have neither
+// address nor connection
+type muxVisual struct {
+	address string
+	conn    *grpc.ClientConn
+	client  pb.VisualClient
+	config  *pb.VisConfiguration
+}
+
+// VisMux sends everything to all the visuals; they can be added and removed
+// any time
 type VisMux struct {
-	addresses     []string
-	connections   []*grpc.ClientConn
-	clients       []pb.VisualClient
-	racers        []pb.Visual_UpdateRaceClient
+	transport     grpc.DialOption
+	visuals       []*muxVisual
+	racers        []*visRacer
 	curTournament *pb.Tournament
+	mu            sync.Mutex
 }
 
-func SetupVisMux(outputs string) (*VisMux, error) {
-	var v = VisMux{
-		addresses: strings.Split(outputs, ","),
-	}
-	for i, addr := range v.addresses {
-		conn, err := grpc.Dial(addr, grpc.WithInsecure(), grpc.WithTimeout(10*time.Second))
-		if err != nil {
+// SetupVisMux dials the visuals with the transport option being either
+// insecure or TLS one; further visuals can register themselves later
+func SetupVisMux(outputs string, transport grpc.DialOption) *VisMux {
+	var v = VisMux{transport: transport}
+
+	for _, addr := range strings.Split(outputs, ",") {
+		if addr == "" {
+			continue
+		}
+		if err := v.DialVisual(addr, nil); err != nil {
 			core.ErrorLogger.Printf("error while dialing to %s: %s\n", addr, err.Error())
-		} else {
-			v.connections = append(v.connections, conn)
-			v.clients = append(v.clients, pb.NewVisualClient(conn))
-			core.InfoLogger.Printf("dialed to vis: %s with state: %s\n", addr, conn.GetState().String())
-			go v.connectionStateUpdater(i, conn)
 		}
 	}
-	if len(v.connections) > 0 {
-		return &v, nil
+	if len(v.visuals) == 0 {
+		core.InfoLogger.Println("no visuals connected; waiting for them to register")
+	}
+	return &v
+}
+
+// DialVisual adds the visual at the address unless it's there already in
+// which case its configuration is updated only
+func (v *VisMux) DialVisual(address string, config *pb.VisConfiguration) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if visual := v.find(address); visual != nil {
+		if config != nil {
+			visual.config = config
+		}
+		return nil
+	}
+	conn, err := grpc.Dial(address, v.transport, grpc.WithTimeout(10*time.Second))
+	if err != nil {
+		return err
+	}
+	visual := &muxVisual{
+		address: address,
+		conn:    conn,
+		client:  pb.NewVisualClient(conn),
+		config:  config,
+	}
+	v.visuals = append(v.visuals, visual)
+	core.InfoLogger.Printf("dialed to vis: %s with state: %s\n", address, conn.GetState().String())
+	go v.connectionStateUpdater(visual)
+	return nil
+}
+
+// RemoveVisual stops sending to the visual at the address and disconnects it
+func (v *VisMux) RemoveVisual(address string) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for i, visual := range v.visuals {
+		if visual.address == address && visual.conn != nil {
+			v.visuals = append(v.visuals[:i], v.visuals[i+1:]...)
+			return visual.conn.Close()
+		}
+	}
+	return fmt.Errorf("no visual at %s", address)
+}
+
+// AddVisual makes the in-process client receive everything sent to the
+// visuals starting with the current tournament
+func (v *VisMux) AddVisual(client pb.VisualClient) {
+	v.mu.Lock()
+	v.visuals = append(v.visuals, &muxVisual{client: client})
+	tournament := v.curTournament
+	v.mu.Unlock()
+
+	if tournament != nil {
+		client.NewTournament(context.Background(), tournament)
 	}
-	return &v, fmt.Errorf("couldnt connect to none of the outputs: %s", outputs)
+}
+
+// find assumes the lock is held
+func (v *VisMux) find(address string) *muxVisual {
+	for _, visual := range v.visuals {
+		if visual.address == address && visual.conn != nil {
+			return visual
+		}
+	}
+	return nil
+}
+
+// clients are the visuals at the moment of the call
+func (v *VisMux) clients() []pb.VisualClient {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	var clients = make([]pb.VisualClient, len(v.visuals))
+	for i, visual := range v.visuals {
+		clients[i] = visual.client
+	}
+	return clients
 }
 
 func (v *VisMux) NewTournament(tournament *pb.Tournament) error {
+	v.mu.Lock()
 	v.curTournament = tournament
+	v.mu.Unlock()
 
-	for _, cl := range v.clients {
+	for _, cl := range v.clients() {
 		go cl.NewTournament(context.Background(), tournament)
 	}
 	return nil
 }
 
 func (v *VisMux) NewRace(race *pb.Race) error {
-	for _, cl := range v.clients {
+	for _, cl := range v.clients() {
 		go cl.NewRace(context.Background(), race)
 	}
 	return nil
 }
 
 func (v *VisMux) StartRace(starter *pb.Starter) error {
-	for _, cl := range v.clients {
+	for _, cl := range v.clients() {
 		go cl.StartRace(context.Background(), starter)
 	}
 	return nil
 }
 
 func (v *VisMux) AbortRace(abortMessage *pb.AbortMessage) error {
-	for _, cl := range v.clients {
+	for _, cl := range v.clients() {
 		go cl.AbortRace(context.Background(), abortMessage)
 	}
 	return nil
 }
 
+// ConfigureVis sends the configuration to the visual of its host name or to
+// all of them if the name is empty
 func (v *VisMux) ConfigureVis(visCfg *pb.VisConfiguration) error {
-	for _, cl := range v.clients {
-		go cl.ConfigureVis(context.Background(), visCfg)
+	if visCfg.HostName == "" {
+		v.mu.Lock()
+		defer v.mu.Unlock()
+
+		// every visual keeps its own name so that it can still be told apart
+		for _, visual := range v.visuals {
+			config := proto.Clone(visCfg).(*pb.VisConfiguration)
+			if visual.config != nil {
+				config.HostName = visual.config.HostName
+			}
+			visual.config = config
+			go visual.client.ConfigureVis(context.Background(), config)
+		}
+		return nil
+	}
+	visuals, err := v.named(visCfg.HostName)
+	if err != nil {
+		return err
+	}
+	for _, visual := range visuals {
+		ctx, cancel := context.WithTimeout(context.Background(), visCallTimeout)
+		_, err = visual.client.ConfigureVis(ctx, visCfg)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("couldnt configure %s: %v", visual.address, err)
+		}
+		v.mu.Lock()
+		visual.config = visCfg
+		v.mu.Unlock()
 	}
 	return nil
 }
 
-func (v *VisMux) connectionStateUpdater(i int, conn *grpc.ClientConn) {
-	for conn != nil {
-		conn.WaitForStateChange(context.Background(), conn.GetState())
-		if conn != nil {
-			core.InfoLogger.Printf("vis connection: %s state: %s",
-				v.addresses[i], conn.GetState().String())
-			if conn.GetState() == connectivity.Ready && v.curTournament != nil {
-				v.clients[i].NewTournament(context.Background(), v.curTournament)
-			}
+// RestartVis applies the last configuration of the named visual again which
+// sets it up anew
+func (v *VisMux) RestartVis(hostName string) error {
+	visuals, err := v.named(hostName)
+	if err != nil {
+		return err
+	}
+	for _, visual := range visuals {
+		v.mu.Lock()
+		visCfg := visual.config
+		v.mu.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), visCallTimeout)
+		_, err = visual.client.ConfigureVis(ctx, visCfg)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("couldnt restart %s: %v", visual.address, err)
 		}
 	}
+	return nil
+}
 
-	core.InfoLogger.Printf("vis connection: %s closed", v.addresses[i])
+// StopVis closes the named visual; it stays listed until it unregisters
+func (v *VisMux) StopVis(hostName string) error {
+	visuals, err := v.named(hostName)
+	if err != nil {
+		return err
+	}
+	for _, visual := range visuals {
+		ctx, cancel := context.WithTimeout(context.Background(), visCallTimeout)
+		_, err = visual.client.StopVis(ctx, &pb.Empty{})
+		cancel()
+		if err != nil {
+			return fmt.Errorf("couldnt stop %s: %v", visual.address, err)
+		}
+	}
+	return nil
+}
+
+// named are the visuals connected over the network known by the host name
+func (v *VisMux) named(hostName string) (visuals []*muxVisual, err error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for _, visual := range v.visuals {
+		if visual.conn != nil && visual.config != nil && visual.config.HostName == hostName {
+			visuals = append(visuals, visual)
+		}
+	}
+	if len(visuals) == 0 {
+		err = fmt.Errorf("no visual named %s", hostName)
+	}
+	return
+}
+
+// Visuals describes the visuals connected over the network
+func (v *VisMux) Visuals() *pb.Visuals {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	var visuals = &pb.Visuals{}
+	for _, visual := range v.visuals {
+		if visual.conn == nil {
+			continue
+		}
+		visuals.Visual = append(visuals.Visual, &pb.VisualInfo{
+			Address:       visual.address,
+			State:         visual.conn.GetState().String(),
+			Configuration: visual.config,
+		})
+	}
+	return visuals
+}
+
+// fetchConfig asks the visual dialed from the command line how it's
+// configured as it has never registered
+func (v *VisMux) fetchConfig(visual *muxVisual) {
+	v.mu.Lock()
+	known := visual.config != nil
+	v.mu.Unlock()
+	if known {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), visCallTimeout)
+	defer cancel()
+	config, err := visual.client.GetConfiguration(ctx, &pb.Empty{})
+	if err != nil {
+		core.ErrorLogger.Printf("couldnt get configuration of %s: %v", visual.address, err)
+		return
+	}
+	v.mu.Lock()
+	if visual.config == nil {
+		visual.config = config
+	}
+	v.mu.Unlock()
+}
+
+func (v *VisMux) connectionStateUpdater(visual *muxVisual) {
+	for state := visual.conn.GetState(); state != connectivity.Shutdown; state = visual.conn.GetState() {
+		visual.conn.WaitForStateChange(context.Background(), state)
+		core.InfoLogger.Printf("vis connection: %s state: %s",
+			visual.address, visual.conn.GetState().String())
+		v.mu.Lock()
+		tournament := v.curTournament
+		v.mu.Unlock()
+		if visual.conn.GetState() != connectivity.Ready {
+			continue
+		}
+		v.fetchConfig(visual)
+		if tournament != nil {
+			visual.client.NewTournament(context.Background(), tournament)
+		}
+	}
+
+	core.InfoLogger.Printf("vis connection: %s closed", visual.address)
 }
 
 func (v *VisMux) SetupRacers() {
-	for i, cl := range v.clients {
-		if racer, err := cl.UpdateRace(context.Background()); err != nil {
-			core.ErrorLogger.Printf("couldnt setup racer for: %s: %v", v.addresses[i], err)
+	v.mu.Lock()
+	visuals := append([]*muxVisual(nil), v.visuals...)
+	v.mu.Unlock()
+
+	for _, visual := range visuals {
+		if racer, err := newVisRacer(visual.client); err != nil {
+			core.ErrorLogger.Printf("couldnt setup racer for: %s: %v", visual.address, err)
 		} else {
 			v.racers = append(v.racers, racer)
 		}
 	}
 }
 
+// SendRaceUpdate never waits for the visuals so that the slow one doesn't
+// hold the race back
 func (v *VisMux) SendRaceUpdate(playerNum uint32, distance uint32) {
 	for _, racer := range v.racers {
-		go racer.Send(&pb.Racer{PlayerNum: playerNum, Distance: distance})
+		racer.send(&pb.Racer{PlayerNum: playerNum, Distance: distance})
+	}
+}
+
+func (v *VisMux) SwapRiders(swap *pb.Swap) {
+	for _, cl := range v.clients() {
+		go cl.SwapRiders(context.Background(), swap)
+	}
+}
+
+func (v *VisMux) EliminateRider(elimination *pb.Elimination) {
+	for _, cl := range v.clients() {
+		go cl.EliminateRider(context.Background(), elimination)
+	}
+}
+
+func (v *VisMux) ShowPairingCode(code *pb.PairingCode) {
+	for _, cl := range v.clients() {
+		go cl.ShowPairingCode(context.Background(), code)
 	}
 }
 
+// CloseRacers lets the visuals receive the updates left for racerCloseTimeout
+// at most; the ones not done by then are cut off
 func (v *VisMux) CloseRacers() error {
 	var (
-		len_before = len(v.racers)
-		closed     = 0
+		len_before  = len(v.racers)
+		closed      = 0
+		ctx, cancel = context.WithTimeout(context.Background(), racerCloseTimeout)
 	)
+	defer cancel()
+
+	for _, racer := range v.racers {
+		close(racer.updates)
+	}
 	for i, racer := range v.racers {
-		err := racer.CloseSend()
-		if err != nil {
-			core.ErrorLogger.Printf("error while closing racer %d: %v", i, err)
-		} else {
-			closed++
+		select {
+		case err := <-racer.done:
+			if err != nil {
+				core.ErrorLogger.Printf("error while closing racer %d: %v", i, err)
+			} else {
+				closed++
+			}
+		case <-ctx.Done():
+			core.ErrorLogger.Printf("racer %d not closed in time", i)
 		}
+		racer.cancel()
 	}
 	v.racers = nil
 	if closed < len_before {
@@ -143,13 +455,13 @@ func (v *VisMux) CloseRacers() error {
 }
 
 func (v *VisMux) ShowResults(results *pb.Results) {
-	for _, cl := range v.clients {
+	for _, cl := range v.clients() {
 		go cl.ShowResults(context.Background(), results)
 	}
 }
 
 func (v *VisMux) FinishRace(results *pb.Results) error {
-	for _, cl := range v.clients {
+	for _, cl := range v.clients() {
 		go cl.FinishRace(context.Background(), results)
 	}
 	return nil
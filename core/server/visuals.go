@@ -0,0 +1,69 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+
+	"github.com/kkoralsky/gosprints/core"
+	pb "github.com/kkoralsky/gosprints/proto"
+	"google.golang.org/grpc/peer"
+)
+
+// visualAddress is the address given in the registration or the sender's
+// host with the registered port
+func visualAddress(host string, registration *pb.VisualRegistration) (string, error) {
+	if registration.Address != "" {
+		return registration.Address, nil
+	}
+	if registration.Port == 0 {
+		return "", errors.New("neither address nor port of the visual given")
+	}
+	return net.JoinHostPort(host, strconv.Itoa(int(registration.Port))), nil
+}
+
+// peerHost is the host the call came from; empty if unknown
+func peerHost(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return ""
+	}
+	return host
+}
+
+// RegisterVisual adds the visual to the ones receiving the races; registering
+// again only updates its configuration
+func (s *Sprints) RegisterVisual(ctx context.Context, registration *pb.VisualRegistration) (*pb.Empty, error) {
+	address, err := visualAddress(peerHost(ctx), registration)
+	if err != nil {
+		return &pb.Empty{}, err
+	}
+	core.DebugLogger.Printf("visual %s registered at %s", registration.Configuration.GetHostName(), address)
+	return &pb.Empty{}, s.visMux.DialVisual(address, registration.Configuration)
+}
+
+func (s *Sprints) UnregisterVisual(ctx context.Context, registration *pb.VisualRegistration) (*pb.Empty, error) {
+	address, err := visualAddress(peerHost(ctx), registration)
+	if err != nil {
+		return &pb.Empty{}, err
+	}
+	core.InfoLogger.Printf("visual %s at %s unregistered", registration.Configuration.GetHostName(), address)
+	return &pb.Empty{}, s.visMux.RemoveVisual(address)
+}
+
+func (s *Sprints) GetVisuals(context.Context, *pb.Empty) (*pb.Visuals, error) {
+	return s.visMux.Visuals(), nil
+}
+
+func (s *Sprints) RestartVis(_ context.Context, spec *pb.VisualSpec) (*pb.Empty, error) {
+	return &pb.Empty{}, s.visMux.RestartVis(spec.HostName)
+}
+
+func (s *Sprints) StopVis(_ context.Context, spec *pb.VisualSpec) (*pb.Empty, error) {
+	return &pb.Empty{}, s.visMux.StopVis(spec.HostName)
+}
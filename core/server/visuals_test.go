@@ -0,0 +1,412 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/kkoralsky/gosprints/core"
+	"github.com/kkoralsky/gosprints/core/certs"
+	pb "github.com/kkoralsky/gosprints/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+)
+
+// dialedVisuals are addresses of the visuals connected over the network
+func dialedVisuals(v *VisMux) (addresses []string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for _, visual := range v.visuals {
+		if visual.conn != nil {
+			addresses = append(addresses, visual.address)
+		}
+	}
+	return
+}
+
+func TestRegisterVisual(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+	s.visMux.transport = grpc.WithInsecure()
+
+	var (
+		ctx = peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 45678},
+		})
+		registration = &pb.VisualRegistration{
+			Port:          9998,
+			Configuration: &pb.VisConfiguration{HostName: "projector"},
+		}
+	)
+	for i := 0; i < 2; i++ {
+		if _, err := s.RegisterVisual(ctx, registration); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if addresses := dialedVisuals(s.visMux); len(addresses) != 1 || addresses[0] != "127.0.0.1:9998" {
+		t.Errorf("visual should be added once at the caller's host, got %v", addresses)
+	}
+	if _, err := s.RegisterVisual(ctx, &pb.VisualRegistration{}); err == nil {
+		t.Error("visual without port shouldn't be registered")
+	}
+
+	if _, err := s.UnregisterVisual(ctx, registration); err != nil {
+		t.Fatal(err)
+	}
+	if addresses := dialedVisuals(s.visMux); len(addresses) != 0 {
+		t.Errorf("visual should be removed, got %v", addresses)
+	}
+	if _, err := s.UnregisterVisual(ctx, registration); err == nil {
+		t.Error("unknown visual shouldn't be unregistered")
+	}
+	// in-process visual is still there
+	if len(s.visMux.clients()) != 1 {
+		t.Error("only registered visual should be removed")
+	}
+}
+
+func TestRegisterOverMutualTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gosprints-certs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	err = certs.Generate(core.CertsConfig{Dir: dir, Hosts: "127.0.0.1", VisualHosts: "127.0.0.1", ValidDays: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		ca         = filepath.Join(dir, certs.CAFile)
+		serverCert = filepath.Join(dir, certs.ServerFile)
+		serverKey  = filepath.Join(dir, certs.ServerKeyFile)
+	)
+
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+	if s.visMux.transport, err = certs.DialOption(ca, serverCert, serverKey); err != nil {
+		t.Fatal(err)
+	}
+	tlsConfig, err := certs.ServerConfig(serverCert, serverKey, ca)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmdServer, err := SetupCmdServer(0, false, s, certs.ServerOptions(tlsConfig)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go cmdServer.Run()
+	defer cmdServer.Stop()
+
+	// visual registers presenting its certificate
+	transport, err := certs.DialOption(ca, filepath.Join(dir, certs.VisualFile), filepath.Join(dir, certs.VisualKeyFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := fmt.Sprintf("127.0.0.1:%d", cmdServer.tcpListener.Addr().(*net.TCPAddr).Port)
+	conn, err := grpc.Dial(address, transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err = pb.NewSprintsClient(conn).RegisterVisual(ctx, &pb.VisualRegistration{Port: 9998}); err != nil {
+		t.Fatal(err)
+	}
+	if addresses := dialedVisuals(s.visMux); len(addresses) != 1 || addresses[0] != "127.0.0.1:9998" {
+		t.Errorf("visual should be registered, got %v", addresses)
+	}
+}
+
+// announce broadcasts the registration to the discovery as the visual does
+func announce(t *testing.T, discovery *Discovery, registration *pb.VisualRegistration, token string, sentAt time.Time) {
+	conn, err := net.DialUDP("udp4", nil, &net.UDPAddr{
+		IP:   net.IPv4(127, 0, 0, 1),
+		Port: discovery.conn.LocalAddr().(*net.UDPAddr).Port,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	announcement := &pb.Announcement{SentAt: sentAt.Unix()}
+	announcement.Registration, _ = proto.Marshal(registration)
+	if token != "" {
+		announcement.Signature = core.SignAnnouncement(token, announcement)
+	}
+	datagram, _ := proto.Marshal(announcement)
+	conn.Write(datagram)
+}
+
+// waitForVisuals waits for the visuals at the addresses to be dialed
+func waitForVisuals(t *testing.T, visMux *VisMux, addresses ...string) {
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); {
+		if dialed := dialedVisuals(visMux); len(dialed) >= len(addresses) {
+			if !reflect.DeepEqual(dialed, addresses) {
+				t.Errorf("announced visuals should be added, got %v", dialed)
+			}
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("announced visuals %v haven't been added", addresses)
+}
+
+func TestDiscovery(t *testing.T) {
+	var visMux = SetupVisMux("", grpc.WithInsecure())
+
+	discovery, err := SetupDiscovery(0, visMux, testAuthenticator(t, core.ServerConfig{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	go discovery.Run()
+	defer discovery.Stop()
+
+	conn, err := net.DialUDP("udp4", nil, discovery.conn.LocalAddr().(*net.UDPAddr))
+	if err == nil {
+		conn.Write([]byte("garbage"))
+		conn.Close()
+	}
+	announce(t, discovery, &pb.VisualRegistration{Address: "projector:9998"}, "", time.Now())
+	waitForVisuals(t, visMux, "projector:9998")
+}
+
+func TestSignedDiscovery(t *testing.T) {
+	var visMux = SetupVisMux("", grpc.WithInsecure())
+
+	discovery, err := SetupDiscovery(0, visMux, testAuthenticator(t, core.ServerConfig{
+		AdminToken:   "secret",
+		StarterToken: "starter",
+		VisualToken:  "display",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	go discovery.Run()
+	defer discovery.Stop()
+
+	announce(t, discovery, &pb.VisualRegistration{Address: "unsigned:9998"}, "", time.Now())
+	announce(t, discovery, &pb.VisualRegistration{Address: "starter:9998"}, "starter", time.Now())
+	announce(t, discovery, &pb.VisualRegistration{Address: "wrong:9998"}, "wrong", time.Now())
+	announce(t, discovery, &pb.VisualRegistration{Address: "stale:9998"}, "secret", time.Now().Add(-time.Hour))
+	announce(t, discovery, &pb.VisualRegistration{Address: "projector:9998"}, "secret", time.Now())
+	announce(t, discovery, &pb.VisualRegistration{Address: "tv:9998"}, "display", time.Now())
+	waitForVisuals(t, visMux, "projector:9998", "tv:9998")
+}
+
+// fakeVisualServer records the configurations it's been sent; calls not
+// overridden aren't expected
+type fakeVisualServer struct {
+	pb.VisualServer
+	mu         sync.Mutex
+	config     *pb.VisConfiguration
+	configured []*pb.VisConfiguration
+	stopped    int
+}
+
+// serveFakeVisual starts the visual configured with config on a random port
+func serveFakeVisual(t *testing.T, config *pb.VisConfiguration) (*fakeVisualServer, string, func()) {
+	var (
+		visual = &fakeVisualServer{config: config}
+		server = grpc.NewServer()
+	)
+	pb.RegisterVisualServer(server, visual)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(listener)
+	return visual, listener.Addr().String(), server.Stop
+}
+
+func (v *fakeVisualServer) GetConfiguration(context.Context, *pb.Empty) (*pb.VisConfiguration, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return v.config, nil
+}
+
+func (v *fakeVisualServer) NewTournament(context.Context, *pb.Tournament) (*pb.Empty, error) {
+	return &pb.Empty{}, nil
+}
+
+func (v *fakeVisualServer) ConfigureVis(_ context.Context, visCfg *pb.VisConfiguration) (*pb.Empty, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.configured = append(v.configured, visCfg)
+	return &pb.Empty{}, nil
+}
+
+func (v *fakeVisualServer) StopVis(context.Context, *pb.Empty) (*pb.Empty, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.stopped++
+	return &pb.Empty{}, nil
+}
+
+func TestTargetedVisuals(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+	s.visMux.transport = grpc.WithInsecure()
+
+	var (
+		ctx       = context.Background()
+		projector = &pb.VisConfiguration{HostName: "projector", VisName: "bar", ResolutionWidth: 1024}
+	)
+	visual, address, stop := serveFakeVisual(t, projector)
+	defer stop()
+
+	_, err := s.RegisterVisual(ctx, &pb.VisualRegistration{
+		Address:       address,
+		Configuration: projector,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	visuals, _ := s.GetVisuals(ctx, &pb.Empty{})
+	if len(visuals.Visual) != 1 || visuals.Visual[0].Address != address ||
+		visuals.Visual[0].Configuration.ResolutionWidth != 1024 || visuals.Visual[0].State == "" {
+		t.Fatalf("registered visual should be listed, got %v", visuals.Visual)
+	}
+
+	if _, err = s.ConfigureVis(ctx, &pb.VisConfiguration{HostName: "nobody"}); err == nil {
+		t.Error("unknown visual shouldn't be configured")
+	}
+	if _, err = s.ConfigureVis(ctx, &pb.VisConfiguration{HostName: "projector", VisName: "clock"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.RestartVis(ctx, &pb.VisualSpec{HostName: "projector"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.StopVis(ctx, &pb.VisualSpec{HostName: "projector"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.StopVis(ctx, &pb.VisualSpec{HostName: "nobody"}); err == nil {
+		t.Error("unknown visual shouldn't be stopped")
+	}
+
+	visual.mu.Lock()
+	defer visual.mu.Unlock()
+	if len(visual.configured) != 2 || visual.configured[1].VisName != "clock" {
+		t.Errorf("visual should be configured and restarted with the new configuration, got %v", visual.configured)
+	}
+	if visual.stopped != 1 {
+		t.Errorf("visual should be stopped once, got %d", visual.stopped)
+	}
+	visuals, _ = s.GetVisuals(ctx, &pb.Empty{})
+	if visuals.Visual[0].Configuration.VisName != "clock" {
+		t.Error("configuration sent should be listed")
+	}
+}
+
+func TestBroadcastKeepsHostNames(t *testing.T) {
+	s, _, cleanup := setupTestSprints(t)
+	defer cleanup()
+	s.visMux.transport = grpc.WithInsecure()
+
+	var ctx = context.Background()
+	for _, hostName := range []string{"projector", "tv"} {
+		config := &pb.VisConfiguration{HostName: hostName, VisName: "bar"}
+		_, address, stop := serveFakeVisual(t, config)
+		defer stop()
+		if _, err := s.RegisterVisual(ctx, &pb.VisualRegistration{Address: address, Configuration: config}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := s.ConfigureVis(ctx, &pb.VisConfiguration{VisName: "clock"}); err != nil {
+		t.Fatal(err)
+	}
+	visuals, _ := s.GetVisuals(ctx, &pb.Empty{})
+	for i, hostName := range []string{"projector", "tv"} {
+		if config := visuals.Visual[i].Configuration; config.HostName != hostName || config.VisName != "clock" {
+			t.Errorf("visual %s should be configured keeping its name, got %v", hostName, config)
+		}
+	}
+	if _, err := s.RestartVis(ctx, &pb.VisualSpec{HostName: "tv"}); err != nil {
+		t.Errorf("visual should still be known by its name: %v", err)
+	}
+}
+
+func TestDialedVisualConfiguration(t *testing.T) {
+	var config = &pb.VisConfiguration{HostName: "tv", VisName: "clock", ResolutionWidth: 800}
+	_, address, stop := serveFakeVisual(t, config)
+	defer stop()
+
+	visMux := SetupVisMux(address, grpc.WithInsecure())
+	defer visMux.RemoveVisual(address)
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if visuals := visMux.Visuals(); visuals.Visual[0].Configuration != nil {
+			if visuals.Visual[0].Configuration.ResolutionWidth != 800 {
+				t.Errorf("configuration of the visual should be listed, got %v", visuals.Visual[0].Configuration)
+			}
+			if err := visMux.StopVis("tv"); err != nil {
+				t.Error(err)
+			}
+			return
+		}
+	}
+	t.Fatal("configuration of the dialed visual hasn't been fetched")
+}
+
+// stuckVisual never takes the race updates until its stream is cancelled
+type stuckVisual struct {
+	fakeVisual
+}
+
+func (v *stuckVisual) UpdateRace(ctx context.Context, _ ...grpc.CallOption) (pb.Visual_UpdateRaceClient, error) {
+	return &stuckRacer{ctx: ctx}, nil
+}
+
+type stuckRacer struct {
+	fakeRacer
+	ctx context.Context
+}
+
+func (r *stuckRacer) Send(*pb.Racer) error {
+	<-r.ctx.Done()
+	return r.ctx.Err()
+}
+
+func TestStuckVisualDoesntHoldRace(t *testing.T) {
+	var (
+		visMux = &VisMux{}
+		visual = &fakeVisual{}
+		done   = make(chan struct{})
+	)
+	visMux.AddVisual(&stuckVisual{})
+	visMux.AddVisual(visual)
+	visMux.SetupRacers()
+
+	go func() {
+		for i := 0; i < 10*racerBuffer; i++ {
+			visMux.SendRaceUpdate(0, uint32(i))
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("updates should never wait for the visual")
+	}
+
+	if err := visMux.CloseRacers(); err == nil {
+		t.Error("stuck racer should be reported as not closed")
+	}
+	visual.mu.Lock()
+	defer visual.mu.Unlock()
+	if visual.updates == 0 {
+		t.Error("other visual should get the updates")
+	}
+}
@@ -0,0 +1,296 @@
+// control panel talking to the JSON gateway of the server it's served from;
+// mirrors workflows of the mobile app
+
+var COLORS = ["blue", "red", "green", "yellow", "white", "rose", "brown",
+              "orange", "gray"];
+
+var state = {
+    token: localStorage.getItem("token") || "",
+    tournament: null,
+    racing: false
+};
+
+function $(id) {
+    return document.getElementById(id);
+}
+
+function showError(message) {
+    var error = $("error");
+    error.textContent = message;
+    error.hidden = !message;
+}
+
+function request(method, body) {
+    var headers = {"Content-Type": "application/json"};
+    if (state.token) {
+        headers["Authorization"] = "Bearer " + state.token;
+    }
+    return fetch("/v1/" + method, {
+        method: "POST",
+        headers: headers,
+        body: JSON.stringify(body || {})
+    }).then(function(response) {
+        if (!response.ok) {
+            return response.json().then(function(error) {
+                throw new Error(method + ": " + error.error);
+            });
+        }
+        return response.text();
+    });
+}
+
+// call invokes unary method resolving with the response message
+function call(method, body) {
+    return request(method, body).then(JSON.parse);
+}
+
+// stream invokes server streaming method resolving with the list of messages
+function stream(method, body) {
+    return request(method, body).then(function(text) {
+        return text.split("\n").filter(Boolean).map(JSON.parse);
+    });
+}
+
+function fail(error) {
+    showError(error.message);
+}
+
+function showSection(id) {
+    ["new-tournament", "load-tournament", "race", "results"].forEach(function(section) {
+        $(section).hidden = section !== id;
+    });
+    document.querySelectorAll("nav button").forEach(function(button) {
+        button.classList.toggle("active", button.dataset.tab === id);
+    });
+}
+
+function distanceMode() {
+    return !state.tournament || state.tournament.mode !== "TIME";
+}
+
+function setDestination(form, distance, value) {
+    var input = form.elements.destValue;
+    input.min = distance ? 50 : 10;
+    input.max = distance ? 4000 : 5 * 60;
+    if (value) {
+        input.value = value;
+    }
+    form.querySelector(".dest-label").textContent = distance ? "Distance" : "Time";
+    form.querySelector(".dest-unit").textContent = distance ? "m" : "s";
+}
+
+function setTournament(tournament) {
+    state.tournament = tournament;
+    $("tournament-name").textContent = tournament.name;
+
+    var players = $("players"),
+        names = playerNames();
+    players.innerHTML = "";
+    for (var i = 0; i < tournament.playerCount; i++) {
+        var label = document.createElement("label"),
+            input = document.createElement("input");
+        label.textContent = "player #" + (i + 1);
+        input.placeholder = "player name";
+        input.value = names[i] || "";
+        label.appendChild(input);
+        players.appendChild(label);
+    }
+    setDestination($("race-form"), distanceMode(), tournament.destValue);
+}
+
+function playerInputs() {
+    return Array.prototype.slice.call($("players").querySelectorAll("input"));
+}
+
+function playerNames() {
+    return playerInputs().map(function(input) {
+        return input.value;
+    });
+}
+
+function setRacing(racing, established) {
+    state.racing = racing;
+    $("new-race").disabled = racing;
+    $("start-race").disabled = !racing && !established;
+    $("start-race").textContent = racing ? "Stop" : "Start";
+}
+
+function connect() {
+    showError("");
+    return call("GetCurrentTournament").then(setTournament).catch(function(error) {
+        fail(error);
+        $("connection").showModal();
+    });
+}
+
+function loadResults() {
+    var list = $("results-list");
+    stream("GetResults", {gender: $("gender").value}).then(function(results) {
+        var destValue = null;
+        list.innerHTML = "";
+        results.forEach(function(result) {
+            if (result.destValue !== destValue) {
+                destValue = result.destValue;
+                addRow(list, "section", [destValue + (distanceMode() ? "m" : "s")]);
+            }
+            var score = distanceMode() ? result.result / 1000 + "s" : result.result.toFixed(2) + "m";
+            addRow(list, "", [result.player.name, result.raceId > 0 ? "race #" + result.raceId : "", score]);
+        });
+    }).catch(fail);
+}
+
+function addRow(list, className, cells) {
+    var row = document.createElement("tr");
+    row.className = className;
+    cells.forEach(function(text, i) {
+        var cell = document.createElement("td");
+        cell.textContent = text;
+        cell.className = ["name", "race", "score"][i];
+        if (cells.length === 1) {
+            cell.colSpan = 3;
+        }
+        row.appendChild(cell);
+    });
+    list.appendChild(row);
+}
+
+$("connection-button").onclick = function() {
+    $("connection-form").elements.token.value = state.token;
+    $("connection").showModal();
+};
+
+$("connection").onclose = function() {
+    if ($("connection").returnValue === "connect") {
+        state.token = $("connection-form").elements.token.value;
+        localStorage.setItem("token", state.token);
+        connect();
+    }
+};
+
+$("pair").onclick = function() {
+    var form = $("connection-form"),
+        code = form.elements.code.value;
+    if (!code) {
+        call("RequestPairing").catch(fail);
+        return;
+    }
+    call("Pair", {code: code}).then(function(pairing) {
+        form.elements.token.value = pairing.token;
+        form.elements.code.value = "";
+        showError("");
+    }).catch(fail);
+};
+
+$("connection-form").elements.code.oninput = function() {
+    $("pair").textContent = this.value ? "Pair" : "Show code";
+};
+
+$("menu").onchange = function() {
+    var choice = this.value;
+    this.value = "";
+    if (choice === "new-tournament") {
+        showSection(choice);
+    } else if (choice === "load-tournament") {
+        call("GetTournamentNames").then(function(names) {
+            var select = $("tournament-names");
+            select.innerHTML = "";
+            names.name.forEach(function(name) {
+                var option = document.createElement("option");
+                option.textContent = name;
+                option.selected = state.tournament && name === state.tournament.name;
+                select.appendChild(option);
+            });
+            showSection(choice);
+        }).catch(fail);
+    }
+};
+
+$("new-tournament-form").onchange = function() {
+    var distance = this.elements.mode.value === "DISTANCE";
+    setDestination(this, distance, distance ? 400 : 25);
+};
+
+$("new-tournament-form").onsubmit = function(event) {
+    var form = this;
+    event.preventDefault();
+    call("NewTournament", {
+        name: form.elements.name.value,
+        destValue: parseInt(form.elements.destValue.value),
+        mode: form.elements.mode.value,
+        playerCount: parseInt(form.elements.playerCount.value),
+        color: COLORS
+    }).then(function(tournament) {
+        setTournament(tournament);
+        showSection("race");
+    }).catch(fail);
+};
+
+$("dismiss-tournament").onclick = $("dismiss-load").onclick = function() {
+    showSection("race");
+};
+
+$("load-button").onclick = function() {
+    call("LoadTournament", {name: $("tournament-names").value}).then(function(tournament) {
+        setTournament(tournament);
+        showSection("results");
+        loadResults();
+    }).catch(fail);
+};
+
+$("race-form").onsubmit = function(event) {
+    event.preventDefault();
+    call("NewRace", {
+        players: playerNames().map(function(name) {
+            return {name: name};
+        }),
+        destValue: parseInt(this.elements.destValue.value)
+    }).then(function() {
+        showError("");
+        setRacing(false, true);
+    }).catch(fail);
+};
+
+$("start-race").onclick = function() {
+    if (state.racing) {
+        setRacing(false, false);
+        call("AbortRace", {message: "aborted"}).catch(fail);
+        return;
+    }
+    setRacing(true, true);
+    call("StartRace").catch(function(error) {
+        setRacing(false, false);
+        fail(error);
+    });
+};
+
+$("swap").onclick = function() {
+    var inputs = playerInputs(),
+        toSwap = "";
+    inputs.forEach(function(input) {
+        var previous = input.value;
+        input.value = toSwap;
+        toSwap = previous;
+    });
+    if (inputs.length) {
+        inputs[0].value = toSwap;
+    }
+};
+
+$("clear").onclick = function() {
+    playerInputs().forEach(function(input) {
+        input.value = "";
+    });
+};
+
+$("gender").onchange = loadResults;
+
+document.querySelectorAll("nav button").forEach(function(button) {
+    button.onclick = function() {
+        showSection(button.dataset.tab);
+        if (button.dataset.tab === "results") {
+            loadResults();
+        }
+    };
+});
+
+connect();
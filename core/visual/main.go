@@ -2,24 +2,40 @@ package visual
 
 import (
 	"github.com/kkoralsky/gosprints/core"
+	"github.com/kkoralsky/gosprints/core/certs"
 )
 
 func VisualServer(cfg core.VisualConfig) {
 	vis, _ := SetupVis(cfg.HostName, cfg.VisName, cfg.Fullscreen, cfg.ResolutionWidth,
-		cfg.ResolutionHeight, cfg.MovingUnit, cfg.DistFactor)
+		cfg.ResolutionHeight, cfg.MovingUnit, cfg.DistFactor, cfg.WebPort)
+
+	tlsConfig, err := certs.ServerConfig(cfg.TLSCert, cfg.TLSKey, cfg.TLSCA)
+	if err != nil {
+		panic(err)
+	}
+
+	registrar, err := setupRegistrar(cfg)
+	if err != nil {
+		panic(err)
+	}
+	defer registrar.Stop()
 
 	for vis != nil {
-		visServer, err := SetupVisServer(cfg.Port, cfg.GrpcDebug, vis)
+		visServer, err := SetupVisServer(cfg.Port, cfg.GrpcDebug, vis, certs.ServerOptions(tlsConfig)...)
 		if err != nil {
 			panic(err)
 		}
 
 		go visServer.Run()
+		registrar.Announce(vis)
 		vis.Run()
+		// free the port for the visual set up anew
+		visServer.Stop()
 
 		vis = Reconfigure(vis)
 
 		core.ExitGracefully(func() {
+			registrar.Stop()
 			visServer.Stop()
 		})
 	}
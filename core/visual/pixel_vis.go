@@ -86,7 +86,7 @@ func (b *pixelBaseVis) NewTournament(_ context.Context, tournament *pb.Tournamen
 	b.destValue = tournament.DestValue
 	b.mode = tournament.Mode
 	switch b.mode {
-	case pb.Tournament_DISTANCE:
+	case pb.Tournament_DISTANCE, pb.Tournament_RELAY, pb.Tournament_ELIMINATION, pb.Tournament_PURSUIT:
 		b.modeUnit = "s"
 	case pb.Tournament_TIME:
 		b.modeUnit = "m"
@@ -116,13 +116,21 @@ func (b *pixelBaseVis) NewRace(_ context.Context, race *pb.Race) (*pb.Empty, err
 
 	b.Clear()
 	b.playerNames = nil
+	b.teamNames = nil
 	b.racingData = nil
+	for _, team := range race.Teams {
+		b.teamNames = append(b.teamNames, team.Name)
+	}
 	starterText := text.New(winCenter, b.fontAtlas)
 	starterText.LineHeight = b.fontAtlas.LineHeight() * 2.5
 	for i, p := range race.Players {
+		var label = p.Name
+		if i < len(race.Handicap) {
+			label += b.handicapText(race.Handicap[i])
+		}
 		starterText.Color = b.colors[i]
-		starterText.Dot.X -= starterText.BoundsOf(p.Name).W() / 2
-		starterText.WriteString(p.Name + "\n")
+		starterText.Dot.X -= starterText.BoundsOf(label).W() / 2
+		starterText.WriteString(label + "\n")
 
 		b.playerNames = append(b.playerNames, p.Name)
 		if i+1 != len(race.Players) {
@@ -138,21 +146,31 @@ func (b *pixelBaseVis) NewRace(_ context.Context, race *pb.Race) (*pb.Empty, err
 }
 
 func (b *pixelBaseVis) AbortRace(_ context.Context, abortMessage *pb.AbortMessage) (*pb.Empty, error) {
+	if abortMessage.Message == "" {
+		abortMessage.Message = "aborted"
+	}
+	b.showMessage(abortMessage.Message)
+	return &pb.Empty{}, nil
+}
+
+func (b *pixelBaseVis) ShowPairingCode(_ context.Context, code *pb.PairingCode) (*pb.Empty, error) {
+	b.showMessage(fmt.Sprintf("pairing code: %s", code.Code))
+	return &pb.Empty{}, nil
+}
+
+// showMessage draws the message in the middle of the window
+func (b *pixelBaseVis) showMessage(message string) {
 	var (
 		winCenter = b.win.Bounds().Center()
 	)
 	messageText := text.New(winCenter, b.fontAtlas)
 	messageText.Color = fontColor
 
-	if abortMessage.Message == "" {
-		abortMessage.Message = "aborted"
-	}
-	messageText.WriteString(abortMessage.Message)
+	messageText.WriteString(message)
 	messageText.Draw(b.win, pixel.IM.Moved(pixel.V(-messageText.Bounds().W()/2,
 		-messageText.Bounds().H()/3)).Scaled(winCenter, fontScale))
 
 	b.win.Update()
-	return &pb.Empty{}, nil
 }
 
 func (b *pixelBaseVis) StartRace(_ context.Context, starter *pb.Starter) (*pb.Empty, error) {
@@ -194,11 +212,28 @@ func (b *pixelBaseVis) FinishRace(_ context.Context, results *pb.Results) (*pb.E
 		winCenter  = b.win.Bounds().Center()
 		resultText = text.New(winCenter, b.fontAtlas)
 	)
+	if len(results.Result) > 0 && results.Result[0].RaceId > 0 {
+		fmt.Fprintf(resultText, "race #%d\n\n", results.Result[0].RaceId)
+	}
 	for i, result := range results.Result {
-		resultText.Color = b.colors[i]
+		if int(result.Lane) < len(b.colors) {
+			resultText.Color = b.colors[result.Lane]
+		} else {
+			resultText.Color = b.colors[i]
+		}
 		resultText.WriteString(result.Player.Name)
 		resultText.Color = fontColor
-		fmt.Fprintf(resultText, " %.3f%s\n\n", b.getResult(result.Result), b.modeUnit)
+		fmt.Fprintf(resultText, " %.3f%s", b.getResult(result.Result), b.modeUnit)
+		if result.Handicap != nil {
+			fmt.Fprintf(resultText, " (%.3f%s%s)", b.getResult(result.RawResult), b.modeUnit,
+				b.handicapText(result.Handicap))
+		}
+		if result.Record {
+			resultText.WriteString(" new record!")
+		} else if result.PersonalBest {
+			resultText.WriteString(" PB!")
+		}
+		resultText.WriteString("\n\n")
 	}
 
 	resultText.Draw(b.win, pixel.IM.Moved(winCenter.Sub(resultText.Bounds().Center())).
@@ -235,6 +270,9 @@ func (b *pixelBaseVis) ConfigureVis(_ context.Context, visCfg *pb.VisConfigurati
 			VSync:     true,
 		}
 		b.imd = imdraw.New(nil)
+	} else if b.win != nil {
+		// closed window makes the visual set up anew with the configuration
+		b.win.SetClosed(true)
 	}
 	return &pb.Empty{}, nil
 }
@@ -272,6 +310,28 @@ func (b *pixelBaseVis) UpdateRace(stream pb.Visual_UpdateRaceServer) error {
 	return nil
 }
 
+// SwapRiders shows name of the rider who has just taken over the relay lane
+func (b *pixelBaseVis) SwapRiders(_ context.Context, swap *pb.Swap) (*pb.Empty, error) {
+	if int(swap.PlayerNum) >= len(b.playerNames) || int(swap.PlayerNum) >= len(b.teamNames) {
+		return &pb.Empty{}, errors.New("player names not set properly - run NewRace first")
+	}
+	b.playerNames[swap.PlayerNum] = fmt.Sprintf("%s: %s", b.teamNames[swap.PlayerNum], swap.Rider.Name)
+	return &pb.Empty{}, nil
+}
+
+// EliminateRider marks the rider who has been knocked out or caught
+func (b *pixelBaseVis) EliminateRider(_ context.Context, elimination *pb.Elimination) (*pb.Empty, error) {
+	if int(elimination.PlayerNum) >= len(b.playerNames) {
+		return &pb.Empty{}, errors.New("player names not set properly - run NewRace first")
+	}
+	if elimination.Caught {
+		b.playerNames[elimination.PlayerNum] += " caught"
+	} else {
+		b.playerNames[elimination.PlayerNum] += fmt.Sprintf(" out (%d.)", elimination.Place)
+	}
+	return &pb.Empty{}, nil
+}
+
 func (p *pixelBaseVis) StopVis(context.Context, *pb.Empty) (*pb.Empty, error) {
 	p.win.SetClosed(true)
 	return &pb.Empty{}, nil
@@ -342,3 +402,17 @@ func loadTTF(path string, size float64) (font.Face, error) {
 		GlyphCacheEntries: 1,
 	}), nil
 }
+
+// handicapText describes handicap of the player; empty if there's none
+func (b *pixelBaseVis) handicapText(handicap *pb.Handicap) (description string) {
+	if handicap == nil {
+		return
+	}
+	if handicap.StartDelay > 0 {
+		description += fmt.Sprintf(" +%.1fs", float32(handicap.StartDelay)/1000)
+	}
+	if handicap.DistanceBonus > 0 && b.visCfg != nil {
+		description += fmt.Sprintf(" +%.0fm", float32(handicap.DistanceBonus*b.visCfg.DistFactor)/100)
+	}
+	return
+}
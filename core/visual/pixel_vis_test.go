@@ -18,7 +18,7 @@ var (
 func TestMain(m *testing.M) {
 	vis_name := flag.String("vis_name", "clock", "either clock or bar")
 	flag.Parse()
-	vis, _ = SetupVis("gosprints", *vis_name, false, 640, 480, 1, 25*5)
+	vis, _ = SetupVis("gosprints", *vis_name, false, 640, 480, 1, 25*5, 9996)
 	go func() {
 		time.Sleep(1100 * time.Millisecond) // wait for visualization to setup
 		m.Run()
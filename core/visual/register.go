@@ -0,0 +1,140 @@
+package visual
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	log "github.com/kkoralsky/gosprints/core"
+	"github.com/kkoralsky/gosprints/core/certs"
+	pb "github.com/kkoralsky/gosprints/proto"
+	"google.golang.org/grpc"
+)
+
+// announceInterval is how often the visual registers again so that the
+// restarted server gets to know about it as well
+const announceInterval = 10 * time.Second
+
+// registrar keeps the visual known to the server by registering with it
+// and/or broadcasting the registration within the LAN
+type registrar struct {
+	mu        sync.Mutex
+	port      uint32
+	token     string
+	vis       VisInterface
+	conn      *grpc.ClientConn
+	client    pb.SprintsClient
+	broadcast *net.UDPConn
+	stop      chan struct{}
+	stopOnce  sync.Once
+}
+
+func setupRegistrar(cfg log.VisualConfig) (*registrar, error) {
+	var (
+		r   = &registrar{port: uint32(cfg.Port), token: cfg.Token, stop: make(chan struct{})}
+		err error
+	)
+	if cfg.Server != "" {
+		var transport grpc.DialOption
+		if transport, err = certs.DialOption(cfg.TLSCA, cfg.TLSCert, cfg.TLSKey); err != nil {
+			return r, err
+		}
+		r.conn, err = grpc.Dial(cfg.Server, transport,
+			grpc.WithPerRPCCredentials(log.TokenCredentials(cfg.Token)))
+		if err != nil {
+			return r, err
+		}
+		r.client = pb.NewSprintsClient(r.conn)
+	}
+	if cfg.DiscoveryPort > 0 {
+		r.broadcast, err = net.DialUDP("udp4", nil,
+			&net.UDPAddr{IP: net.IPv4bcast, Port: int(cfg.DiscoveryPort)})
+	}
+	return r, err
+}
+
+// Announce makes the visual known from now on; registrations are repeated
+// in the background until stopped
+func (r *registrar) Announce(vis VisInterface) {
+	r.mu.Lock()
+	first := r.vis == nil
+	r.vis = vis
+	r.mu.Unlock()
+
+	if first {
+		go r.run()
+	}
+}
+
+func (r *registrar) registration() *pb.VisualRegistration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return &pb.VisualRegistration{Port: r.port, Configuration: r.vis.GetVisCfg()}
+}
+
+func (r *registrar) run() {
+	var ticker = time.NewTicker(announceInterval)
+	defer ticker.Stop()
+
+	for {
+		r.announce()
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *registrar) announce() {
+	var registration = r.registration()
+
+	if r.client != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), announceInterval)
+		if _, err := r.client.RegisterVisual(ctx, registration); err != nil {
+			log.ErrorLogger.Printf("couldnt register with the server: %v", err)
+		}
+		cancel()
+	}
+	if r.broadcast != nil {
+		if _, err := r.broadcast.Write(r.announcement(registration)); err != nil {
+			log.ErrorLogger.Printf("couldnt broadcast the registration: %v", err)
+		}
+	}
+}
+
+// announcement is the datagram of the registration signed with the token
+func (r *registrar) announcement(registration *pb.VisualRegistration) []byte {
+	var announcement = &pb.Announcement{SentAt: time.Now().Unix()}
+
+	announcement.Registration, _ = proto.Marshal(registration)
+	if r.token != "" {
+		announcement.Signature = log.SignAnnouncement(r.token, announcement)
+	}
+	datagram, _ := proto.Marshal(announcement)
+	return datagram
+}
+
+// Stop announcing the visual and tell the server it's gone
+func (r *registrar) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.stop)
+		if r.client != nil {
+			r.mu.Lock()
+			announced := r.vis != nil
+			r.mu.Unlock()
+			if announced {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				r.client.UnregisterVisual(ctx, r.registration())
+				cancel()
+			}
+			r.conn.Close()
+		}
+		if r.broadcast != nil {
+			r.broadcast.Close()
+		}
+	})
+}
@@ -35,6 +35,7 @@ type BaseVis struct {
 	colors      []color.RGBA
 	playerCount uint32
 	playerNames []string
+	teamNames   []string
 	destValue   uint32
 	mode        pb.Tournament_TournamentMode
 	modeUnit    string
@@ -54,6 +55,10 @@ func (b *BaseVis) GetVisCfg() *pb.VisConfiguration {
 	return b.visCfg
 }
 
+func (b *BaseVis) GetConfiguration(context.Context, *pb.Empty) (*pb.VisConfiguration, error) {
+	return b.GetVisCfg(), nil
+}
+
 func (b *BaseVis) updRacingData(playerNum, dist uint32) (realDist, velo float32) {
 
 	var (
@@ -84,7 +89,7 @@ func (b *BaseVis) updRacingData(playerNum, dist uint32) (realDist, velo float32)
 
 func (b *BaseVis) getResult(result float32) float32 {
 	switch b.mode {
-	case pb.Tournament_DISTANCE:
+	case pb.Tournament_DISTANCE, pb.Tournament_RELAY, pb.Tournament_ELIMINATION, pb.Tournament_PURSUIT:
 		return result * float32(math.Pow10(-9)) // decode from nanoseconds to seconds
 	case pb.Tournament_TIME:
 		return result
@@ -102,6 +107,8 @@ func selectVis(visName string) (VisInterface, error) {
 		return NewClock2Vis(), nil
 	case "game":
 		return NewGameVis(), nil
+	case "web":
+		return NewWebVis(), nil
 	default:
 		err := fmt.Errorf("'%s' visualization not found; falling back to 'bar'", visName)
 		core.ErrorLogger.Println(err.Error())
@@ -119,7 +126,7 @@ func Reconfigure(b VisInterface) VisInterface {
 }
 
 func SetupVis(hostName string, visName string, fullscreen bool, resolutionWidth uint,
-	resolutionHeight uint, movingUnit uint, distFactor uint) (VisInterface, error) {
+	resolutionHeight uint, movingUnit uint, distFactor uint, webPort uint) (VisInterface, error) {
 
 	vis, err := selectVis(visName)
 	if vis != nil {
@@ -131,6 +138,7 @@ func SetupVis(hostName string, visName string, fullscreen bool, resolutionWidth
 			ResolutionHeight: uint32(resolutionHeight),
 			MovingUnit:       uint32(movingUnit),
 			DistFactor:       uint32(distFactor),
+			WebPort:          uint32(webPort),
 		})
 
 		vis.NewTournament(context.Background(), &core.DefultTournament)
@@ -5,8 +5,13 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 	"net"
+	"time"
 )
 
+// stopTimeout is how long the calls in progress, e.g. the one restarting
+// the visual, get to finish when the server stops
+const stopTimeout = time.Second
+
 type VisServer struct {
 	port        uint
 	grpcServer  *grpc.Server
@@ -19,15 +24,24 @@ func (v *VisServer) Run() {
 }
 
 func (v *VisServer) Stop() {
-	v.grpcServer.Stop()
+	var stopped = make(chan struct{})
+	go func() {
+		v.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(stopTimeout):
+		v.grpcServer.Stop()
+	}
 	v.tcpListener.Close()
 }
 
-func SetupVisServer(port uint, debug bool, vis VisInterface) (*VisServer, error) {
+func SetupVisServer(port uint, debug bool, vis VisInterface, opts ...grpc.ServerOption) (*VisServer, error) {
 	var (
 		v = &VisServer{
 			port:       port,
-			grpcServer: grpc.NewServer(),
+			grpcServer: grpc.NewServer(opts...),
 			vis:        vis,
 		}
 		err error
@@ -0,0 +1,174 @@
+package visual
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/gobuffalo/packr"
+	"github.com/golang/protobuf/jsonpb"
+	log "github.com/kkoralsky/gosprints/core"
+	"github.com/kkoralsky/gosprints/core/feed"
+	pb "github.com/kkoralsky/gosprints/proto"
+)
+
+// events of the web visual on top of the ones in package feed
+const (
+	clearEvent   = "clear"   // Empty: screen to be cleared
+	pairingEvent = "pairing" // PairingCode to be shown
+)
+
+// webVis serves the page drawing the race in browsers instead of drawing it
+// itself, so that any number of TVs or laptops can show it; the events are
+// forwarded to them over WebSocket
+type webVis struct {
+	BaseVis
+
+	mu       sync.Mutex
+	feed     *feed.Feed
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func NewWebVis() *webVis {
+	return &webVis{
+		feed: feed.New(),
+		stop: make(chan struct{}),
+	}
+}
+
+func (w *webVis) handler() http.Handler {
+	var mux = http.NewServeMux()
+
+	mux.Handle("/events", w.feed.Handler())
+	mux.HandleFunc("/configuration", func(rw http.ResponseWriter, r *http.Request) {
+		w.mu.Lock()
+		visCfg := w.visCfg
+		w.mu.Unlock()
+
+		rw.Header().Set("Content-Type", "application/json")
+		marshaler := &jsonpb.Marshaler{EmitDefaults: true}
+		if err := marshaler.Marshal(rw, visCfg); err != nil {
+			log.ErrorLogger.Printf("couldnt send configuration: %v", err)
+		}
+	})
+	mux.Handle("/assets/", http.StripPrefix("/assets/", http.FileServer(packr.NewBox("./assets"))))
+	mux.Handle("/", http.FileServer(packr.NewBox("./web")))
+	return mux
+}
+
+// Run serves the page until the visual is stopped or reconfigured
+func (w *webVis) Run() {
+	listener, err := net.ListenTCP("tcp", &net.TCPAddr{Port: int(w.GetVisCfg().WebPort)})
+	if err != nil {
+		panic(err)
+	}
+	server := &http.Server{Handler: w.handler()}
+	go server.Serve(listener)
+	log.InfoLogger.Printf("web visual served on port %d", w.GetVisCfg().WebPort)
+
+	<-w.stop
+	server.Close()
+}
+
+func (w *webVis) GetVisCfg() *pb.VisConfiguration {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.visCfg
+}
+
+func (w *webVis) GetConfiguration(context.Context, *pb.Empty) (*pb.VisConfiguration, error) {
+	return w.GetVisCfg(), nil
+}
+
+func (w *webVis) NewTournament(_ context.Context, tournament *pb.Tournament) (*pb.Empty, error) {
+	w.playerCount = tournament.PlayerCount
+	w.destValue = tournament.DestValue
+	w.mode = tournament.Mode
+	w.feed.Send(feed.Tournament, tournament)
+	return &pb.Empty{}, nil
+}
+
+func (w *webVis) NewRace(_ context.Context, race *pb.Race) (*pb.Empty, error) {
+	w.playerNames = nil
+	for _, player := range race.Players {
+		w.playerNames = append(w.playerNames, player.Name)
+	}
+	w.feed.Send(feed.Race, race)
+	return &pb.Empty{}, nil
+}
+
+func (w *webVis) StartRace(_ context.Context, starter *pb.Starter) (*pb.Empty, error) {
+	w.feed.Send(feed.Countdown, starter)
+	return &pb.Empty{}, nil
+}
+
+func (w *webVis) AbortRace(_ context.Context, abortMessage *pb.AbortMessage) (*pb.Empty, error) {
+	if abortMessage.Message == "" {
+		abortMessage.Message = "aborted"
+	}
+	w.feed.Send(feed.Abort, abortMessage)
+	return &pb.Empty{}, nil
+}
+
+func (w *webVis) UpdateRace(stream pb.Visual_UpdateRaceServer) error {
+	for {
+		racer, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		w.feed.Send(feed.Update, racer)
+	}
+}
+
+func (w *webVis) SwapRiders(_ context.Context, swap *pb.Swap) (*pb.Empty, error) {
+	w.feed.Send(feed.Swap, swap)
+	return &pb.Empty{}, nil
+}
+
+func (w *webVis) EliminateRider(_ context.Context, elimination *pb.Elimination) (*pb.Empty, error) {
+	w.feed.Send(feed.Elimination, elimination)
+	return &pb.Empty{}, nil
+}
+
+func (w *webVis) FinishRace(_ context.Context, results *pb.Results) (*pb.Empty, error) {
+	w.feed.Send(feed.Finish, results)
+	return &pb.Empty{}, nil
+}
+
+func (w *webVis) ShowResults(_ context.Context, results *pb.Results) (*pb.Empty, error) {
+	w.feed.Send(feed.Results, results)
+	return &pb.Empty{}, nil
+}
+
+func (w *webVis) ShowPairingCode(_ context.Context, code *pb.PairingCode) (*pb.Empty, error) {
+	w.feed.Send(pairingEvent, code)
+	return &pb.Empty{}, nil
+}
+
+// ConfigureVis applied again stops serving so that the visual is set up anew
+// with the configuration
+func (w *webVis) ConfigureVis(_ context.Context, visCfg *pb.VisConfiguration) (*pb.Empty, error) {
+	w.mu.Lock()
+	w.visCfg = visCfg
+	w.mu.Unlock()
+
+	if !w.ResetConfiguration() {
+		w.StopVis(context.Background(), &pb.Empty{})
+	}
+	return &pb.Empty{}, nil
+}
+
+func (w *webVis) StopVis(context.Context, *pb.Empty) (*pb.Empty, error) {
+	w.stopOnce.Do(func() { close(w.stop) })
+	return &pb.Empty{}, nil
+}
+
+func (w *webVis) Clear() {
+	w.feed.Send(clearEvent, &pb.Empty{})
+}
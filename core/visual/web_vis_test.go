@@ -0,0 +1,75 @@
+package visual
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kkoralsky/gosprints/core/feed"
+	pb "github.com/kkoralsky/gosprints/proto"
+	"golang.org/x/net/websocket"
+)
+
+func Test_WebVis(t *testing.T) {
+	webVis := NewWebVis()
+	webVis.ConfigureVis(context.Background(), &pb.VisConfiguration{HostName: "tv", VisName: "web"})
+	server := httptest.NewServer(webVis.handler())
+	defer server.Close()
+
+	for path, content := range map[string]string{
+		"/":              "/events",
+		"/configuration": `"hostName":"tv"`,
+	} {
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if !strings.Contains(string(body), content) {
+			t.Errorf("%s should contain %s", path, content)
+		}
+	}
+
+	ws, err := websocket.Dial(strings.Replace(server.URL, "http", "ws", 1)+"/events", "", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+	for webVis.feed.Clients() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	webVis.NewTournament(context.Background(), &pb.Tournament{Name: "web", PlayerCount: 2})
+	webVis.NewRace(context.Background(), &pb.Race{Players: []*pb.Player{{Name: "first"}, {Name: "second"}}})
+	webVis.FinishRace(context.Background(), &pb.Results{})
+	for _, expected := range []string{feed.Tournament, feed.Race, feed.Finish} {
+		var (
+			frame string
+			event struct{ Event string }
+		)
+		if err := websocket.Message.Receive(ws, &frame); err != nil {
+			t.Fatal(err)
+		}
+		json.Unmarshal([]byte(frame), &event)
+		if event.Event != expected {
+			t.Errorf("%s should be forwarded to browsers, got %s", expected, event.Event)
+		}
+	}
+
+	// configured again it stops to be set up anew
+	webVis.ConfigureVis(context.Background(), &pb.VisConfiguration{HostName: "tv", VisName: "bar"})
+	select {
+	case <-webVis.stop:
+	default:
+		t.Error("reconfigured visual should stop")
+	}
+	if webVis.IsConfigured() {
+		t.Error("reconfigured visual should be set up again")
+	}
+}
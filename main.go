@@ -4,6 +4,7 @@ import (
 	"flag"
 	"fmt"
 	"github.com/kkoralsky/gosprints/core"
+	"github.com/kkoralsky/gosprints/core/certs"
 	"github.com/kkoralsky/gosprints/core/server"
 	"github.com/kkoralsky/gosprints/core/visual"
 	"os"
@@ -11,7 +12,7 @@ import (
 
 func main() {
 	flag.Usage = func() {
-		fmt.Printf("Usage:\n%s server|visual [-help|other options]\n", os.Args[0])
+		fmt.Printf("Usage:\n%s server|visual|migrate|export|pdf|import|certs [-help|other options]\n", os.Args[0])
 		flag.PrintDefaults()
 	}
 	flag.Parse()
@@ -25,8 +26,38 @@ func main() {
 			server.SprintsServer(cfg)
 		case "visual":
 			cfg := core.VisualConfig{}
-			core.FlagsetParse(cfg.Setup(), args[1:], nil)
+			core.FlagsetParse(cfg.Setup(), args[1:], cfg.Validate)
 			visual.VisualServer(cfg)
+		case "migrate":
+			cfg := core.MigrateConfig{}
+			core.FlagsetParse(cfg.Setup(), args[1:], cfg.Validate)
+			if err := server.Migrate(cfg); err != nil {
+				core.ErrorLogger.Fatalln(err)
+			}
+		case "export":
+			cfg := core.ExportConfig{}
+			core.FlagsetParse(cfg.Setup(), args[1:], cfg.Validate)
+			if err := server.Export(cfg); err != nil {
+				core.ErrorLogger.Fatalln(err)
+			}
+		case "pdf":
+			cfg := core.PdfConfig{}
+			core.FlagsetParse(cfg.Setup(), args[1:], cfg.Validate)
+			if err := server.Pdf(cfg); err != nil {
+				core.ErrorLogger.Fatalln(err)
+			}
+		case "import":
+			cfg := core.ImportConfig{}
+			core.FlagsetParse(cfg.Setup(), args[1:], cfg.Validate)
+			if err := server.Import(cfg); err != nil {
+				core.ErrorLogger.Fatalln(err)
+			}
+		case "certs":
+			cfg := core.CertsConfig{}
+			core.FlagsetParse(cfg.Setup(), args[1:], cfg.Validate)
+			if err := certs.Generate(cfg); err != nil {
+				core.ErrorLogger.Fatalln(err)
+			}
 		default:
 			flag.Usage()
 		}
@@ -30,13 +30,16 @@ ApplicationWindow {
         Qt.application.domain = "domain"
         Qt.application.organization = "organization"
 
-        SprintsClient.dialGrpc(settings.connectionHost, settings.connectionPort, false)
+        SprintsClient.dialGrpc(settings.connectionHost, settings.connectionPort,
+                               settings.connectionToken, settings.connectionCa, false)
     }
 
     Settings {
         id: settings
         property alias connectionHost: connectionHostField.text
         property alias connectionPort: connectionPortField.text
+        property alias connectionToken: connectionTokenField.text
+        property alias connectionCa: connectionCaField.text
     }
 
     Connections {
@@ -181,6 +184,68 @@ ApplicationWindow {
                 }
             }
 
+            RowLayout {
+                spacing: 10
+
+                Label {
+                    text: "Token:"
+                }
+
+                TextField {
+                    id: connectionTokenField
+                    echoMode: TextInput.Password
+                    Layout.fillWidth: true
+                }
+            }
+
+            RowLayout {
+                spacing: 10
+
+                Label {
+                    text: "CA file:"
+                }
+
+                TextField {
+                    id: connectionCaField
+                    placeholderText: "none for plain connection"
+                    Layout.fillWidth: true
+                }
+            }
+
+            RowLayout {
+                spacing: 10
+
+                TextField {
+                    id: pairingCodeField
+                    placeholderText: "pairing code"
+                    inputMethodHints: Qt.ImhDigitsOnly
+                    Layout.fillWidth: true
+                }
+
+                Button {
+                    text: pairingCodeField.text ? "Pair" : "Show code"
+                    onClicked: {
+                        if(!pairingCodeField.text) {
+                            SprintsClient.requestPairing()
+                        } else if(!SprintsClient.pair(pairingCodeField.text)) {
+                            connectionTokenField.text = SprintsClient.token
+                            pairingCodeField.text = ""
+                            SprintsClient.dialGrpc(
+                                    connectionHostField.text,
+                                    parseInt(connectionPortField.text),
+                                    connectionTokenField.text,
+                                    connectionCaField.text,
+                                    false
+                            )
+                        }
+                    }
+
+                    Material.foreground: Material.primary
+                    Material.background: "transparent"
+                    Material.elevation: 0
+                }
+            }
+
             RowLayout {
                 spacing: 10
 
@@ -191,6 +256,8 @@ ApplicationWindow {
                         SprintsClient.dialGrpc(
                                 connectionHostField.text,
                                 parseInt(connectionPortField.text),
+                                connectionTokenField.text,
+                                connectionCaField.text,
                                 false  // dont block
                         )
                         settingsPopup.close()
@@ -6,9 +6,13 @@ import QtQuick.Dialogs 1.0
 Page {
     id: newTournamentPage
 
+    // as in proto
     enum TournamentMode {
         DISTANCE,
-        TIME
+        TIME,
+        RELAY,
+        ELIMINATION,
+        PURSUIT
     }
 
     property string name: tournamentNameTextField.text
@@ -20,7 +20,9 @@ Pane {
 
             Label {
                 id: sectionLabel
-                text: section + (newTournamentPage.mode == NewTournament.TournamentMode.DISTANCE ? "m" : "s")
+                // time and elimination interval are in seconds, distances in meters
+                text: section + (TournamentConfig.mode == NewTournament.TournamentMode.TIME ||
+                                 TournamentConfig.mode == NewTournament.TournamentMode.ELIMINATION ? "s" : "m")
                 anchors.centerIn: parent
             }
         }
@@ -34,13 +36,20 @@ Pane {
                     text: name
                 }
 
+                Label {
+                    visible: raceId > 0
+                    text: "race #" + raceId
+                    opacity: 0.6
+                }
+
                 Label {
                     Layout.fillWidth: true
+                    // results of time constrained races are meters, the others miliseconds
                     text: {
-                        if(newTournamentPage.mode == NewTournament.TournamentMode.DISTANCE) {
-                            return score / 1000 + "s"
+                        if(TournamentConfig.mode == NewTournament.TournamentMode.TIME) {
+                            return score.toFixed(2) + "m"
                         } else {
-                            return score + "m"
+                            return score / 1000 + "s"
                         }
                     }
                     horizontalAlignment: Qt.AlignRight
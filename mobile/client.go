@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	log "github.com/kkoralsky/gosprints/core"
+	"github.com/kkoralsky/gosprints/core/certs"
 	pb "github.com/kkoralsky/gosprints/proto"
 	"github.com/therecipe/qt/core"
 	"google.golang.org/grpc"
@@ -44,7 +45,10 @@ type SprintsClient struct {
 	_ func(msg string)      `signal:"success"`
 
 	_ int                                                 `property:"connState"`
-	_ func(string, uint, bool) string                     `slot:"dialGrpc"`
+	_ string                                              `property:"token"`
+	_ func(string, uint, string, string, bool) string     `slot:"dialGrpc"`
+	_ func() string                                       `slot:"requestPairing"`
+	_ func(string) string                                 `slot:"pair"`
 	_ func(string, uint, int32, uint, []string) string    `slot:"newTournament"`
 	_ func(string) error                                  `slot:"loadTournament"`
 	_ func([]string, uint) string                         `slot:"newRace"`
@@ -59,7 +63,9 @@ func init() {
 }
 
 type SprintsClientInterface interface {
-	dialGrpc(string, uint, bool) string
+	dialGrpc(string, uint, string, string, bool) string
+	requestPairing() string
+	pair(string) string
 	newTournament(string, uint, int32, uint, []string) string
 	newRace([]string, uint) string
 	startRace() string
@@ -76,6 +82,8 @@ func SetupSprintsClient(resultModel *ResultModel) *SprintsClient {
 	client.connState = connectivity.Shutdown
 
 	client.ConnectDialGrpc(client.dialGrpc)
+	client.ConnectRequestPairing(client.requestPairing)
+	client.ConnectPair(client.pair)
 	client.ConnectNewTournament(client.newTournament)
 	client.ConnectNewRace(client.newRace)
 	client.ConnectStartRace(client.startRace)
@@ -89,18 +97,27 @@ func SetupSprintsClient(resultModel *ResultModel) *SprintsClient {
 	return client
 }
 
-func (s *SprintsClient) dialGrpc(hostName string, port uint, blocking bool) string {
-	var err error
+// dialGrpc connects to the server over TLS if the CA certificate file is
+// given, insecurely otherwise
+func (s *SprintsClient) dialGrpc(hostName string, port uint, token string, caFile string, blocking bool) string {
+	transport, err := certs.DialOption(caFile, "", "")
+	if err != nil {
+		log.ErrorLogger.Println(err.Error())
+		return err.Error()
+	}
+	var dialOptions = []grpc.DialOption{
+		transport,
+		grpc.WithTimeout(10 * time.Second),
+		grpc.WithPerRPCCredentials(log.TokenCredentials(token)),
+	}
 	s.Close()
 
 	s.addr = fmt.Sprintf("%s:%d", hostName, port)
 	log.DebugLogger.Printf("trying connect to %s endpoint\n", s.addr)
 	if blocking {
-		s.conn, err = grpc.Dial(s.addr, grpc.WithInsecure(), grpc.WithTimeout(10*time.Second),
-			grpc.WithBlock())
-	} else {
-		s.conn, err = grpc.Dial(s.addr, grpc.WithInsecure(), grpc.WithTimeout(10*time.Second))
+		dialOptions = append(dialOptions, grpc.WithBlock())
 	}
+	s.conn, err = grpc.Dial(s.addr, dialOptions...)
 	if err != nil {
 		log.ErrorLogger.Println(err.Error())
 		return err.Error()
@@ -179,6 +196,28 @@ func (s *SprintsClient) updateConnectionState() {
 	log.DebugLogger.Printf("stop updating connection state: %s", s.connState.String())
 }
 
+// requestPairing makes the server show pairing code on the visuals
+func (s *SprintsClient) requestPairing() string {
+	_, err := s.client.RequestPairing(context.Background(), &pb.Empty{})
+	if err != nil {
+		log.ErrorLogger.Println(err.Error())
+		return err.Error()
+	}
+	return ""
+}
+
+// pair exchanges the pairing code for the token which is set then
+func (s *SprintsClient) pair(code string) string {
+	pairing, err := s.client.Pair(context.Background(), &pb.PairingRequest{Code: code})
+	if err != nil {
+		log.ErrorLogger.Println(err.Error())
+		return err.Error()
+	}
+	s.SetToken(pairing.Token)
+	log.InfoLogger.Printf("paired as %s", pairing.Role)
+	return ""
+}
+
 func (s *SprintsClient) newTournament(name string, destValue uint, mode int, playerCount uint, colors []string) string {
 	var err error
 	s.tournament, err = s.client.NewTournament(context.Background(), &pb.Tournament{
@@ -193,6 +232,7 @@ func (s *SprintsClient) newTournament(name string, destValue uint, mode int, pla
 		log.ErrorLogger.Println(err.Error())
 		return err.Error()
 	}
+	s.updateCurrentTournament()
 	return ""
 }
 
@@ -284,7 +324,8 @@ func (s *SprintsClient) getResults(gender string) {
 			result.Player.Name,
 			pb.Gender_name[int32(result.Player.Gender)],
 			result.Result,
-			uint(result.DestValue))
+			uint(result.DestValue),
+			uint(result.RaceId))
 	}
 	log.DebugLogger.Printf("loaded %d results", s.resultModel.rowCount(nil))
 	return
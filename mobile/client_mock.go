@@ -10,12 +10,21 @@ type mockSprintsClient struct {
 	SprintsClient
 }
 
-func (m *mockSprintsClient) dialGrpc(string, uint, bool) string {
+func (m *mockSprintsClient) dialGrpc(string, uint, string, string, bool) string {
 	m.connState = connectivity.Ready
 	m.SetConnState(int(m.connState))
 	return ""
 }
 
+func (m *mockSprintsClient) requestPairing() string {
+	return ""
+}
+
+func (m *mockSprintsClient) pair(string) string {
+	m.SetToken("mock")
+	return ""
+}
+
 func (m *mockSprintsClient) newTournament(string, uint, int, uint, []string) string {
 	return ""
 }
@@ -43,6 +52,7 @@ func (m *mockSprintsClient) getResults(string) {
 			pb.Gender_name[int32(result.Player.Gender)],
 			result.Result,
 			uint(result.DestValue),
+			uint(result.RaceId),
 		)
 	}
 }
@@ -54,6 +64,8 @@ func setupMockSprintsClient(resultModel *ResultModel) *mockSprintsClient {
 	client.connState = connectivity.Shutdown
 
 	client.ConnectDialGrpc(client.dialGrpc)
+	client.ConnectRequestPairing(client.requestPairing)
+	client.ConnectPair(client.pair)
 	client.ConnectNewTournament(client.newTournament)
 	client.ConnectNewRace(client.newRace)
 	client.ConnectStartRace(client.startRace)
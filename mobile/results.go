@@ -9,6 +9,7 @@ const (
 	Score
 	DestValue
 	Gender
+	RaceId
 )
 
 type ResultModel struct {
@@ -19,7 +20,7 @@ type ResultModel struct {
 	_ map[int]*core.QByteArray `property:"roles"`
 	_ []*Result                `property:"results"`
 
-	_ func(string, string, float32, uint) `slot:"addResult"`
+	_ func(string, string, float32, uint, uint) `slot:"addResult"`
 }
 
 type Result struct {
@@ -29,6 +30,7 @@ type Result struct {
 	_ float32 `property:"score"`
 	_ uint32  `property:"destValue"`
 	_ string  `property:"gender"`
+	_ uint32  `property:"raceId"`
 }
 
 func init() {
@@ -41,6 +43,7 @@ func (r *ResultModel) init() {
 		Score:     core.NewQByteArray2("score", len("score")),
 		Gender:    core.NewQByteArray2("gender", len("gender")),
 		DestValue: core.NewQByteArray2("destValue", len("destValue")),
+		RaceId:    core.NewQByteArray2("raceId", len("raceId")),
 	})
 
 	r.ConnectRowCount(r.rowCount)
@@ -75,6 +78,8 @@ func (r *ResultModel) data(index *core.QModelIndex, role int) *core.QVariant {
 		return core.NewQVariant13(result.Score())
 	case DestValue:
 		return core.NewQVariant8(result.DestValue())
+	case RaceId:
+		return core.NewQVariant8(result.RaceId())
 	default:
 		return core.NewQVariant()
 	}
@@ -90,7 +95,7 @@ func (r *ResultModel) modelReset() {
 	r.EndResetModel()
 }
 
-func (r *ResultModel) addResult(playerName, gender string, score float32, destValue uint) {
+func (r *ResultModel) addResult(playerName, gender string, score float32, destValue, raceId uint) {
 	r.BeginInsertRows(core.NewQModelIndex(), len(r.Results()), len(r.Results()))
 
 	result := NewResult(nil)
@@ -98,6 +103,7 @@ func (r *ResultModel) addResult(playerName, gender string, score float32, destVa
 	result.SetName(playerName)
 	result.SetGender(gender)
 	result.SetDestValue(destValue)
+	result.SetRaceId(raceId)
 	r.SetResults(append(r.Results(), result))
 
 	r.EndInsertRows()
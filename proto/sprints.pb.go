@@ -11,12 +11,30 @@ It has these top-level messages:
 	Empty
 	AbortMessage
 	Race
+	Ghost
+	Team
+	Leg
+	Elimination
+	Swap
+	Handicap
 	DefinedRace
 	Results
 	Result
+	Split
+	TracePoint
+	ResultEdit
+	AuditEntry
+	Record
+	RecordSpec
 	Tournaments
 	TournamentNames
 	TournamentSpec
+	ExportSpec
+	PrintSpec
+	ImportSpec
+	ImportReport
+	ExportedFile
+	TournamentRename
 	DefinedPlayer
 	ResultSpec
 	Player
@@ -24,6 +42,14 @@ It has these top-level messages:
 	Racer
 	Tournament
 	VisConfiguration
+	VisualRegistration
+	Announcement
+	VisualSpec
+	VisualInfo
+	Visuals
+	PairingRequest
+	Pairing
+	PairingCode
 */
 package pb
 
@@ -71,27 +97,89 @@ func (x Gender) String() string {
 }
 func (Gender) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
 
+// roles of the control clients; every role is allowed to do what the lower
+// ones do
+type Role int32
+
+const (
+	// results, records and watching
+	Role_VIEWER Role = 0
+	// races
+	Role_STARTER Role = 1
+	// tournaments, corrections and configuration
+	Role_ADMIN Role = 2
+)
+
+var Role_name = map[int32]string{
+	0: "VIEWER",
+	1: "STARTER",
+	2: "ADMIN",
+}
+var Role_value = map[string]int32{
+	"VIEWER":  0,
+	"STARTER": 1,
+	"ADMIN":   2,
+}
+
+func (x Role) String() string {
+	return proto.EnumName(Role_name, int32(x))
+}
+func (Role) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+type Ghost_Source int32
+
+const (
+	Ghost_PERSONAL_BEST Ghost_Source = 0
+	Ghost_LEADER        Ghost_Source = 1
+	Ghost_RECORD        Ghost_Source = 2
+)
+
+var Ghost_Source_name = map[int32]string{
+	0: "PERSONAL_BEST",
+	1: "LEADER",
+	2: "RECORD",
+}
+var Ghost_Source_value = map[string]int32{
+	"PERSONAL_BEST": 0,
+	"LEADER":        1,
+	"RECORD":        2,
+}
+
+func (x Ghost_Source) String() string {
+	return proto.EnumName(Ghost_Source_name, int32(x))
+}
+func (Ghost_Source) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{3, 0} }
+
 type Tournament_TournamentMode int32
 
 const (
-	Tournament_DISTANCE Tournament_TournamentMode = 0
-	Tournament_TIME     Tournament_TournamentMode = 1
+	Tournament_DISTANCE    Tournament_TournamentMode = 0
+	Tournament_TIME        Tournament_TournamentMode = 1
+	Tournament_RELAY       Tournament_TournamentMode = 2
+	Tournament_ELIMINATION Tournament_TournamentMode = 3
+	Tournament_PURSUIT     Tournament_TournamentMode = 4
 )
 
 var Tournament_TournamentMode_name = map[int32]string{
 	0: "DISTANCE",
 	1: "TIME",
+	2: "RELAY",
+	3: "ELIMINATION",
+	4: "PURSUIT",
 }
 var Tournament_TournamentMode_value = map[string]int32{
-	"DISTANCE": 0,
-	"TIME":     1,
+	"DISTANCE":    0,
+	"TIME":        1,
+	"RELAY":       2,
+	"ELIMINATION": 3,
+	"PURSUIT":     4,
 }
 
 func (x Tournament_TournamentMode) String() string {
 	return proto.EnumName(Tournament_TournamentMode_name, int32(x))
 }
 func (Tournament_TournamentMode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{14, 0}
+	return fileDescriptor0, []int{32, 0}
 }
 
 type Empty struct {
@@ -119,8 +207,18 @@ func (m *AbortMessage) GetMessage() string {
 }
 
 type Race struct {
-	Players   []*Player `protobuf:"bytes,1,rep,name=players" json:"players,omitempty"`
-	DestValue uint32    `protobuf:"varint,2,opt,name=destValue" json:"destValue,omitempty"`
+	Players []*Player `protobuf:"bytes,1,rep,name=players" json:"players,omitempty"`
+	// distance in device units or time in seconds to race for; interval in
+	// seconds between eliminations in elimination races
+	DestValue uint32 `protobuf:"varint,2,opt,name=destValue" json:"destValue,omitempty"`
+	// handicap of every player in the order of players; no handicaps if empty
+	Handicap []*Handicap `protobuf:"bytes,3,rep,name=handicap" json:"handicap,omitempty"`
+	// derive handicaps from personal bests of the players
+	HandicapFromPersonalBests bool `protobuf:"varint,4,opt,name=handicapFromPersonalBests" json:"handicapFromPersonalBests,omitempty"`
+	// relay: team of every lane; players are filled with teams then
+	Teams []*Team `protobuf:"bytes,5,rep,name=teams" json:"teams,omitempty"`
+	// lanes driven by recorded performances; they follow the players' lanes
+	Ghosts []*Ghost `protobuf:"bytes,6,rep,name=ghosts" json:"ghosts,omitempty"`
 }
 
 func (m *Race) Reset()                    { *m = Race{} }
@@ -142,738 +240,2717 @@ func (m *Race) GetDestValue() uint32 {
 	return 0
 }
 
-type DefinedRace struct {
-	// how many races are remaining before this one will take place
-	RacesRemaining uint32           `protobuf:"varint,1,opt,name=racesRemaining" json:"racesRemaining,omitempty"`
-	Player         []*DefinedPlayer `protobuf:"bytes,2,rep,name=player" json:"player,omitempty"`
+func (m *Race) GetHandicap() []*Handicap {
+	if m != nil {
+		return m.Handicap
+	}
+	return nil
 }
 
-func (m *DefinedRace) Reset()                    { *m = DefinedRace{} }
-func (m *DefinedRace) String() string            { return proto.CompactTextString(m) }
-func (*DefinedRace) ProtoMessage()               {}
-func (*DefinedRace) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
-
-func (m *DefinedRace) GetRacesRemaining() uint32 {
+func (m *Race) GetHandicapFromPersonalBests() bool {
 	if m != nil {
-		return m.RacesRemaining
+		return m.HandicapFromPersonalBests
 	}
-	return 0
+	return false
 }
 
-func (m *DefinedRace) GetPlayer() []*DefinedPlayer {
+func (m *Race) GetTeams() []*Team {
 	if m != nil {
-		return m.Player
+		return m.Teams
 	}
 	return nil
 }
 
-type Results struct {
-	Result []*Result `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
-}
-
-func (m *Results) Reset()                    { *m = Results{} }
-func (m *Results) String() string            { return proto.CompactTextString(m) }
-func (*Results) ProtoMessage()               {}
-func (*Results) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
-
-func (m *Results) GetResult() []*Result {
+func (m *Race) GetGhosts() []*Ghost {
 	if m != nil {
-		return m.Result
+		return m.Ghosts
 	}
 	return nil
 }
 
-type Result struct {
-	Player    *Player `protobuf:"bytes,1,opt,name=player" json:"player,omitempty"`
-	Result    float32 `protobuf:"fixed32,2,opt,name=result" json:"result,omitempty"`
-	DestValue uint32  `protobuf:"varint,3,opt,name=destValue" json:"destValue,omitempty"`
+type Ghost struct {
+	Source Ghost_Source `protobuf:"varint,1,opt,name=source,enum=pb.Ghost_Source" json:"source,omitempty"`
+	// personal best: whose performance to race; first player if empty
+	PlayerName string `protobuf:"bytes,2,opt,name=playerName" json:"playerName,omitempty"`
 }
 
-func (m *Result) Reset()                    { *m = Result{} }
-func (m *Result) String() string            { return proto.CompactTextString(m) }
-func (*Result) ProtoMessage()               {}
-func (*Result) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
-
-func (m *Result) GetPlayer() *Player {
-	if m != nil {
-		return m.Player
-	}
-	return nil
-}
+func (m *Ghost) Reset()                    { *m = Ghost{} }
+func (m *Ghost) String() string            { return proto.CompactTextString(m) }
+func (*Ghost) ProtoMessage()               {}
+func (*Ghost) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
 
-func (m *Result) GetResult() float32 {
+func (m *Ghost) GetSource() Ghost_Source {
 	if m != nil {
-		return m.Result
+		return m.Source
 	}
-	return 0
+	return Ghost_PERSONAL_BEST
 }
 
-func (m *Result) GetDestValue() uint32 {
+func (m *Ghost) GetPlayerName() string {
 	if m != nil {
-		return m.DestValue
+		return m.PlayerName
 	}
-	return 0
+	return ""
 }
 
-type Tournaments struct {
-	Tournament []*Tournament `protobuf:"bytes,1,rep,name=tournament" json:"tournament,omitempty"`
+type Team struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// in the order they ride their legs
+	Riders []*Player `protobuf:"bytes,2,rep,name=riders" json:"riders,omitempty"`
 }
 
-func (m *Tournaments) Reset()                    { *m = Tournaments{} }
-func (m *Tournaments) String() string            { return proto.CompactTextString(m) }
-func (*Tournaments) ProtoMessage()               {}
-func (*Tournaments) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (m *Team) Reset()                    { *m = Team{} }
+func (m *Team) String() string            { return proto.CompactTextString(m) }
+func (*Team) ProtoMessage()               {}
+func (*Team) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
-func (m *Tournaments) GetTournament() []*Tournament {
+func (m *Team) GetName() string {
 	if m != nil {
-		return m.Tournament
+		return m.Name
 	}
-	return nil
-}
-
-type TournamentNames struct {
-	Name []string `protobuf:"bytes,1,rep,name=name" json:"name,omitempty"`
+	return ""
 }
 
-func (m *TournamentNames) Reset()                    { *m = TournamentNames{} }
-func (m *TournamentNames) String() string            { return proto.CompactTextString(m) }
-func (*TournamentNames) ProtoMessage()               {}
-func (*TournamentNames) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
-
-func (m *TournamentNames) GetName() []string {
+func (m *Team) GetRiders() []*Player {
 	if m != nil {
-		return m.Name
+		return m.Riders
 	}
 	return nil
 }
 
-type TournamentSpec struct {
-	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+type Leg struct {
+	Rider *Player `protobuf:"bytes,1,opt,name=rider" json:"rider,omitempty"`
+	// length of the leg in device units
+	Distance uint32 `protobuf:"varint,2,opt,name=distance" json:"distance,omitempty"`
+	// miliseconds since the start when the leg was finished
+	Time uint32 `protobuf:"varint,3,opt,name=time" json:"time,omitempty"`
+	// miliseconds the leg took
+	LegTime float32 `protobuf:"fixed32,4,opt,name=legTime" json:"legTime,omitempty"`
 }
 
-func (m *TournamentSpec) Reset()                    { *m = TournamentSpec{} }
-func (m *TournamentSpec) String() string            { return proto.CompactTextString(m) }
-func (*TournamentSpec) ProtoMessage()               {}
-func (*TournamentSpec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (m *Leg) Reset()                    { *m = Leg{} }
+func (m *Leg) String() string            { return proto.CompactTextString(m) }
+func (*Leg) ProtoMessage()               {}
+func (*Leg) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
-func (m *TournamentSpec) GetName() string {
+func (m *Leg) GetRider() *Player {
 	if m != nil {
-		return m.Name
+		return m.Rider
 	}
-	return ""
-}
-
-type DefinedPlayer struct {
-	Color           string           `protobuf:"bytes,1,opt,name=color" json:"color,omitempty"`
-	RacesRemaining  uint32           `protobuf:"varint,2,opt,name=racesRemaining" json:"racesRemaining,omitempty"`
-	OtherContenders []*DefinedPlayer `protobuf:"bytes,3,rep,name=otherContenders" json:"otherContenders,omitempty"`
+	return nil
 }
 
-func (m *DefinedPlayer) Reset()                    { *m = DefinedPlayer{} }
-func (m *DefinedPlayer) String() string            { return proto.CompactTextString(m) }
-func (*DefinedPlayer) ProtoMessage()               {}
-func (*DefinedPlayer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
-
-func (m *DefinedPlayer) GetColor() string {
+func (m *Leg) GetDistance() uint32 {
 	if m != nil {
-		return m.Color
+		return m.Distance
 	}
-	return ""
+	return 0
 }
 
-func (m *DefinedPlayer) GetRacesRemaining() uint32 {
+func (m *Leg) GetTime() uint32 {
 	if m != nil {
-		return m.RacesRemaining
+		return m.Time
 	}
 	return 0
 }
 
-func (m *DefinedPlayer) GetOtherContenders() []*DefinedPlayer {
+func (m *Leg) GetLegTime() float32 {
 	if m != nil {
-		return m.OtherContenders
+		return m.LegTime
 	}
-	return nil
+	return 0
 }
 
-type ResultSpec struct {
-	Gender         Gender `protobuf:"varint,1,opt,name=gender,enum=pb.Gender" json:"gender,omitempty"`
-	Last           uint32 `protobuf:"varint,2,opt,name=last" json:"last,omitempty"`
-	TournamentName string `protobuf:"bytes,3,opt,name=tournamentName" json:"tournamentName,omitempty"`
+type Elimination struct {
+	PlayerNum uint32  `protobuf:"varint,1,opt,name=playerNum" json:"playerNum,omitempty"`
+	Player    *Player `protobuf:"bytes,2,opt,name=player" json:"player,omitempty"`
+	// place the player has finished at
+	Place uint32 `protobuf:"varint,3,opt,name=place" json:"place,omitempty"`
+	// miliseconds since the start
+	Time uint32 `protobuf:"varint,4,opt,name=time" json:"time,omitempty"`
+	// pursuit: the player has been caught rather than knocked out
+	Caught bool `protobuf:"varint,5,opt,name=caught" json:"caught,omitempty"`
 }
 
-func (m *ResultSpec) Reset()                    { *m = ResultSpec{} }
-func (m *ResultSpec) String() string            { return proto.CompactTextString(m) }
-func (*ResultSpec) ProtoMessage()               {}
-func (*ResultSpec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (m *Elimination) Reset()                    { *m = Elimination{} }
+func (m *Elimination) String() string            { return proto.CompactTextString(m) }
+func (*Elimination) ProtoMessage()               {}
+func (*Elimination) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
-func (m *ResultSpec) GetGender() Gender {
+func (m *Elimination) GetPlayerNum() uint32 {
 	if m != nil {
-		return m.Gender
+		return m.PlayerNum
 	}
-	return Gender_MALE
+	return 0
 }
 
-func (m *ResultSpec) GetLast() uint32 {
+func (m *Elimination) GetPlayer() *Player {
 	if m != nil {
-		return m.Last
+		return m.Player
 	}
-	return 0
+	return nil
 }
 
-func (m *ResultSpec) GetTournamentName() string {
+func (m *Elimination) GetPlace() uint32 {
 	if m != nil {
-		return m.TournamentName
+		return m.Place
 	}
-	return ""
-}
-
-type Player struct {
-	Name   string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
-	Gender Gender `protobuf:"varint,2,opt,name=gender,enum=pb.Gender" json:"gender,omitempty"`
+	return 0
 }
 
-func (m *Player) Reset()                    { *m = Player{} }
-func (m *Player) String() string            { return proto.CompactTextString(m) }
-func (*Player) ProtoMessage()               {}
-func (*Player) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
-
-func (m *Player) GetName() string {
+func (m *Elimination) GetTime() uint32 {
 	if m != nil {
-		return m.Name
+		return m.Time
 	}
-	return ""
+	return 0
 }
 
-func (m *Player) GetGender() Gender {
+func (m *Elimination) GetCaught() bool {
 	if m != nil {
-		return m.Gender
+		return m.Caught
 	}
-	return Gender_MALE
+	return false
 }
 
-type Starter struct {
-	CountdownTime uint32 `protobuf:"varint,1,opt,name=countdownTime" json:"countdownTime,omitempty"`
+type Swap struct {
+	PlayerNum uint32 `protobuf:"varint,1,opt,name=playerNum" json:"playerNum,omitempty"`
+	// number of the leg which has just started
+	Leg   uint32  `protobuf:"varint,2,opt,name=leg" json:"leg,omitempty"`
+	Rider *Player `protobuf:"bytes,3,opt,name=rider" json:"rider,omitempty"`
+	// distance in device units where the swap took place
+	Distance uint32 `protobuf:"varint,4,opt,name=distance" json:"distance,omitempty"`
 }
 
-func (m *Starter) Reset()                    { *m = Starter{} }
-func (m *Starter) String() string            { return proto.CompactTextString(m) }
-func (*Starter) ProtoMessage()               {}
-func (*Starter) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (m *Swap) Reset()                    { *m = Swap{} }
+func (m *Swap) String() string            { return proto.CompactTextString(m) }
+func (*Swap) ProtoMessage()               {}
+func (*Swap) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
-func (m *Starter) GetCountdownTime() uint32 {
+func (m *Swap) GetPlayerNum() uint32 {
 	if m != nil {
-		return m.CountdownTime
+		return m.PlayerNum
 	}
 	return 0
 }
 
-type Racer struct {
-	PlayerNum uint32 `protobuf:"varint,1,opt,name=playerNum" json:"playerNum,omitempty"`
-	Distance  uint32 `protobuf:"varint,2,opt,name=distance" json:"distance,omitempty"`
+func (m *Swap) GetLeg() uint32 {
+	if m != nil {
+		return m.Leg
+	}
+	return 0
 }
 
-func (m *Racer) Reset()                    { *m = Racer{} }
-func (m *Racer) String() string            { return proto.CompactTextString(m) }
-func (*Racer) ProtoMessage()               {}
-func (*Racer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
-
-func (m *Racer) GetPlayerNum() uint32 {
+func (m *Swap) GetRider() *Player {
 	if m != nil {
-		return m.PlayerNum
+		return m.Rider
 	}
-	return 0
+	return nil
 }
 
-func (m *Racer) GetDistance() uint32 {
+func (m *Swap) GetDistance() uint32 {
 	if m != nil {
 		return m.Distance
 	}
 	return 0
 }
 
-type Tournament struct {
-	Name        string                    `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
-	DestValue   uint32                    `protobuf:"varint,3,opt,name=destValue" json:"destValue,omitempty"`
-	Mode        Tournament_TournamentMode `protobuf:"varint,4,opt,name=mode,enum=pb.Tournament_TournamentMode" json:"mode,omitempty"`
-	PlayerCount uint32                    `protobuf:"varint,5,opt,name=playerCount" json:"playerCount,omitempty"`
-	Color       []string                  `protobuf:"bytes,6,rep,name=color" json:"color,omitempty"`
-	Result      []*Result                 `protobuf:"bytes,7,rep,name=result" json:"result,omitempty"`
+type Handicap struct {
+	// miliseconds after the common start when the player's distance starts
+	// to count
+	StartDelay uint32 `protobuf:"varint,1,opt,name=startDelay" json:"startDelay,omitempty"`
+	// distance in device units the player gets ahead of the others
+	DistanceBonus uint32 `protobuf:"varint,2,opt,name=distanceBonus" json:"distanceBonus,omitempty"`
 }
 
-func (m *Tournament) Reset()                    { *m = Tournament{} }
-func (m *Tournament) String() string            { return proto.CompactTextString(m) }
-func (*Tournament) ProtoMessage()               {}
-func (*Tournament) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (m *Handicap) Reset()                    { *m = Handicap{} }
+func (m *Handicap) String() string            { return proto.CompactTextString(m) }
+func (*Handicap) ProtoMessage()               {}
+func (*Handicap) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
-func (m *Tournament) GetName() string {
+func (m *Handicap) GetStartDelay() uint32 {
 	if m != nil {
-		return m.Name
+		return m.StartDelay
 	}
-	return ""
+	return 0
 }
 
-func (m *Tournament) GetDestValue() uint32 {
+func (m *Handicap) GetDistanceBonus() uint32 {
 	if m != nil {
-		return m.DestValue
+		return m.DistanceBonus
 	}
 	return 0
 }
 
-func (m *Tournament) GetMode() Tournament_TournamentMode {
-	if m != nil {
-		return m.Mode
-	}
-	return Tournament_DISTANCE
+type DefinedRace struct {
+	// how many races are remaining before this one will take place
+	RacesRemaining uint32           `protobuf:"varint,1,opt,name=racesRemaining" json:"racesRemaining,omitempty"`
+	Player         []*DefinedPlayer `protobuf:"bytes,2,rep,name=player" json:"player,omitempty"`
 }
 
-func (m *Tournament) GetPlayerCount() uint32 {
+func (m *DefinedRace) Reset()                    { *m = DefinedRace{} }
+func (m *DefinedRace) String() string            { return proto.CompactTextString(m) }
+func (*DefinedRace) ProtoMessage()               {}
+func (*DefinedRace) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *DefinedRace) GetRacesRemaining() uint32 {
 	if m != nil {
-		return m.PlayerCount
+		return m.RacesRemaining
 	}
 	return 0
 }
 
-func (m *Tournament) GetColor() []string {
+func (m *DefinedRace) GetPlayer() []*DefinedPlayer {
 	if m != nil {
-		return m.Color
+		return m.Player
 	}
 	return nil
 }
 
-func (m *Tournament) GetResult() []*Result {
+type Results struct {
+	Result []*Result `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *Results) Reset()                    { *m = Results{} }
+func (m *Results) String() string            { return proto.CompactTextString(m) }
+func (*Results) ProtoMessage()               {}
+func (*Results) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *Results) GetResult() []*Result {
 	if m != nil {
 		return m.Result
 	}
 	return nil
 }
 
-type VisConfiguration struct {
-	HostName         string `protobuf:"bytes,1,opt,name=hostName" json:"hostName,omitempty"`
-	VisName          string `protobuf:"bytes,2,opt,name=visName" json:"visName,omitempty"`
-	Fullscreen       bool   `protobuf:"varint,3,opt,name=fullscreen" json:"fullscreen,omitempty"`
-	ResolutionWidth  uint32 `protobuf:"varint,4,opt,name=resolutionWidth" json:"resolutionWidth,omitempty"`
-	ResolutionHeight uint32 `protobuf:"varint,5,opt,name=resolutionHeight" json:"resolutionHeight,omitempty"`
-	MovingUnit       uint32 `protobuf:"varint,6,opt,name=movingUnit" json:"movingUnit,omitempty"`
-	DistFactor       uint32 `protobuf:"varint,7,opt,name=distFactor" json:"distFactor,omitempty"`
+type Result struct {
+	Player    *Player `protobuf:"bytes,1,opt,name=player" json:"player,omitempty"`
+	Result    float32 `protobuf:"fixed32,2,opt,name=result" json:"result,omitempty"`
+	DestValue uint32  `protobuf:"varint,3,opt,name=destValue" json:"destValue,omitempty"`
+	// set when the result improved player's personal best
+	PersonalBest bool `protobuf:"varint,4,opt,name=personalBest" json:"personalBest,omitempty"`
+	// set when the result beat the all-time record, either the overall one
+	// or the one of player's category
+	Record bool `protobuf:"varint,5,opt,name=record" json:"record,omitempty"`
+	// unique within the tournament
+	Id                     uint32 `protobuf:"varint,6,opt,name=id" json:"id,omitempty"`
+	RaceId                 uint32 `protobuf:"varint,7,opt,name=raceId" json:"raceId,omitempty"`
+	Disqualified           bool   `protobuf:"varint,8,opt,name=disqualified" json:"disqualified,omitempty"`
+	DisqualificationReason string `protobuf:"bytes,9,opt,name=disqualificationReason" json:"disqualificationReason,omitempty"`
+	// unix time in miliseconds when the race started (after the countdown)
+	StartTimestamp int64 `protobuf:"varint,10,opt,name=startTimestamp" json:"startTimestamp,omitempty"`
+	// number of the player within the race which is also the device input
+	Lane      uint32    `protobuf:"varint,11,opt,name=lane" json:"lane,omitempty"`
+	Color     string    `protobuf:"bytes,12,opt,name=color" json:"color,omitempty"`
+	Opponents []*Player `protobuf:"bytes,13,rep,name=opponents" json:"opponents,omitempty"`
+	// countdown in miliseconds used before the start
+	CountdownTime uint32 `protobuf:"varint,14,opt,name=countdownTime" json:"countdownTime,omitempty"`
+	// how many times the player false-started the race
+	FalseStarts uint32   `protobuf:"varint,15,opt,name=falseStarts" json:"falseStarts,omitempty"`
+	DeviceType  string   `protobuf:"bytes,16,opt,name=deviceType" json:"deviceType,omitempty"`
+	Split       []*Split `protobuf:"bytes,17,rep,name=split" json:"split,omitempty"`
+	// downsampled course of the race
+	Trace []*TracePoint `protobuf:"bytes,18,rep,name=trace" json:"trace,omitempty"`
+	// time constrained races: miliseconds since the start when the last
+	// revolution of the result was registered; breaks ties
+	ReachedAt uint32 `protobuf:"varint,19,opt,name=reachedAt" json:"reachedAt,omitempty"`
+	// result without handicap: time since the player's own start or distance
+	// without bonus
+	RawResult float32   `protobuf:"fixed32,20,opt,name=rawResult" json:"rawResult,omitempty"`
+	Handicap  *Handicap `protobuf:"bytes,21,opt,name=handicap" json:"handicap,omitempty"`
+	// relay: legs of the team
+	Legs []*Leg `protobuf:"bytes,22,rep,name=legs" json:"legs,omitempty"`
+	// place within the race
+	Place uint32 `protobuf:"varint,23,opt,name=place" json:"place,omitempty"`
+	// knocked out in elimination or caught in pursuit race
+	Eliminated bool `protobuf:"varint,24,opt,name=eliminated" json:"eliminated,omitempty"`
+	// result of a ghost lane; such results are never stored
+	Ghost bool `protobuf:"varint,25,opt,name=ghost" json:"ghost,omitempty"`
 }
 
-func (m *VisConfiguration) Reset()                    { *m = VisConfiguration{} }
-func (m *VisConfiguration) String() string            { return proto.CompactTextString(m) }
-func (*VisConfiguration) ProtoMessage()               {}
-func (*VisConfiguration) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (m *Result) Reset()                    { *m = Result{} }
+func (m *Result) String() string            { return proto.CompactTextString(m) }
+func (*Result) ProtoMessage()               {}
+func (*Result) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
-func (m *VisConfiguration) GetHostName() string {
+func (m *Result) GetPlayer() *Player {
 	if m != nil {
-		return m.HostName
+		return m.Player
 	}
-	return ""
+	return nil
 }
 
-func (m *VisConfiguration) GetVisName() string {
+func (m *Result) GetResult() float32 {
 	if m != nil {
-		return m.VisName
+		return m.Result
 	}
-	return ""
+	return 0
 }
 
-func (m *VisConfiguration) GetFullscreen() bool {
+func (m *Result) GetDestValue() uint32 {
 	if m != nil {
-		return m.Fullscreen
+		return m.DestValue
 	}
-	return false
+	return 0
 }
 
-func (m *VisConfiguration) GetResolutionWidth() uint32 {
+func (m *Result) GetPersonalBest() bool {
 	if m != nil {
-		return m.ResolutionWidth
+		return m.PersonalBest
 	}
-	return 0
+	return false
 }
 
-func (m *VisConfiguration) GetResolutionHeight() uint32 {
+func (m *Result) GetRecord() bool {
 	if m != nil {
-		return m.ResolutionHeight
+		return m.Record
+	}
+	return false
+}
+
+func (m *Result) GetId() uint32 {
+	if m != nil {
+		return m.Id
 	}
 	return 0
 }
 
-func (m *VisConfiguration) GetMovingUnit() uint32 {
+func (m *Result) GetRaceId() uint32 {
 	if m != nil {
-		return m.MovingUnit
+		return m.RaceId
 	}
 	return 0
 }
 
-func (m *VisConfiguration) GetDistFactor() uint32 {
+func (m *Result) GetDisqualified() bool {
 	if m != nil {
-		return m.DistFactor
+		return m.Disqualified
+	}
+	return false
+}
+
+func (m *Result) GetDisqualificationReason() string {
+	if m != nil {
+		return m.DisqualificationReason
+	}
+	return ""
+}
+
+func (m *Result) GetStartTimestamp() int64 {
+	if m != nil {
+		return m.StartTimestamp
 	}
 	return 0
 }
 
-func init() {
-	proto.RegisterType((*Empty)(nil), "pb.Empty")
-	proto.RegisterType((*AbortMessage)(nil), "pb.AbortMessage")
-	proto.RegisterType((*Race)(nil), "pb.Race")
-	proto.RegisterType((*DefinedRace)(nil), "pb.DefinedRace")
-	proto.RegisterType((*Results)(nil), "pb.Results")
-	proto.RegisterType((*Result)(nil), "pb.Result")
-	proto.RegisterType((*Tournaments)(nil), "pb.Tournaments")
-	proto.RegisterType((*TournamentNames)(nil), "pb.TournamentNames")
-	proto.RegisterType((*TournamentSpec)(nil), "pb.TournamentSpec")
-	proto.RegisterType((*DefinedPlayer)(nil), "pb.DefinedPlayer")
-	proto.RegisterType((*ResultSpec)(nil), "pb.ResultSpec")
-	proto.RegisterType((*Player)(nil), "pb.Player")
-	proto.RegisterType((*Starter)(nil), "pb.Starter")
-	proto.RegisterType((*Racer)(nil), "pb.Racer")
-	proto.RegisterType((*Tournament)(nil), "pb.Tournament")
-	proto.RegisterType((*VisConfiguration)(nil), "pb.VisConfiguration")
-	proto.RegisterEnum("pb.Gender", Gender_name, Gender_value)
-	proto.RegisterEnum("pb.Tournament_TournamentMode", Tournament_TournamentMode_name, Tournament_TournamentMode_value)
+func (m *Result) GetLane() uint32 {
+	if m != nil {
+		return m.Lane
+	}
+	return 0
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *Result) GetColor() string {
+	if m != nil {
+		return m.Color
+	}
+	return ""
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *Result) GetOpponents() []*Player {
+	if m != nil {
+		return m.Opponents
+	}
+	return nil
+}
 
-// Client API for Sprints service
+func (m *Result) GetCountdownTime() uint32 {
+	if m != nil {
+		return m.CountdownTime
+	}
+	return 0
+}
 
-type SprintsClient interface {
-	NewTournament(ctx context.Context, in *Tournament, opts ...grpc.CallOption) (*Tournament, error)
-	NewRace(ctx context.Context, in *Race, opts ...grpc.CallOption) (*Empty, error)
-	StartRace(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Player, error)
-	AbortRace(ctx context.Context, in *AbortMessage, opts ...grpc.CallOption) (*Empty, error)
-	ConfigureVis(ctx context.Context, in *VisConfiguration, opts ...grpc.CallOption) (*Empty, error)
-	GetResults(ctx context.Context, in *ResultSpec, opts ...grpc.CallOption) (Sprints_GetResultsClient, error)
-	GetTournamentNames(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TournamentNames, error)
-	GetCurrentTournament(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Tournament, error)
-	LoadTournament(ctx context.Context, in *TournamentSpec, opts ...grpc.CallOption) (*Tournament, error)
-	ShowResults(ctx context.Context, in *ResultSpec, opts ...grpc.CallOption) (*Empty, error)
+func (m *Result) GetFalseStarts() uint32 {
+	if m != nil {
+		return m.FalseStarts
+	}
+	return 0
 }
 
-type sprintsClient struct {
-	cc *grpc.ClientConn
+func (m *Result) GetDeviceType() string {
+	if m != nil {
+		return m.DeviceType
+	}
+	return ""
 }
 
-func NewSprintsClient(cc *grpc.ClientConn) SprintsClient {
-	return &sprintsClient{cc}
+func (m *Result) GetSplit() []*Split {
+	if m != nil {
+		return m.Split
+	}
+	return nil
 }
 
-func (c *sprintsClient) NewTournament(ctx context.Context, in *Tournament, opts ...grpc.CallOption) (*Tournament, error) {
-	out := new(Tournament)
-	err := grpc.Invoke(ctx, "/pb.Sprints/NewTournament", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *Result) GetTrace() []*TracePoint {
+	if m != nil {
+		return m.Trace
 	}
-	return out, nil
+	return nil
 }
 
-func (c *sprintsClient) NewRace(ctx context.Context, in *Race, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := grpc.Invoke(ctx, "/pb.Sprints/NewRace", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *Result) GetReachedAt() uint32 {
+	if m != nil {
+		return m.ReachedAt
 	}
-	return out, nil
+	return 0
 }
 
-func (c *sprintsClient) StartRace(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Player, error) {
-	out := new(Player)
-	err := grpc.Invoke(ctx, "/pb.Sprints/StartRace", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *Result) GetRawResult() float32 {
+	if m != nil {
+		return m.RawResult
 	}
-	return out, nil
+	return 0
 }
 
-func (c *sprintsClient) AbortRace(ctx context.Context, in *AbortMessage, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := grpc.Invoke(ctx, "/pb.Sprints/AbortRace", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *Result) GetHandicap() *Handicap {
+	if m != nil {
+		return m.Handicap
 	}
-	return out, nil
+	return nil
 }
 
-func (c *sprintsClient) ConfigureVis(ctx context.Context, in *VisConfiguration, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := grpc.Invoke(ctx, "/pb.Sprints/ConfigureVis", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *Result) GetLegs() []*Leg {
+	if m != nil {
+		return m.Legs
 	}
-	return out, nil
+	return nil
 }
 
-func (c *sprintsClient) GetResults(ctx context.Context, in *ResultSpec, opts ...grpc.CallOption) (Sprints_GetResultsClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Sprints_serviceDesc.Streams[0], c.cc, "/pb.Sprints/GetResults", opts...)
-	if err != nil {
-		return nil, err
+func (m *Result) GetPlace() uint32 {
+	if m != nil {
+		return m.Place
 	}
-	x := &sprintsGetResultsClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	return 0
+}
+
+func (m *Result) GetEliminated() bool {
+	if m != nil {
+		return m.Eliminated
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	return false
+}
+
+func (m *Result) GetGhost() bool {
+	if m != nil {
+		return m.Ghost
 	}
-	return x, nil
+	return false
 }
 
-type Sprints_GetResultsClient interface {
-	Recv() (*Result, error)
-	grpc.ClientStream
+type Split struct {
+	// distance in device units
+	Distance uint32 `protobuf:"varint,1,opt,name=distance" json:"distance,omitempty"`
+	// miliseconds since the start
+	Time uint32 `protobuf:"varint,2,opt,name=time" json:"time,omitempty"`
 }
 
-type sprintsGetResultsClient struct {
-	grpc.ClientStream
+func (m *Split) Reset()                    { *m = Split{} }
+func (m *Split) String() string            { return proto.CompactTextString(m) }
+func (*Split) ProtoMessage()               {}
+func (*Split) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *Split) GetDistance() uint32 {
+	if m != nil {
+		return m.Distance
+	}
+	return 0
 }
 
-func (x *sprintsGetResultsClient) Recv() (*Result, error) {
-	m := new(Result)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *Split) GetTime() uint32 {
+	if m != nil {
+		return m.Time
 	}
-	return m, nil
+	return 0
 }
 
-func (c *sprintsClient) GetTournamentNames(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TournamentNames, error) {
-	out := new(TournamentNames)
-	err := grpc.Invoke(ctx, "/pb.Sprints/GetTournamentNames", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type TracePoint struct {
+	// miliseconds since the start
+	Time uint32 `protobuf:"varint,1,opt,name=time" json:"time,omitempty"`
+	// distance in device units
+	Distance uint32 `protobuf:"varint,2,opt,name=distance" json:"distance,omitempty"`
+	// km/h since the previous point
+	Speed float32 `protobuf:"fixed32,3,opt,name=speed" json:"speed,omitempty"`
+}
+
+func (m *TracePoint) Reset()                    { *m = TracePoint{} }
+func (m *TracePoint) String() string            { return proto.CompactTextString(m) }
+func (*TracePoint) ProtoMessage()               {}
+func (*TracePoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *TracePoint) GetTime() uint32 {
+	if m != nil {
+		return m.Time
 	}
-	return out, nil
+	return 0
 }
 
-func (c *sprintsClient) GetCurrentTournament(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Tournament, error) {
-	out := new(Tournament)
-	err := grpc.Invoke(ctx, "/pb.Sprints/GetCurrentTournament", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *TracePoint) GetDistance() uint32 {
+	if m != nil {
+		return m.Distance
 	}
-	return out, nil
+	return 0
 }
 
-func (c *sprintsClient) LoadTournament(ctx context.Context, in *TournamentSpec, opts ...grpc.CallOption) (*Tournament, error) {
-	out := new(Tournament)
-	err := grpc.Invoke(ctx, "/pb.Sprints/LoadTournament", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *TracePoint) GetSpeed() float32 {
+	if m != nil {
+		return m.Speed
 	}
-	return out, nil
+	return 0
 }
 
-func (c *sprintsClient) ShowResults(ctx context.Context, in *ResultSpec, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := grpc.Invoke(ctx, "/pb.Sprints/ShowResults", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type ResultEdit struct {
+	// tournament the result belongs to; current one if empty
+	TournamentName string `protobuf:"bytes,1,opt,name=tournamentName" json:"tournamentName,omitempty"`
+	ResultId       uint32 `protobuf:"varint,2,opt,name=resultId" json:"resultId,omitempty"`
+	// replaces player of the result on EditResult
+	Player *Player `protobuf:"bytes,3,opt,name=player" json:"player,omitempty"`
+	Reason string  `protobuf:"bytes,4,opt,name=reason" json:"reason,omitempty"`
+}
+
+func (m *ResultEdit) Reset()                    { *m = ResultEdit{} }
+func (m *ResultEdit) String() string            { return proto.CompactTextString(m) }
+func (*ResultEdit) ProtoMessage()               {}
+func (*ResultEdit) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *ResultEdit) GetTournamentName() string {
+	if m != nil {
+		return m.TournamentName
+	}
+	return ""
+}
+
+func (m *ResultEdit) GetResultId() uint32 {
+	if m != nil {
+		return m.ResultId
+	}
+	return 0
+}
+
+func (m *ResultEdit) GetPlayer() *Player {
+	if m != nil {
+		return m.Player
+	}
+	return nil
+}
+
+func (m *ResultEdit) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type AuditEntry struct {
+	// unix time of the change
+	Timestamp int64  `protobuf:"varint,1,opt,name=timestamp" json:"timestamp,omitempty"`
+	Action    string `protobuf:"bytes,2,opt,name=action" json:"action,omitempty"`
+	ResultId  uint32 `protobuf:"varint,3,opt,name=resultId" json:"resultId,omitempty"`
+	Details   string `protobuf:"bytes,4,opt,name=details" json:"details,omitempty"`
+}
+
+func (m *AuditEntry) Reset()                    { *m = AuditEntry{} }
+func (m *AuditEntry) String() string            { return proto.CompactTextString(m) }
+func (*AuditEntry) ProtoMessage()               {}
+func (*AuditEntry) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *AuditEntry) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *AuditEntry) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *AuditEntry) GetResultId() uint32 {
+	if m != nil {
+		return m.ResultId
+	}
+	return 0
+}
+
+func (m *AuditEntry) GetDetails() string {
+	if m != nil {
+		return m.Details
+	}
+	return ""
+}
+
+type Record struct {
+	Result         *Result                   `protobuf:"bytes,1,opt,name=result" json:"result,omitempty"`
+	Mode           Tournament_TournamentMode `protobuf:"varint,2,opt,name=mode,enum=pb.Tournament_TournamentMode" json:"mode,omitempty"`
+	TournamentName string                    `protobuf:"bytes,3,opt,name=tournamentName" json:"tournamentName,omitempty"`
+	// player category the record is held in; empty for the overall record
+	Category string `protobuf:"bytes,4,opt,name=category" json:"category,omitempty"`
+}
+
+func (m *Record) Reset()                    { *m = Record{} }
+func (m *Record) String() string            { return proto.CompactTextString(m) }
+func (*Record) ProtoMessage()               {}
+func (*Record) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *Record) GetResult() *Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *Record) GetMode() Tournament_TournamentMode {
+	if m != nil {
+		return m.Mode
+	}
+	return Tournament_DISTANCE
+}
+
+func (m *Record) GetTournamentName() string {
+	if m != nil {
+		return m.TournamentName
+	}
+	return ""
+}
+
+func (m *Record) GetCategory() string {
+	if m != nil {
+		return m.Category
+	}
+	return ""
+}
+
+type RecordSpec struct {
+	Gender Gender `protobuf:"varint,1,opt,name=gender,enum=pb.Gender" json:"gender,omitempty"`
+	// records of the given player category; overall records if empty
+	Category string `protobuf:"bytes,2,opt,name=category" json:"category,omitempty"`
+}
+
+func (m *RecordSpec) Reset()                    { *m = RecordSpec{} }
+func (m *RecordSpec) String() string            { return proto.CompactTextString(m) }
+func (*RecordSpec) ProtoMessage()               {}
+func (*RecordSpec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *RecordSpec) GetGender() Gender {
+	if m != nil {
+		return m.Gender
+	}
+	return Gender_MALE
+}
+
+func (m *RecordSpec) GetCategory() string {
+	if m != nil {
+		return m.Category
+	}
+	return ""
+}
+
+type Tournaments struct {
+	Tournament []*Tournament `protobuf:"bytes,1,rep,name=tournament" json:"tournament,omitempty"`
+}
+
+func (m *Tournaments) Reset()                    { *m = Tournaments{} }
+func (m *Tournaments) String() string            { return proto.CompactTextString(m) }
+func (*Tournaments) ProtoMessage()               {}
+func (*Tournaments) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *Tournaments) GetTournament() []*Tournament {
+	if m != nil {
+		return m.Tournament
+	}
+	return nil
+}
+
+type TournamentNames struct {
+	Name     []string `protobuf:"bytes,1,rep,name=name" json:"name,omitempty"`
+	Archived []string `protobuf:"bytes,2,rep,name=archived" json:"archived,omitempty"`
+}
+
+func (m *TournamentNames) Reset()                    { *m = TournamentNames{} }
+func (m *TournamentNames) String() string            { return proto.CompactTextString(m) }
+func (*TournamentNames) ProtoMessage()               {}
+func (*TournamentNames) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *TournamentNames) GetName() []string {
+	if m != nil {
+		return m.Name
+	}
+	return nil
+}
+
+func (m *TournamentNames) GetArchived() []string {
+	if m != nil {
+		return m.Archived
+	}
+	return nil
+}
+
+type TournamentSpec struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// used by ArchiveTournament: false restores archived tournament
+	Archived bool `protobuf:"varint,2,opt,name=archived" json:"archived,omitempty"`
+}
+
+func (m *TournamentSpec) Reset()                    { *m = TournamentSpec{} }
+func (m *TournamentSpec) String() string            { return proto.CompactTextString(m) }
+func (*TournamentSpec) ProtoMessage()               {}
+func (*TournamentSpec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *TournamentSpec) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TournamentSpec) GetArchived() bool {
+	if m != nil {
+		return m.Archived
+	}
+	return false
+}
+
+type ExportSpec struct {
+	// current tournament if empty
+	TournamentName string `protobuf:"bytes,1,opt,name=tournamentName" json:"tournamentName,omitempty"`
+	// csv, json or xlsx
+	Format string `protobuf:"bytes,2,opt,name=format" json:"format,omitempty"`
+}
+
+func (m *ExportSpec) Reset()                    { *m = ExportSpec{} }
+func (m *ExportSpec) String() string            { return proto.CompactTextString(m) }
+func (*ExportSpec) ProtoMessage()               {}
+func (*ExportSpec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *ExportSpec) GetTournamentName() string {
+	if m != nil {
+		return m.TournamentName
+	}
+	return ""
+}
+
+func (m *ExportSpec) GetFormat() string {
+	if m != nil {
+		return m.Format
+	}
+	return ""
+}
+
+type PrintSpec struct {
+	// current tournament if empty
+	TournamentName string `protobuf:"bytes,1,opt,name=tournamentName" json:"tournamentName,omitempty"`
+	// event title printed on top of every page; tournament name if empty
+	Title string `protobuf:"bytes,2,opt,name=title" json:"title,omitempty"`
+	// event date; today if empty
+	Date string `protobuf:"bytes,3,opt,name=date" json:"date,omitempty"`
+	// print certificates instead of result sheet
+	Certificates bool `protobuf:"varint,4,opt,name=certificates" json:"certificates,omitempty"`
+	// print certificate of the given player only
+	PlayerName string `protobuf:"bytes,5,opt,name=playerName" json:"playerName,omitempty"`
+}
+
+func (m *PrintSpec) Reset()                    { *m = PrintSpec{} }
+func (m *PrintSpec) String() string            { return proto.CompactTextString(m) }
+func (*PrintSpec) ProtoMessage()               {}
+func (*PrintSpec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *PrintSpec) GetTournamentName() string {
+	if m != nil {
+		return m.TournamentName
+	}
+	return ""
+}
+
+func (m *PrintSpec) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *PrintSpec) GetDate() string {
+	if m != nil {
+		return m.Date
+	}
+	return ""
+}
+
+func (m *PrintSpec) GetCertificates() bool {
+	if m != nil {
+		return m.Certificates
+	}
+	return false
+}
+
+func (m *PrintSpec) GetPlayerName() string {
+	if m != nil {
+		return m.PlayerName
+	}
+	return ""
+}
+
+type ImportSpec struct {
+	// current tournament if empty
+	TournamentName string `protobuf:"bytes,1,opt,name=tournamentName" json:"tournamentName,omitempty"`
+	// csv or json
+	Format  string `protobuf:"bytes,2,opt,name=format" json:"format,omitempty"`
+	Content []byte `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	// only validate and report what would be imported
+	DryRun bool `protobuf:"varint,4,opt,name=dryRun" json:"dryRun,omitempty"`
+}
+
+func (m *ImportSpec) Reset()                    { *m = ImportSpec{} }
+func (m *ImportSpec) String() string            { return proto.CompactTextString(m) }
+func (*ImportSpec) ProtoMessage()               {}
+func (*ImportSpec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *ImportSpec) GetTournamentName() string {
+	if m != nil {
+		return m.TournamentName
+	}
+	return ""
+}
+
+func (m *ImportSpec) GetFormat() string {
+	if m != nil {
+		return m.Format
+	}
+	return ""
+}
+
+func (m *ImportSpec) GetContent() []byte {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (m *ImportSpec) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type ImportReport struct {
+	Imported uint32 `protobuf:"varint,1,opt,name=imported" json:"imported,omitempty"`
+	// entries skipped as they're already in the tournament or repeated
+	Duplicate []string `protobuf:"bytes,2,rep,name=duplicate" json:"duplicate,omitempty"`
+	// nothing gets imported when there are any errors
+	Error  []string `protobuf:"bytes,3,rep,name=error" json:"error,omitempty"`
+	DryRun bool     `protobuf:"varint,4,opt,name=dryRun" json:"dryRun,omitempty"`
+}
+
+func (m *ImportReport) Reset()                    { *m = ImportReport{} }
+func (m *ImportReport) String() string            { return proto.CompactTextString(m) }
+func (*ImportReport) ProtoMessage()               {}
+func (*ImportReport) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *ImportReport) GetImported() uint32 {
+	if m != nil {
+		return m.Imported
+	}
+	return 0
+}
+
+func (m *ImportReport) GetDuplicate() []string {
+	if m != nil {
+		return m.Duplicate
+	}
+	return nil
+}
+
+func (m *ImportReport) GetError() []string {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *ImportReport) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type ExportedFile struct {
+	FileName string `protobuf:"bytes,1,opt,name=fileName" json:"fileName,omitempty"`
+	MimeType string `protobuf:"bytes,2,opt,name=mimeType" json:"mimeType,omitempty"`
+	Content  []byte `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (m *ExportedFile) Reset()                    { *m = ExportedFile{} }
+func (m *ExportedFile) String() string            { return proto.CompactTextString(m) }
+func (*ExportedFile) ProtoMessage()               {}
+func (*ExportedFile) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *ExportedFile) GetFileName() string {
+	if m != nil {
+		return m.FileName
+	}
+	return ""
+}
+
+func (m *ExportedFile) GetMimeType() string {
+	if m != nil {
+		return m.MimeType
+	}
+	return ""
+}
+
+func (m *ExportedFile) GetContent() []byte {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+type TournamentRename struct {
+	Name    string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	NewName string `protobuf:"bytes,2,opt,name=newName" json:"newName,omitempty"`
+}
+
+func (m *TournamentRename) Reset()                    { *m = TournamentRename{} }
+func (m *TournamentRename) String() string            { return proto.CompactTextString(m) }
+func (*TournamentRename) ProtoMessage()               {}
+func (*TournamentRename) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *TournamentRename) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TournamentRename) GetNewName() string {
+	if m != nil {
+		return m.NewName
+	}
+	return ""
+}
+
+type DefinedPlayer struct {
+	Color           string           `protobuf:"bytes,1,opt,name=color" json:"color,omitempty"`
+	RacesRemaining  uint32           `protobuf:"varint,2,opt,name=racesRemaining" json:"racesRemaining,omitempty"`
+	OtherContenders []*DefinedPlayer `protobuf:"bytes,3,rep,name=otherContenders" json:"otherContenders,omitempty"`
+}
+
+func (m *DefinedPlayer) Reset()                    { *m = DefinedPlayer{} }
+func (m *DefinedPlayer) String() string            { return proto.CompactTextString(m) }
+func (*DefinedPlayer) ProtoMessage()               {}
+func (*DefinedPlayer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *DefinedPlayer) GetColor() string {
+	if m != nil {
+		return m.Color
+	}
+	return ""
+}
+
+func (m *DefinedPlayer) GetRacesRemaining() uint32 {
+	if m != nil {
+		return m.RacesRemaining
+	}
+	return 0
+}
+
+func (m *DefinedPlayer) GetOtherContenders() []*DefinedPlayer {
+	if m != nil {
+		return m.OtherContenders
+	}
+	return nil
+}
+
+type ResultSpec struct {
+	Gender Gender `protobuf:"varint,1,opt,name=gender,enum=pb.Gender" json:"gender,omitempty"`
+	// take into account only the results of the last N races
+	Last uint32 `protobuf:"varint,2,opt,name=last" json:"last,omitempty"`
+	// tournament to take results from; current one if empty
+	TournamentName string `protobuf:"bytes,3,opt,name=tournamentName" json:"tournamentName,omitempty"`
+	// how many of the ranked results to return; all of them if 0
+	Top uint32 `protobuf:"varint,4,opt,name=top" json:"top,omitempty"`
+	// how many of the ranked results to skip (paging)
+	Offset uint32 `protobuf:"varint,5,opt,name=offset" json:"offset,omitempty"`
+	// results of the given race only regardless of gender
+	RaceId uint32 `protobuf:"varint,6,opt,name=raceId" json:"raceId,omitempty"`
+}
+
+func (m *ResultSpec) Reset()                    { *m = ResultSpec{} }
+func (m *ResultSpec) String() string            { return proto.CompactTextString(m) }
+func (*ResultSpec) ProtoMessage()               {}
+func (*ResultSpec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *ResultSpec) GetGender() Gender {
+	if m != nil {
+		return m.Gender
+	}
+	return Gender_MALE
+}
+
+func (m *ResultSpec) GetLast() uint32 {
+	if m != nil {
+		return m.Last
+	}
+	return 0
+}
+
+func (m *ResultSpec) GetTournamentName() string {
+	if m != nil {
+		return m.TournamentName
+	}
+	return ""
+}
+
+func (m *ResultSpec) GetTop() uint32 {
+	if m != nil {
+		return m.Top
+	}
+	return 0
+}
+
+func (m *ResultSpec) GetOffset() uint32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ResultSpec) GetRaceId() uint32 {
+	if m != nil {
+		return m.RaceId
+	}
+	return 0
+}
+
+type Player struct {
+	Name     string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Gender   Gender `protobuf:"varint,2,opt,name=gender,enum=pb.Gender" json:"gender,omitempty"`
+	Category string `protobuf:"bytes,3,opt,name=category" json:"category,omitempty"`
+}
+
+func (m *Player) Reset()                    { *m = Player{} }
+func (m *Player) String() string            { return proto.CompactTextString(m) }
+func (*Player) ProtoMessage()               {}
+func (*Player) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *Player) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Player) GetGender() Gender {
+	if m != nil {
+		return m.Gender
+	}
+	return Gender_MALE
+}
+
+func (m *Player) GetCategory() string {
+	if m != nil {
+		return m.Category
+	}
+	return ""
+}
+
+type Starter struct {
+	CountdownTime uint32 `protobuf:"varint,1,opt,name=countdownTime" json:"countdownTime,omitempty"`
+}
+
+func (m *Starter) Reset()                    { *m = Starter{} }
+func (m *Starter) String() string            { return proto.CompactTextString(m) }
+func (*Starter) ProtoMessage()               {}
+func (*Starter) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *Starter) GetCountdownTime() uint32 {
+	if m != nil {
+		return m.CountdownTime
+	}
+	return 0
+}
+
+type Racer struct {
+	PlayerNum uint32 `protobuf:"varint,1,opt,name=playerNum" json:"playerNum,omitempty"`
+	Distance  uint32 `protobuf:"varint,2,opt,name=distance" json:"distance,omitempty"`
+}
+
+func (m *Racer) Reset()                    { *m = Racer{} }
+func (m *Racer) String() string            { return proto.CompactTextString(m) }
+func (*Racer) ProtoMessage()               {}
+func (*Racer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *Racer) GetPlayerNum() uint32 {
+	if m != nil {
+		return m.PlayerNum
+	}
+	return 0
+}
+
+func (m *Racer) GetDistance() uint32 {
+	if m != nil {
+		return m.Distance
+	}
+	return 0
+}
+
+type Tournament struct {
+	Name         string                    `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	DestValue    uint32                    `protobuf:"varint,3,opt,name=destValue" json:"destValue,omitempty"`
+	Mode         Tournament_TournamentMode `protobuf:"varint,4,opt,name=mode,enum=pb.Tournament_TournamentMode" json:"mode,omitempty"`
+	PlayerCount  uint32                    `protobuf:"varint,5,opt,name=playerCount" json:"playerCount,omitempty"`
+	Color        []string                  `protobuf:"bytes,6,rep,name=color" json:"color,omitempty"`
+	Result       []*Result                 `protobuf:"bytes,7,rep,name=result" json:"result,omitempty"`
+	LastResultId uint32                    `protobuf:"varint,8,opt,name=lastResultId" json:"lastResultId,omitempty"`
+	LastRaceId   uint32                    `protobuf:"varint,9,opt,name=lastRaceId" json:"lastRaceId,omitempty"`
+	// every correction made to the results
+	Audit    []*AuditEntry `protobuf:"bytes,10,rep,name=audit" json:"audit,omitempty"`
+	Archived bool          `protobuf:"varint,11,opt,name=archived" json:"archived,omitempty"`
+	// allows NewTournament to replace existing tournament of the same name;
+	// never stored
+	Overwrite bool `protobuf:"varint,12,opt,name=overwrite" json:"overwrite,omitempty"`
+	// registered players; race players of the same name get their categories
+	Player []*Player `protobuf:"bytes,13,rep,name=player" json:"player,omitempty"`
+	// results of time constrained races are in meters rather than in device
+	// units as they used to be
+	ResultsInMeters bool `protobuf:"varint,14,opt,name=resultsInMeters" json:"resultsInMeters,omitempty"`
+	// relay: distances in device units where riders swap; equal legs if empty
+	SwapDistance []uint32 `protobuf:"varint,15,rep,packed,name=swapDistance" json:"swapDistance,omitempty"`
+	// pursuit: distance in device units the riders start apart; the one who
+	// makes it up catches the other
+	StartOffset uint32 `protobuf:"varint,16,opt,name=startOffset" json:"startOffset,omitempty"`
+}
+
+func (m *Tournament) Reset()                    { *m = Tournament{} }
+func (m *Tournament) String() string            { return proto.CompactTextString(m) }
+func (*Tournament) ProtoMessage()               {}
+func (*Tournament) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *Tournament) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Tournament) GetDestValue() uint32 {
+	if m != nil {
+		return m.DestValue
+	}
+	return 0
+}
+
+func (m *Tournament) GetMode() Tournament_TournamentMode {
+	if m != nil {
+		return m.Mode
+	}
+	return Tournament_DISTANCE
+}
+
+func (m *Tournament) GetPlayerCount() uint32 {
+	if m != nil {
+		return m.PlayerCount
+	}
+	return 0
+}
+
+func (m *Tournament) GetColor() []string {
+	if m != nil {
+		return m.Color
+	}
+	return nil
+}
+
+func (m *Tournament) GetResult() []*Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *Tournament) GetLastResultId() uint32 {
+	if m != nil {
+		return m.LastResultId
+	}
+	return 0
+}
+
+func (m *Tournament) GetLastRaceId() uint32 {
+	if m != nil {
+		return m.LastRaceId
+	}
+	return 0
+}
+
+func (m *Tournament) GetAudit() []*AuditEntry {
+	if m != nil {
+		return m.Audit
+	}
+	return nil
+}
+
+func (m *Tournament) GetArchived() bool {
+	if m != nil {
+		return m.Archived
+	}
+	return false
+}
+
+func (m *Tournament) GetOverwrite() bool {
+	if m != nil {
+		return m.Overwrite
+	}
+	return false
+}
+
+func (m *Tournament) GetPlayer() []*Player {
+	if m != nil {
+		return m.Player
+	}
+	return nil
+}
+
+func (m *Tournament) GetResultsInMeters() bool {
+	if m != nil {
+		return m.ResultsInMeters
+	}
+	return false
+}
+
+func (m *Tournament) GetSwapDistance() []uint32 {
+	if m != nil {
+		return m.SwapDistance
+	}
+	return nil
+}
+
+func (m *Tournament) GetStartOffset() uint32 {
+	if m != nil {
+		return m.StartOffset
+	}
+	return 0
+}
+
+type VisConfiguration struct {
+	HostName         string `protobuf:"bytes,1,opt,name=hostName" json:"hostName,omitempty"`
+	VisName          string `protobuf:"bytes,2,opt,name=visName" json:"visName,omitempty"`
+	Fullscreen       bool   `protobuf:"varint,3,opt,name=fullscreen" json:"fullscreen,omitempty"`
+	ResolutionWidth  uint32 `protobuf:"varint,4,opt,name=resolutionWidth" json:"resolutionWidth,omitempty"`
+	ResolutionHeight uint32 `protobuf:"varint,5,opt,name=resolutionHeight" json:"resolutionHeight,omitempty"`
+	MovingUnit       uint32 `protobuf:"varint,6,opt,name=movingUnit" json:"movingUnit,omitempty"`
+	DistFactor       uint32 `protobuf:"varint,7,opt,name=distFactor" json:"distFactor,omitempty"`
+	// web visual: TCP port of the page served to the browsers
+	WebPort uint32 `protobuf:"varint,8,opt,name=webPort" json:"webPort,omitempty"`
+}
+
+func (m *VisConfiguration) Reset()                    { *m = VisConfiguration{} }
+func (m *VisConfiguration) String() string            { return proto.CompactTextString(m) }
+func (*VisConfiguration) ProtoMessage()               {}
+func (*VisConfiguration) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *VisConfiguration) GetHostName() string {
+	if m != nil {
+		return m.HostName
+	}
+	return ""
+}
+
+func (m *VisConfiguration) GetVisName() string {
+	if m != nil {
+		return m.VisName
+	}
+	return ""
+}
+
+func (m *VisConfiguration) GetFullscreen() bool {
+	if m != nil {
+		return m.Fullscreen
+	}
+	return false
+}
+
+func (m *VisConfiguration) GetResolutionWidth() uint32 {
+	if m != nil {
+		return m.ResolutionWidth
+	}
+	return 0
+}
+
+func (m *VisConfiguration) GetResolutionHeight() uint32 {
+	if m != nil {
+		return m.ResolutionHeight
+	}
+	return 0
+}
+
+func (m *VisConfiguration) GetMovingUnit() uint32 {
+	if m != nil {
+		return m.MovingUnit
+	}
+	return 0
+}
+
+func (m *VisConfiguration) GetDistFactor() uint32 {
+	if m != nil {
+		return m.DistFactor
+	}
+	return 0
+}
+
+func (m *VisConfiguration) GetWebPort() uint32 {
+	if m != nil {
+		return m.WebPort
+	}
+	return 0
+}
+
+// VisualRegistration announces the visual to the server either with
+// RegisterVisual or broadcast over UDP within Announcement
+type VisualRegistration struct {
+	// host:port of the Visual service; the sender's host and the port if empty
+	Address       string            `protobuf:"bytes,1,opt,name=address" json:"address,omitempty"`
+	Port          uint32            `protobuf:"varint,2,opt,name=port" json:"port,omitempty"`
+	Configuration *VisConfiguration `protobuf:"bytes,3,opt,name=configuration" json:"configuration,omitempty"`
+}
+
+func (m *VisualRegistration) Reset()                    { *m = VisualRegistration{} }
+func (m *VisualRegistration) String() string            { return proto.CompactTextString(m) }
+func (*VisualRegistration) ProtoMessage()               {}
+func (*VisualRegistration) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *VisualRegistration) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *VisualRegistration) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *VisualRegistration) GetConfiguration() *VisConfiguration {
+	if m != nil {
+		return m.Configuration
+	}
+	return nil
+}
+
+// Announcement is the datagram broadcast by the visuals for the discovery;
+// signature is required when the server has auth enabled
+type Announcement struct {
+	// VisualRegistration
+	Registration []byte `protobuf:"bytes,1,opt,name=registration,proto3" json:"registration,omitempty"`
+	// unix time in seconds; announcements too old are ignored
+	SentAt int64 `protobuf:"varint,2,opt,name=sentAt" json:"sentAt,omitempty"`
+	// HMAC-SHA256 of the registration and sentAt keyed with the visual or admin token
+	Signature []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *Announcement) Reset()                    { *m = Announcement{} }
+func (m *Announcement) String() string            { return proto.CompactTextString(m) }
+func (*Announcement) ProtoMessage()               {}
+func (*Announcement) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *Announcement) GetRegistration() []byte {
+	if m != nil {
+		return m.Registration
+	}
+	return nil
+}
+
+func (m *Announcement) GetSentAt() int64 {
+	if m != nil {
+		return m.SentAt
+	}
+	return 0
+}
+
+func (m *Announcement) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type VisualSpec struct {
+	HostName string `protobuf:"bytes,1,opt,name=hostName" json:"hostName,omitempty"`
+}
+
+func (m *VisualSpec) Reset()                    { *m = VisualSpec{} }
+func (m *VisualSpec) String() string            { return proto.CompactTextString(m) }
+func (*VisualSpec) ProtoMessage()               {}
+func (*VisualSpec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *VisualSpec) GetHostName() string {
+	if m != nil {
+		return m.HostName
+	}
+	return ""
+}
+
+type VisualInfo struct {
+	Address string `protobuf:"bytes,1,opt,name=address" json:"address,omitempty"`
+	// connectivity state: IDLE, CONNECTING, READY, TRANSIENT_FAILURE or
+	// SHUTDOWN
+	State string `protobuf:"bytes,2,opt,name=state" json:"state,omitempty"`
+	// last configuration registered or sent to the visual; unknown if empty
+	Configuration *VisConfiguration `protobuf:"bytes,3,opt,name=configuration" json:"configuration,omitempty"`
+}
+
+func (m *VisualInfo) Reset()                    { *m = VisualInfo{} }
+func (m *VisualInfo) String() string            { return proto.CompactTextString(m) }
+func (*VisualInfo) ProtoMessage()               {}
+func (*VisualInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *VisualInfo) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *VisualInfo) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *VisualInfo) GetConfiguration() *VisConfiguration {
+	if m != nil {
+		return m.Configuration
+	}
+	return nil
+}
+
+type Visuals struct {
+	Visual []*VisualInfo `protobuf:"bytes,1,rep,name=visual" json:"visual,omitempty"`
+}
+
+func (m *Visuals) Reset()                    { *m = Visuals{} }
+func (m *Visuals) String() string            { return proto.CompactTextString(m) }
+func (*Visuals) ProtoMessage()               {}
+func (*Visuals) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *Visuals) GetVisual() []*VisualInfo {
+	if m != nil {
+		return m.Visual
+	}
+	return nil
+}
+
+type PairingRequest struct {
+	// code shown on the visuals
+	Code string `protobuf:"bytes,1,opt,name=code" json:"code,omitempty"`
+}
+
+func (m *PairingRequest) Reset()                    { *m = PairingRequest{} }
+func (m *PairingRequest) String() string            { return proto.CompactTextString(m) }
+func (*PairingRequest) ProtoMessage()               {}
+func (*PairingRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *PairingRequest) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+type Pairing struct {
+	// to be sent in the "authorization" metadata of every call
+	Token string `protobuf:"bytes,1,opt,name=token" json:"token,omitempty"`
+	Role  Role   `protobuf:"varint,2,opt,name=role,enum=pb.Role" json:"role,omitempty"`
+}
+
+func (m *Pairing) Reset()                    { *m = Pairing{} }
+func (m *Pairing) String() string            { return proto.CompactTextString(m) }
+func (*Pairing) ProtoMessage()               {}
+func (*Pairing) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *Pairing) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *Pairing) GetRole() Role {
+	if m != nil {
+		return m.Role
+	}
+	return Role_VIEWER
+}
+
+type PairingCode struct {
+	Code string `protobuf:"bytes,1,opt,name=code" json:"code,omitempty"`
+	// role the code grants
+	Role Role `protobuf:"varint,2,opt,name=role,enum=pb.Role" json:"role,omitempty"`
+	// seconds the code is valid for
+	ValidFor uint32 `protobuf:"varint,3,opt,name=validFor" json:"validFor,omitempty"`
+}
+
+func (m *PairingCode) Reset()                    { *m = PairingCode{} }
+func (m *PairingCode) String() string            { return proto.CompactTextString(m) }
+func (*PairingCode) ProtoMessage()               {}
+func (*PairingCode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *PairingCode) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *PairingCode) GetRole() Role {
+	if m != nil {
+		return m.Role
+	}
+	return Role_VIEWER
+}
+
+func (m *PairingCode) GetValidFor() uint32 {
+	if m != nil {
+		return m.ValidFor
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*Empty)(nil), "pb.Empty")
+	proto.RegisterType((*AbortMessage)(nil), "pb.AbortMessage")
+	proto.RegisterType((*Race)(nil), "pb.Race")
+	proto.RegisterType((*Ghost)(nil), "pb.Ghost")
+	proto.RegisterType((*Team)(nil), "pb.Team")
+	proto.RegisterType((*Leg)(nil), "pb.Leg")
+	proto.RegisterType((*Elimination)(nil), "pb.Elimination")
+	proto.RegisterType((*Swap)(nil), "pb.Swap")
+	proto.RegisterType((*Handicap)(nil), "pb.Handicap")
+	proto.RegisterType((*DefinedRace)(nil), "pb.DefinedRace")
+	proto.RegisterType((*Results)(nil), "pb.Results")
+	proto.RegisterType((*Result)(nil), "pb.Result")
+	proto.RegisterType((*Split)(nil), "pb.Split")
+	proto.RegisterType((*TracePoint)(nil), "pb.TracePoint")
+	proto.RegisterType((*ResultEdit)(nil), "pb.ResultEdit")
+	proto.RegisterType((*AuditEntry)(nil), "pb.AuditEntry")
+	proto.RegisterType((*Record)(nil), "pb.Record")
+	proto.RegisterType((*RecordSpec)(nil), "pb.RecordSpec")
+	proto.RegisterType((*Tournaments)(nil), "pb.Tournaments")
+	proto.RegisterType((*TournamentNames)(nil), "pb.TournamentNames")
+	proto.RegisterType((*TournamentSpec)(nil), "pb.TournamentSpec")
+	proto.RegisterType((*ExportSpec)(nil), "pb.ExportSpec")
+	proto.RegisterType((*PrintSpec)(nil), "pb.PrintSpec")
+	proto.RegisterType((*ImportSpec)(nil), "pb.ImportSpec")
+	proto.RegisterType((*ImportReport)(nil), "pb.ImportReport")
+	proto.RegisterType((*ExportedFile)(nil), "pb.ExportedFile")
+	proto.RegisterType((*TournamentRename)(nil), "pb.TournamentRename")
+	proto.RegisterType((*DefinedPlayer)(nil), "pb.DefinedPlayer")
+	proto.RegisterType((*ResultSpec)(nil), "pb.ResultSpec")
+	proto.RegisterType((*Player)(nil), "pb.Player")
+	proto.RegisterType((*Starter)(nil), "pb.Starter")
+	proto.RegisterType((*Racer)(nil), "pb.Racer")
+	proto.RegisterType((*Tournament)(nil), "pb.Tournament")
+	proto.RegisterType((*VisConfiguration)(nil), "pb.VisConfiguration")
+	proto.RegisterType((*VisualRegistration)(nil), "pb.VisualRegistration")
+	proto.RegisterType((*Announcement)(nil), "pb.Announcement")
+	proto.RegisterType((*VisualSpec)(nil), "pb.VisualSpec")
+	proto.RegisterType((*VisualInfo)(nil), "pb.VisualInfo")
+	proto.RegisterType((*Visuals)(nil), "pb.Visuals")
+	proto.RegisterType((*PairingRequest)(nil), "pb.PairingRequest")
+	proto.RegisterType((*Pairing)(nil), "pb.Pairing")
+	proto.RegisterType((*PairingCode)(nil), "pb.PairingCode")
+	proto.RegisterEnum("pb.Gender", Gender_name, Gender_value)
+	proto.RegisterEnum("pb.Role", Role_name, Role_value)
+	proto.RegisterEnum("pb.Ghost_Source", Ghost_Source_name, Ghost_Source_value)
+	proto.RegisterEnum("pb.Tournament_TournamentMode", Tournament_TournamentMode_name, Tournament_TournamentMode_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Sprints service
+
+type SprintsClient interface {
+	NewTournament(ctx context.Context, in *Tournament, opts ...grpc.CallOption) (*Tournament, error)
+	NewRace(ctx context.Context, in *Race, opts ...grpc.CallOption) (*Empty, error)
+	StartRace(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Player, error)
+	AbortRace(ctx context.Context, in *AbortMessage, opts ...grpc.CallOption) (*Empty, error)
+	// configures the visual of the hostName only; all of them if it's empty
+	ConfigureVis(ctx context.Context, in *VisConfiguration, opts ...grpc.CallOption) (*Empty, error)
+	GetResults(ctx context.Context, in *ResultSpec, opts ...grpc.CallOption) (Sprints_GetResultsClient, error)
+	GetTournamentNames(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TournamentNames, error)
+	GetCurrentTournament(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Tournament, error)
+	LoadTournament(ctx context.Context, in *TournamentSpec, opts ...grpc.CallOption) (*Tournament, error)
+	ShowResults(ctx context.Context, in *ResultSpec, opts ...grpc.CallOption) (*Empty, error)
+	GetPersonalBests(ctx context.Context, in *Player, opts ...grpc.CallOption) (Sprints_GetPersonalBestsClient, error)
+	GetRecords(ctx context.Context, in *RecordSpec, opts ...grpc.CallOption) (Sprints_GetRecordsClient, error)
+	EditResult(ctx context.Context, in *ResultEdit, opts ...grpc.CallOption) (*Result, error)
+	DisqualifyResult(ctx context.Context, in *ResultEdit, opts ...grpc.CallOption) (*Result, error)
+	DeleteResult(ctx context.Context, in *ResultEdit, opts ...grpc.CallOption) (*Empty, error)
+	UndoLastRace(ctx context.Context, in *TournamentSpec, opts ...grpc.CallOption) (*Results, error)
+	RenameTournament(ctx context.Context, in *TournamentRename, opts ...grpc.CallOption) (*Tournament, error)
+	DeleteTournament(ctx context.Context, in *TournamentSpec, opts ...grpc.CallOption) (*Empty, error)
+	ArchiveTournament(ctx context.Context, in *TournamentSpec, opts ...grpc.CallOption) (*Tournament, error)
+	DuplicateTournament(ctx context.Context, in *TournamentRename, opts ...grpc.CallOption) (*Tournament, error)
+	ExportResults(ctx context.Context, in *ExportSpec, opts ...grpc.CallOption) (*ExportedFile, error)
+	PrintResults(ctx context.Context, in *PrintSpec, opts ...grpc.CallOption) (*ExportedFile, error)
+	ImportPlayers(ctx context.Context, in *ImportSpec, opts ...grpc.CallOption) (*ImportReport, error)
+	ImportResults(ctx context.Context, in *ImportSpec, opts ...grpc.CallOption) (*ImportReport, error)
+	GetLegResults(ctx context.Context, in *ResultSpec, opts ...grpc.CallOption) (Sprints_GetLegResultsClient, error)
+	// shows pairing code on the visuals; the only calls allowed without a token
+	// are this one and Pair
+	RequestPairing(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	Pair(ctx context.Context, in *PairingRequest, opts ...grpc.CallOption) (*Pairing, error)
+	// visuals add themselves to the ones receiving the races
+	RegisterVisual(ctx context.Context, in *VisualRegistration, opts ...grpc.CallOption) (*Empty, error)
+	UnregisterVisual(ctx context.Context, in *VisualRegistration, opts ...grpc.CallOption) (*Empty, error)
+	// visuals connected over the network
+	GetVisuals(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Visuals, error)
+	// applies the last configuration of the visual again
+	RestartVis(ctx context.Context, in *VisualSpec, opts ...grpc.CallOption) (*Empty, error)
+	StopVis(ctx context.Context, in *VisualSpec, opts ...grpc.CallOption) (*Empty, error)
+}
+
+type sprintsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSprintsClient(cc *grpc.ClientConn) SprintsClient {
+	return &sprintsClient{cc}
+}
+
+func (c *sprintsClient) NewTournament(ctx context.Context, in *Tournament, opts ...grpc.CallOption) (*Tournament, error) {
+	out := new(Tournament)
+	err := grpc.Invoke(ctx, "/pb.Sprints/NewTournament", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) NewRace(ctx context.Context, in *Race, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/pb.Sprints/NewRace", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) StartRace(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Player, error) {
+	out := new(Player)
+	err := grpc.Invoke(ctx, "/pb.Sprints/StartRace", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) AbortRace(ctx context.Context, in *AbortMessage, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/pb.Sprints/AbortRace", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) ConfigureVis(ctx context.Context, in *VisConfiguration, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/pb.Sprints/ConfigureVis", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) GetResults(ctx context.Context, in *ResultSpec, opts ...grpc.CallOption) (Sprints_GetResultsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Sprints_serviceDesc.Streams[0], c.cc, "/pb.Sprints/GetResults", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sprintsGetResultsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Sprints_GetResultsClient interface {
+	Recv() (*Result, error)
+	grpc.ClientStream
+}
+
+type sprintsGetResultsClient struct {
+	grpc.ClientStream
+}
+
+func (x *sprintsGetResultsClient) Recv() (*Result, error) {
+	m := new(Result)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *sprintsClient) GetTournamentNames(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TournamentNames, error) {
+	out := new(TournamentNames)
+	err := grpc.Invoke(ctx, "/pb.Sprints/GetTournamentNames", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) GetCurrentTournament(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Tournament, error) {
+	out := new(Tournament)
+	err := grpc.Invoke(ctx, "/pb.Sprints/GetCurrentTournament", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) LoadTournament(ctx context.Context, in *TournamentSpec, opts ...grpc.CallOption) (*Tournament, error) {
+	out := new(Tournament)
+	err := grpc.Invoke(ctx, "/pb.Sprints/LoadTournament", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) ShowResults(ctx context.Context, in *ResultSpec, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/pb.Sprints/ShowResults", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) GetPersonalBests(ctx context.Context, in *Player, opts ...grpc.CallOption) (Sprints_GetPersonalBestsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Sprints_serviceDesc.Streams[1], c.cc, "/pb.Sprints/GetPersonalBests", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sprintsGetPersonalBestsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Sprints_GetPersonalBestsClient interface {
+	Recv() (*Record, error)
+	grpc.ClientStream
+}
+
+type sprintsGetPersonalBestsClient struct {
+	grpc.ClientStream
+}
+
+func (x *sprintsGetPersonalBestsClient) Recv() (*Record, error) {
+	m := new(Record)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *sprintsClient) GetRecords(ctx context.Context, in *RecordSpec, opts ...grpc.CallOption) (Sprints_GetRecordsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Sprints_serviceDesc.Streams[2], c.cc, "/pb.Sprints/GetRecords", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sprintsGetRecordsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Sprints_GetRecordsClient interface {
+	Recv() (*Record, error)
+	grpc.ClientStream
+}
+
+type sprintsGetRecordsClient struct {
+	grpc.ClientStream
+}
+
+func (x *sprintsGetRecordsClient) Recv() (*Record, error) {
+	m := new(Record)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *sprintsClient) EditResult(ctx context.Context, in *ResultEdit, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := grpc.Invoke(ctx, "/pb.Sprints/EditResult", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) DisqualifyResult(ctx context.Context, in *ResultEdit, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := grpc.Invoke(ctx, "/pb.Sprints/DisqualifyResult", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) DeleteResult(ctx context.Context, in *ResultEdit, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/pb.Sprints/DeleteResult", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) UndoLastRace(ctx context.Context, in *TournamentSpec, opts ...grpc.CallOption) (*Results, error) {
+	out := new(Results)
+	err := grpc.Invoke(ctx, "/pb.Sprints/UndoLastRace", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) RenameTournament(ctx context.Context, in *TournamentRename, opts ...grpc.CallOption) (*Tournament, error) {
+	out := new(Tournament)
+	err := grpc.Invoke(ctx, "/pb.Sprints/RenameTournament", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) DeleteTournament(ctx context.Context, in *TournamentSpec, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/pb.Sprints/DeleteTournament", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) ArchiveTournament(ctx context.Context, in *TournamentSpec, opts ...grpc.CallOption) (*Tournament, error) {
+	out := new(Tournament)
+	err := grpc.Invoke(ctx, "/pb.Sprints/ArchiveTournament", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) DuplicateTournament(ctx context.Context, in *TournamentRename, opts ...grpc.CallOption) (*Tournament, error) {
+	out := new(Tournament)
+	err := grpc.Invoke(ctx, "/pb.Sprints/DuplicateTournament", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) ExportResults(ctx context.Context, in *ExportSpec, opts ...grpc.CallOption) (*ExportedFile, error) {
+	out := new(ExportedFile)
+	err := grpc.Invoke(ctx, "/pb.Sprints/ExportResults", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) PrintResults(ctx context.Context, in *PrintSpec, opts ...grpc.CallOption) (*ExportedFile, error) {
+	out := new(ExportedFile)
+	err := grpc.Invoke(ctx, "/pb.Sprints/PrintResults", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) ImportPlayers(ctx context.Context, in *ImportSpec, opts ...grpc.CallOption) (*ImportReport, error) {
+	out := new(ImportReport)
+	err := grpc.Invoke(ctx, "/pb.Sprints/ImportPlayers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) ImportResults(ctx context.Context, in *ImportSpec, opts ...grpc.CallOption) (*ImportReport, error) {
+	out := new(ImportReport)
+	err := grpc.Invoke(ctx, "/pb.Sprints/ImportResults", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) GetLegResults(ctx context.Context, in *ResultSpec, opts ...grpc.CallOption) (Sprints_GetLegResultsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Sprints_serviceDesc.Streams[3], c.cc, "/pb.Sprints/GetLegResults", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sprintsGetLegResultsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Sprints_GetLegResultsClient interface {
+	Recv() (*Result, error)
+	grpc.ClientStream
+}
+
+type sprintsGetLegResultsClient struct {
+	grpc.ClientStream
+}
+
+func (x *sprintsGetLegResultsClient) Recv() (*Result, error) {
+	m := new(Result)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *sprintsClient) RequestPairing(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/pb.Sprints/RequestPairing", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) Pair(ctx context.Context, in *PairingRequest, opts ...grpc.CallOption) (*Pairing, error) {
+	out := new(Pairing)
+	err := grpc.Invoke(ctx, "/pb.Sprints/Pair", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) RegisterVisual(ctx context.Context, in *VisualRegistration, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/pb.Sprints/RegisterVisual", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) UnregisterVisual(ctx context.Context, in *VisualRegistration, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/pb.Sprints/UnregisterVisual", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) GetVisuals(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Visuals, error) {
+	out := new(Visuals)
+	err := grpc.Invoke(ctx, "/pb.Sprints/GetVisuals", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) RestartVis(ctx context.Context, in *VisualSpec, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/pb.Sprints/RestartVis", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sprintsClient) StopVis(ctx context.Context, in *VisualSpec, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/pb.Sprints/StopVis", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Sprints service
+
+type SprintsServer interface {
+	NewTournament(context.Context, *Tournament) (*Tournament, error)
+	NewRace(context.Context, *Race) (*Empty, error)
+	StartRace(context.Context, *Empty) (*Player, error)
+	AbortRace(context.Context, *AbortMessage) (*Empty, error)
+	// configures the visual of the hostName only; all of them if it's empty
+	ConfigureVis(context.Context, *VisConfiguration) (*Empty, error)
+	GetResults(*ResultSpec, Sprints_GetResultsServer) error
+	GetTournamentNames(context.Context, *Empty) (*TournamentNames, error)
+	GetCurrentTournament(context.Context, *Empty) (*Tournament, error)
+	LoadTournament(context.Context, *TournamentSpec) (*Tournament, error)
+	ShowResults(context.Context, *ResultSpec) (*Empty, error)
+	GetPersonalBests(*Player, Sprints_GetPersonalBestsServer) error
+	GetRecords(*RecordSpec, Sprints_GetRecordsServer) error
+	EditResult(context.Context, *ResultEdit) (*Result, error)
+	DisqualifyResult(context.Context, *ResultEdit) (*Result, error)
+	DeleteResult(context.Context, *ResultEdit) (*Empty, error)
+	UndoLastRace(context.Context, *TournamentSpec) (*Results, error)
+	RenameTournament(context.Context, *TournamentRename) (*Tournament, error)
+	DeleteTournament(context.Context, *TournamentSpec) (*Empty, error)
+	ArchiveTournament(context.Context, *TournamentSpec) (*Tournament, error)
+	DuplicateTournament(context.Context, *TournamentRename) (*Tournament, error)
+	ExportResults(context.Context, *ExportSpec) (*ExportedFile, error)
+	PrintResults(context.Context, *PrintSpec) (*ExportedFile, error)
+	ImportPlayers(context.Context, *ImportSpec) (*ImportReport, error)
+	ImportResults(context.Context, *ImportSpec) (*ImportReport, error)
+	GetLegResults(*ResultSpec, Sprints_GetLegResultsServer) error
+	// shows pairing code on the visuals; the only calls allowed without a token
+	// are this one and Pair
+	RequestPairing(context.Context, *Empty) (*Empty, error)
+	Pair(context.Context, *PairingRequest) (*Pairing, error)
+	// visuals add themselves to the ones receiving the races
+	RegisterVisual(context.Context, *VisualRegistration) (*Empty, error)
+	UnregisterVisual(context.Context, *VisualRegistration) (*Empty, error)
+	// visuals connected over the network
+	GetVisuals(context.Context, *Empty) (*Visuals, error)
+	// applies the last configuration of the visual again
+	RestartVis(context.Context, *VisualSpec) (*Empty, error)
+	StopVis(context.Context, *VisualSpec) (*Empty, error)
+}
+
+func RegisterSprintsServer(s *grpc.Server, srv SprintsServer) {
+	s.RegisterService(&_Sprints_serviceDesc, srv)
+}
+
+func _Sprints_NewTournament_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Tournament)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SprintsServer).NewTournament(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Sprints/NewTournament",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SprintsServer).NewTournament(ctx, req.(*Tournament))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sprints_NewRace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Race)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SprintsServer).NewRace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Sprints/NewRace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SprintsServer).NewRace(ctx, req.(*Race))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sprints_StartRace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SprintsServer).StartRace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Sprints/StartRace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SprintsServer).StartRace(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sprints_AbortRace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbortMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SprintsServer).AbortRace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Sprints/AbortRace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SprintsServer).AbortRace(ctx, req.(*AbortMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sprints_ConfigureVis_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VisConfiguration)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SprintsServer).ConfigureVis(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Sprints/ConfigureVis",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SprintsServer).ConfigureVis(ctx, req.(*VisConfiguration))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sprints_GetResults_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ResultSpec)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SprintsServer).GetResults(m, &sprintsGetResultsServer{stream})
+}
+
+type Sprints_GetResultsServer interface {
+	Send(*Result) error
+	grpc.ServerStream
+}
+
+type sprintsGetResultsServer struct {
+	grpc.ServerStream
+}
+
+func (x *sprintsGetResultsServer) Send(m *Result) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Sprints_GetTournamentNames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SprintsServer).GetTournamentNames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Sprints/GetTournamentNames",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SprintsServer).GetTournamentNames(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sprints_GetCurrentTournament_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SprintsServer).GetCurrentTournament(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Sprints/GetCurrentTournament",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SprintsServer).GetCurrentTournament(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sprints_LoadTournament_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TournamentSpec)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SprintsServer).LoadTournament(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Sprints/LoadTournament",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SprintsServer).LoadTournament(ctx, req.(*TournamentSpec))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sprints_ShowResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResultSpec)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SprintsServer).ShowResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Sprints/ShowResults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SprintsServer).ShowResults(ctx, req.(*ResultSpec))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sprints_GetPersonalBests_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Player)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SprintsServer).GetPersonalBests(m, &sprintsGetPersonalBestsServer{stream})
+}
+
+type Sprints_GetPersonalBestsServer interface {
+	Send(*Record) error
+	grpc.ServerStream
+}
+
+type sprintsGetPersonalBestsServer struct {
+	grpc.ServerStream
+}
+
+func (x *sprintsGetPersonalBestsServer) Send(m *Record) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Sprints_GetRecords_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RecordSpec)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SprintsServer).GetRecords(m, &sprintsGetRecordsServer{stream})
+}
+
+type Sprints_GetRecordsServer interface {
+	Send(*Record) error
+	grpc.ServerStream
+}
+
+type sprintsGetRecordsServer struct {
+	grpc.ServerStream
+}
+
+func (x *sprintsGetRecordsServer) Send(m *Record) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Sprints_EditResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResultEdit)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SprintsServer).EditResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Sprints/EditResult",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SprintsServer).EditResult(ctx, req.(*ResultEdit))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sprints_DisqualifyResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResultEdit)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SprintsServer).DisqualifyResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Sprints/DisqualifyResult",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SprintsServer).DisqualifyResult(ctx, req.(*ResultEdit))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sprints_DeleteResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResultEdit)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SprintsServer).DeleteResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Sprints/DeleteResult",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SprintsServer).DeleteResult(ctx, req.(*ResultEdit))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sprints_UndoLastRace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TournamentSpec)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SprintsServer).UndoLastRace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Sprints/UndoLastRace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SprintsServer).UndoLastRace(ctx, req.(*TournamentSpec))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sprints_RenameTournament_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TournamentRename)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SprintsServer).RenameTournament(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Sprints/RenameTournament",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SprintsServer).RenameTournament(ctx, req.(*TournamentRename))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sprints_DeleteTournament_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TournamentSpec)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SprintsServer).DeleteTournament(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Sprints/DeleteTournament",
 	}
-	return out, nil
-}
-
-// Server API for Sprints service
-
-type SprintsServer interface {
-	NewTournament(context.Context, *Tournament) (*Tournament, error)
-	NewRace(context.Context, *Race) (*Empty, error)
-	StartRace(context.Context, *Empty) (*Player, error)
-	AbortRace(context.Context, *AbortMessage) (*Empty, error)
-	ConfigureVis(context.Context, *VisConfiguration) (*Empty, error)
-	GetResults(*ResultSpec, Sprints_GetResultsServer) error
-	GetTournamentNames(context.Context, *Empty) (*TournamentNames, error)
-	GetCurrentTournament(context.Context, *Empty) (*Tournament, error)
-	LoadTournament(context.Context, *TournamentSpec) (*Tournament, error)
-	ShowResults(context.Context, *ResultSpec) (*Empty, error)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SprintsServer).DeleteTournament(ctx, req.(*TournamentSpec))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterSprintsServer(s *grpc.Server, srv SprintsServer) {
-	s.RegisterService(&_Sprints_serviceDesc, srv)
+func _Sprints_ArchiveTournament_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TournamentSpec)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SprintsServer).ArchiveTournament(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Sprints/ArchiveTournament",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SprintsServer).ArchiveTournament(ctx, req.(*TournamentSpec))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Sprints_NewTournament_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Tournament)
+func _Sprints_DuplicateTournament_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TournamentRename)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SprintsServer).NewTournament(ctx, in)
+		return srv.(SprintsServer).DuplicateTournament(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.Sprints/NewTournament",
+		FullMethod: "/pb.Sprints/DuplicateTournament",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SprintsServer).NewTournament(ctx, req.(*Tournament))
+		return srv.(SprintsServer).DuplicateTournament(ctx, req.(*TournamentRename))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Sprints_NewRace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Race)
+func _Sprints_ExportResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportSpec)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SprintsServer).NewRace(ctx, in)
+		return srv.(SprintsServer).ExportResults(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.Sprints/NewRace",
+		FullMethod: "/pb.Sprints/ExportResults",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SprintsServer).NewRace(ctx, req.(*Race))
+		return srv.(SprintsServer).ExportResults(ctx, req.(*ExportSpec))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Sprints_StartRace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+func _Sprints_PrintResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrintSpec)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SprintsServer).StartRace(ctx, in)
+		return srv.(SprintsServer).PrintResults(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.Sprints/StartRace",
+		FullMethod: "/pb.Sprints/PrintResults",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SprintsServer).StartRace(ctx, req.(*Empty))
+		return srv.(SprintsServer).PrintResults(ctx, req.(*PrintSpec))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Sprints_AbortRace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AbortMessage)
+func _Sprints_ImportPlayers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportSpec)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SprintsServer).AbortRace(ctx, in)
+		return srv.(SprintsServer).ImportPlayers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.Sprints/AbortRace",
+		FullMethod: "/pb.Sprints/ImportPlayers",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SprintsServer).AbortRace(ctx, req.(*AbortMessage))
+		return srv.(SprintsServer).ImportPlayers(ctx, req.(*ImportSpec))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Sprints_ConfigureVis_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VisConfiguration)
+func _Sprints_ImportResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportSpec)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SprintsServer).ConfigureVis(ctx, in)
+		return srv.(SprintsServer).ImportResults(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.Sprints/ConfigureVis",
+		FullMethod: "/pb.Sprints/ImportResults",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SprintsServer).ConfigureVis(ctx, req.(*VisConfiguration))
+		return srv.(SprintsServer).ImportResults(ctx, req.(*ImportSpec))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Sprints_GetResults_Handler(srv interface{}, stream grpc.ServerStream) error {
+func _Sprints_GetLegResults_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(ResultSpec)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(SprintsServer).GetResults(m, &sprintsGetResultsServer{stream})
+	return srv.(SprintsServer).GetLegResults(m, &sprintsGetLegResultsServer{stream})
 }
 
-type Sprints_GetResultsServer interface {
+type Sprints_GetLegResultsServer interface {
 	Send(*Result) error
 	grpc.ServerStream
 }
 
-type sprintsGetResultsServer struct {
+type sprintsGetLegResultsServer struct {
 	grpc.ServerStream
 }
 
-func (x *sprintsGetResultsServer) Send(m *Result) error {
+func (x *sprintsGetLegResultsServer) Send(m *Result) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Sprints_GetTournamentNames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Sprints_RequestPairing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SprintsServer).GetTournamentNames(ctx, in)
+		return srv.(SprintsServer).RequestPairing(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.Sprints/GetTournamentNames",
+		FullMethod: "/pb.Sprints/RequestPairing",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SprintsServer).GetTournamentNames(ctx, req.(*Empty))
+		return srv.(SprintsServer).RequestPairing(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Sprints_GetCurrentTournament_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Sprints_Pair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PairingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SprintsServer).Pair(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Sprints/Pair",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SprintsServer).Pair(ctx, req.(*PairingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sprints_RegisterVisual_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VisualRegistration)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SprintsServer).RegisterVisual(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Sprints/RegisterVisual",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SprintsServer).RegisterVisual(ctx, req.(*VisualRegistration))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sprints_UnregisterVisual_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VisualRegistration)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SprintsServer).UnregisterVisual(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Sprints/UnregisterVisual",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SprintsServer).UnregisterVisual(ctx, req.(*VisualRegistration))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sprints_GetVisuals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SprintsServer).GetCurrentTournament(ctx, in)
+		return srv.(SprintsServer).GetVisuals(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.Sprints/GetCurrentTournament",
+		FullMethod: "/pb.Sprints/GetVisuals",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SprintsServer).GetCurrentTournament(ctx, req.(*Empty))
+		return srv.(SprintsServer).GetVisuals(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Sprints_LoadTournament_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TournamentSpec)
+func _Sprints_RestartVis_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VisualSpec)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SprintsServer).LoadTournament(ctx, in)
+		return srv.(SprintsServer).RestartVis(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.Sprints/LoadTournament",
+		FullMethod: "/pb.Sprints/RestartVis",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SprintsServer).LoadTournament(ctx, req.(*TournamentSpec))
+		return srv.(SprintsServer).RestartVis(ctx, req.(*VisualSpec))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Sprints_ShowResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ResultSpec)
+func _Sprints_StopVis_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VisualSpec)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SprintsServer).ShowResults(ctx, in)
+		return srv.(SprintsServer).StopVis(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.Sprints/ShowResults",
+		FullMethod: "/pb.Sprints/StopVis",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SprintsServer).ShowResults(ctx, req.(*ResultSpec))
+		return srv.(SprintsServer).StopVis(ctx, req.(*VisualSpec))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -918,6 +2995,82 @@ var _Sprints_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ShowResults",
 			Handler:    _Sprints_ShowResults_Handler,
 		},
+		{
+			MethodName: "EditResult",
+			Handler:    _Sprints_EditResult_Handler,
+		},
+		{
+			MethodName: "DisqualifyResult",
+			Handler:    _Sprints_DisqualifyResult_Handler,
+		},
+		{
+			MethodName: "DeleteResult",
+			Handler:    _Sprints_DeleteResult_Handler,
+		},
+		{
+			MethodName: "UndoLastRace",
+			Handler:    _Sprints_UndoLastRace_Handler,
+		},
+		{
+			MethodName: "RenameTournament",
+			Handler:    _Sprints_RenameTournament_Handler,
+		},
+		{
+			MethodName: "DeleteTournament",
+			Handler:    _Sprints_DeleteTournament_Handler,
+		},
+		{
+			MethodName: "ArchiveTournament",
+			Handler:    _Sprints_ArchiveTournament_Handler,
+		},
+		{
+			MethodName: "DuplicateTournament",
+			Handler:    _Sprints_DuplicateTournament_Handler,
+		},
+		{
+			MethodName: "ExportResults",
+			Handler:    _Sprints_ExportResults_Handler,
+		},
+		{
+			MethodName: "PrintResults",
+			Handler:    _Sprints_PrintResults_Handler,
+		},
+		{
+			MethodName: "ImportPlayers",
+			Handler:    _Sprints_ImportPlayers_Handler,
+		},
+		{
+			MethodName: "ImportResults",
+			Handler:    _Sprints_ImportResults_Handler,
+		},
+		{
+			MethodName: "RequestPairing",
+			Handler:    _Sprints_RequestPairing_Handler,
+		},
+		{
+			MethodName: "Pair",
+			Handler:    _Sprints_Pair_Handler,
+		},
+		{
+			MethodName: "RegisterVisual",
+			Handler:    _Sprints_RegisterVisual_Handler,
+		},
+		{
+			MethodName: "UnregisterVisual",
+			Handler:    _Sprints_UnregisterVisual_Handler,
+		},
+		{
+			MethodName: "GetVisuals",
+			Handler:    _Sprints_GetVisuals_Handler,
+		},
+		{
+			MethodName: "RestartVis",
+			Handler:    _Sprints_RestartVis_Handler,
+		},
+		{
+			MethodName: "StopVis",
+			Handler:    _Sprints_StopVis_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -925,6 +3078,21 @@ var _Sprints_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Sprints_GetResults_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetPersonalBests",
+			Handler:       _Sprints_GetPersonalBests_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetRecords",
+			Handler:       _Sprints_GetRecords_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetLegResults",
+			Handler:       _Sprints_GetLegResults_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "sprints.proto",
 }
@@ -937,10 +3105,15 @@ type VisualClient interface {
 	StartRace(ctx context.Context, in *Starter, opts ...grpc.CallOption) (*Empty, error)
 	AbortRace(ctx context.Context, in *AbortMessage, opts ...grpc.CallOption) (*Empty, error)
 	UpdateRace(ctx context.Context, opts ...grpc.CallOption) (Visual_UpdateRaceClient, error)
+	SwapRiders(ctx context.Context, in *Swap, opts ...grpc.CallOption) (*Empty, error)
+	EliminateRider(ctx context.Context, in *Elimination, opts ...grpc.CallOption) (*Empty, error)
 	FinishRace(ctx context.Context, in *Results, opts ...grpc.CallOption) (*Empty, error)
 	ShowResults(ctx context.Context, in *Results, opts ...grpc.CallOption) (*Empty, error)
 	ConfigureVis(ctx context.Context, in *VisConfiguration, opts ...grpc.CallOption) (*Empty, error)
 	StopVis(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	ShowPairingCode(ctx context.Context, in *PairingCode, opts ...grpc.CallOption) (*Empty, error)
+	// lets the server know the visuals it dialed itself
+	GetConfiguration(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*VisConfiguration, error)
 }
 
 type visualClient struct {
@@ -1021,6 +3194,24 @@ func (x *visualUpdateRaceClient) CloseAndRecv() (*Empty, error) {
 	return m, nil
 }
 
+func (c *visualClient) SwapRiders(ctx context.Context, in *Swap, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/pb.Visual/SwapRiders", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *visualClient) EliminateRider(ctx context.Context, in *Elimination, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/pb.Visual/EliminateRider", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *visualClient) FinishRace(ctx context.Context, in *Results, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
 	err := grpc.Invoke(ctx, "/pb.Visual/FinishRace", in, out, c.cc, opts...)
@@ -1057,6 +3248,24 @@ func (c *visualClient) StopVis(ctx context.Context, in *Empty, opts ...grpc.Call
 	return out, nil
 }
 
+func (c *visualClient) ShowPairingCode(ctx context.Context, in *PairingCode, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/pb.Visual/ShowPairingCode", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *visualClient) GetConfiguration(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*VisConfiguration, error) {
+	out := new(VisConfiguration)
+	err := grpc.Invoke(ctx, "/pb.Visual/GetConfiguration", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Visual service
 
 type VisualServer interface {
@@ -1065,10 +3274,15 @@ type VisualServer interface {
 	StartRace(context.Context, *Starter) (*Empty, error)
 	AbortRace(context.Context, *AbortMessage) (*Empty, error)
 	UpdateRace(Visual_UpdateRaceServer) error
+	SwapRiders(context.Context, *Swap) (*Empty, error)
+	EliminateRider(context.Context, *Elimination) (*Empty, error)
 	FinishRace(context.Context, *Results) (*Empty, error)
 	ShowResults(context.Context, *Results) (*Empty, error)
 	ConfigureVis(context.Context, *VisConfiguration) (*Empty, error)
 	StopVis(context.Context, *Empty) (*Empty, error)
+	ShowPairingCode(context.Context, *PairingCode) (*Empty, error)
+	// lets the server know the visuals it dialed itself
+	GetConfiguration(context.Context, *Empty) (*VisConfiguration, error)
 }
 
 func RegisterVisualServer(s *grpc.Server, srv VisualServer) {
@@ -1173,6 +3387,42 @@ func (x *visualUpdateRaceServer) Recv() (*Racer, error) {
 	return m, nil
 }
 
+func _Visual_SwapRiders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Swap)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VisualServer).SwapRiders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Visual/SwapRiders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VisualServer).SwapRiders(ctx, req.(*Swap))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Visual_EliminateRider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Elimination)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VisualServer).EliminateRider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Visual/EliminateRider",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VisualServer).EliminateRider(ctx, req.(*Elimination))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Visual_FinishRace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Results)
 	if err := dec(in); err != nil {
@@ -1245,6 +3495,42 @@ func _Visual_StopVis_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Visual_ShowPairingCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PairingCode)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VisualServer).ShowPairingCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Visual/ShowPairingCode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VisualServer).ShowPairingCode(ctx, req.(*PairingCode))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Visual_GetConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VisualServer).GetConfiguration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Visual/GetConfiguration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VisualServer).GetConfiguration(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Visual_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pb.Visual",
 	HandlerType: (*VisualServer)(nil),
@@ -1265,6 +3551,14 @@ var _Visual_serviceDesc = grpc.ServiceDesc{
 			MethodName: "AbortRace",
 			Handler:    _Visual_AbortRace_Handler,
 		},
+		{
+			MethodName: "SwapRiders",
+			Handler:    _Visual_SwapRiders_Handler,
+		},
+		{
+			MethodName: "EliminateRider",
+			Handler:    _Visual_EliminateRider_Handler,
+		},
 		{
 			MethodName: "FinishRace",
 			Handler:    _Visual_FinishRace_Handler,
@@ -1281,6 +3575,14 @@ var _Visual_serviceDesc = grpc.ServiceDesc{
 			MethodName: "StopVis",
 			Handler:    _Visual_StopVis_Handler,
 		},
+		{
+			MethodName: "ShowPairingCode",
+			Handler:    _Visual_ShowPairingCode_Handler,
+		},
+		{
+			MethodName: "GetConfiguration",
+			Handler:    _Visual_GetConfiguration_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1295,63 +3597,170 @@ var _Visual_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("sprints.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 924 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xdd, 0x6e, 0xe3, 0x44,
-	0x14, 0xc6, 0x69, 0x62, 0x27, 0x27, 0x4d, 0x1a, 0x86, 0x0a, 0x59, 0x11, 0x2c, 0x95, 0xe9, 0x22,
-	0x6f, 0x25, 0x5a, 0xb6, 0xec, 0x1d, 0x42, 0xa2, 0xca, 0xa6, 0xdd, 0x45, 0xdb, 0x80, 0x9c, 0x6c,
-	0xb9, 0xc5, 0xb1, 0xa7, 0xc9, 0x48, 0xce, 0x8c, 0x35, 0x33, 0xde, 0x6a, 0x5f, 0x81, 0x87, 0xe3,
-	0x39, 0x78, 0x03, 0xee, 0x10, 0x9a, 0xf1, 0x24, 0x1e, 0x9b, 0x86, 0x85, 0xbb, 0x99, 0xef, 0x7c,
-	0x73, 0x7e, 0x3f, 0x9f, 0x04, 0x06, 0x22, 0xe7, 0x84, 0x4a, 0x71, 0x9e, 0x73, 0x26, 0x19, 0x6a,
-	0xe5, 0xcb, 0xc0, 0x83, 0xce, 0x74, 0x93, 0xcb, 0xf7, 0x41, 0x08, 0x87, 0x57, 0x4b, 0xc6, 0xe5,
-	0x2d, 0x16, 0x22, 0x5e, 0x61, 0xe4, 0x83, 0xb7, 0x29, 0x8f, 0xbe, 0x73, 0xe2, 0x84, 0xbd, 0x68,
-	0x7b, 0x0d, 0x7e, 0x84, 0x76, 0x14, 0x27, 0x18, 0x9d, 0x82, 0x97, 0x67, 0xf1, 0x7b, 0xcc, 0x85,
-	0xef, 0x9c, 0x1c, 0x84, 0xfd, 0x4b, 0x38, 0xcf, 0x97, 0xe7, 0x3f, 0x6b, 0x28, 0xda, 0x9a, 0xd0,
-	0x67, 0xd0, 0x4b, 0xb1, 0x90, 0x77, 0x71, 0x56, 0x60, 0xbf, 0x75, 0xe2, 0x84, 0x83, 0xa8, 0x02,
-	0x82, 0x5f, 0xa1, 0xff, 0x12, 0xdf, 0x13, 0x8a, 0x53, 0xed, 0xf2, 0x2b, 0x18, 0xf2, 0x38, 0xc1,
-	0x22, 0xc2, 0x9b, 0x98, 0x50, 0x42, 0x57, 0x3a, 0xf6, 0x20, 0x6a, 0xa0, 0xe8, 0x19, 0xb8, 0xa5,
-	0x7f, 0xbf, 0xa5, 0x23, 0x7f, 0xac, 0x22, 0x1b, 0x47, 0x26, 0x01, 0x43, 0x08, 0xbe, 0x06, 0x2f,
-	0xc2, 0xa2, 0xc8, 0xa4, 0x40, 0x01, 0xb8, 0x5c, 0x1f, 0xed, 0x7c, 0x4b, 0x63, 0x64, 0x2c, 0xc1,
-	0x12, 0xdc, 0x12, 0x51, 0x6c, 0x13, 0x43, 0xe5, 0x50, 0xaf, 0xce, 0x58, 0xd0, 0xa7, 0x3b, 0x8f,
-	0xaa, 0xb2, 0xd6, 0xd6, 0x4b, 0xbd, 0xe8, 0x83, 0x66, 0xd1, 0xdf, 0x43, 0x7f, 0xc1, 0x0a, 0x4e,
-	0xe3, 0x0d, 0xa6, 0x52, 0xa0, 0x73, 0x00, 0xb9, 0xbb, 0x9a, 0xd4, 0x86, 0x2a, 0x58, 0x45, 0x8a,
-	0x2c, 0x46, 0xf0, 0x14, 0x8e, 0x2a, 0xcb, 0x2c, 0xde, 0x60, 0x81, 0x10, 0xb4, 0xd5, 0x55, 0x3f,
-	0xee, 0x45, 0xfa, 0x1c, 0x9c, 0xc2, 0xb0, 0xa2, 0xcd, 0x73, 0x9c, 0x58, 0x2c, 0x67, 0xc7, 0xfa,
-	0xcd, 0x81, 0x41, 0xad, 0x71, 0xe8, 0x18, 0x3a, 0x09, 0xcb, 0x18, 0x37, 0xb4, 0xf2, 0xf2, 0xc8,
-	0x64, 0x5a, 0x8f, 0x4e, 0xe6, 0x3b, 0x38, 0x62, 0x72, 0x8d, 0xf9, 0x84, 0x51, 0x89, 0x69, 0xaa,
-	0xc4, 0x71, 0xb0, 0x6f, 0x44, 0x4d, 0x66, 0x90, 0x01, 0x94, 0xcd, 0xd7, 0xe9, 0x06, 0xe0, 0xae,
-	0xb4, 0x41, 0x67, 0x32, 0x2c, 0x07, 0x70, 0xa3, 0x91, 0xc8, 0x58, 0x54, 0x49, 0x59, 0x2c, 0xa4,
-	0x49, 0x46, 0x9f, 0x55, 0xaa, 0xb2, 0xd6, 0x1f, 0x3d, 0x81, 0x5e, 0xd4, 0x40, 0x83, 0x1f, 0xc0,
-	0x35, 0x25, 0x3f, 0xd2, 0x18, 0x2b, 0x7a, 0x6b, 0x5f, 0xf4, 0xe0, 0x02, 0xbc, 0xb9, 0x8c, 0xb9,
-	0xc4, 0x1c, 0x9d, 0xc2, 0x20, 0x61, 0x05, 0x95, 0x29, 0x7b, 0xa0, 0x0b, 0x62, 0x7c, 0x0d, 0xa2,
-	0x3a, 0x18, 0x5c, 0x41, 0x47, 0xe9, 0x9c, 0x2b, 0x81, 0x94, 0x12, 0x9a, 0x15, 0x1b, 0x43, 0xad,
-	0x00, 0x34, 0x86, 0x6e, 0x4a, 0x84, 0x8c, 0x69, 0xb2, 0xfd, 0x64, 0x76, 0xf7, 0xe0, 0x4f, 0x07,
-	0xa0, 0x9a, 0xeb, 0xa3, 0xa9, 0xff, 0xab, 0xfa, 0xd0, 0x73, 0x68, 0x6f, 0x58, 0x8a, 0xfd, 0xb6,
-	0x2e, 0xeb, 0xf3, 0xba, 0xd0, 0xac, 0xe3, 0x2d, 0x4b, 0x71, 0xa4, 0xa9, 0xe8, 0x04, 0xfa, 0x65,
-	0x72, 0x13, 0x55, 0x8d, 0xdf, 0xd1, 0x2e, 0x6d, 0xa8, 0x12, 0x8d, 0xab, 0x15, 0x68, 0x44, 0x53,
-	0x7d, 0x70, 0xde, 0xde, 0x0f, 0x2e, 0xb4, 0x65, 0xaa, 0x62, 0xa2, 0x43, 0xe8, 0xbe, 0x7c, 0x3d,
-	0x5f, 0x5c, 0xcd, 0x26, 0xd3, 0xd1, 0x47, 0xa8, 0x0b, 0xed, 0xc5, 0xeb, 0xdb, 0xe9, 0xc8, 0x09,
-	0xfe, 0x72, 0x60, 0x74, 0x47, 0xc4, 0x84, 0xd1, 0x7b, 0xb2, 0x2a, 0x78, 0x2c, 0x09, 0xa3, 0xaa,
-	0x55, 0x6b, 0x26, 0xca, 0x31, 0x97, 0x3d, 0xd8, 0xdd, 0xd5, 0x0a, 0x7b, 0x47, 0x84, 0x36, 0xb5,
-	0xca, 0x15, 0x66, 0xae, 0xe8, 0x09, 0xc0, 0x7d, 0x91, 0x65, 0x22, 0xe1, 0x18, 0x53, 0xdd, 0xa2,
-	0x6e, 0x64, 0x21, 0x28, 0x84, 0x23, 0x8e, 0x05, 0xcb, 0x0a, 0x15, 0xe3, 0x17, 0x92, 0xca, 0xb5,
-	0x6e, 0xd7, 0x20, 0x6a, 0xc2, 0xe8, 0x0c, 0x46, 0x15, 0xf4, 0x0a, 0x93, 0xd5, 0x7a, 0xdb, 0x9f,
-	0x7f, 0xe0, 0x2a, 0xea, 0x86, 0xbd, 0x23, 0x74, 0xf5, 0x96, 0x12, 0xe9, 0xbb, 0x9a, 0x65, 0x21,
-	0xca, 0xae, 0xc6, 0x7c, 0x1d, 0x27, 0x92, 0x71, 0xdf, 0x2b, 0xed, 0x15, 0x72, 0xf6, 0x0c, 0xdc,
-	0x52, 0x80, 0xaa, 0x29, 0xb7, 0x57, 0x6f, 0x54, 0x7b, 0x00, 0xdc, 0xeb, 0xa9, 0x3e, 0x3b, 0xa8,
-	0x07, 0x9d, 0x9f, 0x16, 0xaf, 0xa6, 0xd1, 0xa8, 0x75, 0xf9, 0xfb, 0x01, 0x78, 0xf3, 0x72, 0xd9,
-	0xa3, 0x0b, 0x18, 0xcc, 0xf0, 0x83, 0xa5, 0x99, 0xc6, 0x72, 0x19, 0x37, 0xee, 0xe8, 0x09, 0x78,
-	0x33, 0xfc, 0xa0, 0x17, 0x72, 0x57, 0x4f, 0x2c, 0x4e, 0xf0, 0xb8, 0xa7, 0x4e, 0xfa, 0xa7, 0x02,
-	0x05, 0xd0, 0xd3, 0xb2, 0xd7, 0x8c, 0x0a, 0x1f, 0x5b, 0x1b, 0x12, 0x85, 0xd0, 0xd3, 0x3f, 0x27,
-	0x9a, 0x33, 0x52, 0x06, 0xfb, 0xd7, 0xc5, 0xf6, 0x76, 0x01, 0x87, 0xdb, 0x91, 0xe2, 0x3b, 0x22,
-	0xd0, 0xb1, 0x32, 0x35, 0xe7, 0x6c, 0x3f, 0x38, 0x03, 0xb8, 0xc1, 0x72, 0xbb, 0xd4, 0x87, 0x95,
-	0xa6, 0xd4, 0xd6, 0x18, 0x5b, 0x1a, 0xfb, 0xc6, 0x41, 0x2f, 0x00, 0xdd, 0x60, 0xd9, 0x5c, 0x97,
-	0x56, 0xce, 0x9f, 0xd4, 0x6b, 0x2f, 0xed, 0xcf, 0xe1, 0xf8, 0x06, 0xcb, 0x49, 0xc1, 0x39, 0xa6,
-	0xd6, 0x63, 0xfb, 0x5d, 0xb3, 0x67, 0x2f, 0x60, 0xf8, 0x86, 0xc5, 0xa9, 0xfd, 0x65, 0xd6, 0x19,
-	0x3a, 0xb9, 0xe6, 0xab, 0x10, 0xfa, 0xf3, 0x35, 0x7b, 0xd8, 0x57, 0x4b, 0x15, 0xef, 0xf2, 0x8f,
-	0x16, 0xb8, 0x77, 0x44, 0x14, 0x71, 0x86, 0xce, 0x3e, 0x34, 0x4f, 0xab, 0x57, 0x1f, 0x1a, 0xe5,
-	0x97, 0xf6, 0x28, 0xfb, 0x0a, 0x37, 0x0b, 0xcd, 0x26, 0xfd, 0xf7, 0x59, 0x9e, 0x02, 0xbc, 0xcd,
-	0xd3, 0x58, 0xe2, 0x4a, 0x1a, 0x7a, 0xdf, 0x59, 0x9c, 0xd0, 0x51, 0xac, 0x6b, 0x42, 0x89, 0x58,
-	0x57, 0x51, 0x4d, 0x07, 0x6c, 0x5f, 0x4f, 0xeb, 0xbd, 0xd9, 0x47, 0xfb, 0xdf, 0xf2, 0xf9, 0x42,
-	0x2d, 0x6d, 0x96, 0x2b, 0xae, 0x35, 0xcf, 0xea, 0xb8, 0x74, 0xf5, 0xbf, 0xa3, 0x6f, 0xff, 0x0e,
-	0x00, 0x00, 0xff, 0xff, 0x20, 0xa3, 0xf8, 0x93, 0x2e, 0x09, 0x00, 0x00,
+	// 2627 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0xcd, 0x73, 0x1c, 0x47,
+	0x15, 0xcf, 0xec, 0xa7, 0xf6, 0xed, 0x87, 0xc6, 0x1d, 0x61, 0x26, 0x22, 0x38, 0xca, 0x60, 0x92,
+	0x8d, 0x8b, 0xd8, 0xb1, 0x1d, 0x08, 0x45, 0x08, 0x95, 0x8d, 0xb4, 0x56, 0xb6, 0x4a, 0x92, 0x45,
+	0x6b, 0xed, 0x14, 0x05, 0x55, 0x64, 0x3c, 0xd3, 0x5a, 0x4d, 0x31, 0x3b, 0xb3, 0x99, 0xe9, 0x95,
+	0xac, 0x4b, 0x8e, 0x1c, 0x28, 0x2e, 0xdc, 0x38, 0xc0, 0x81, 0xe2, 0xce, 0xdf, 0xc3, 0x1f, 0xc1,
+	0x95, 0x3b, 0xf5, 0x5e, 0xf7, 0xec, 0xf4, 0xac, 0x76, 0x65, 0x19, 0xb8, 0x48, 0xfd, 0x7e, 0xfd,
+	0xfa, 0xf5, 0xeb, 0xd7, 0xef, 0xab, 0x67, 0xa1, 0x9b, 0xcd, 0xd2, 0x30, 0x96, 0xd9, 0xfd, 0x59,
+	0x9a, 0xc8, 0x84, 0x55, 0x66, 0x2f, 0xdc, 0x26, 0xd4, 0x87, 0xd3, 0x99, 0xbc, 0x74, 0xfb, 0xd0,
+	0x19, 0xbc, 0x48, 0x52, 0x79, 0x28, 0xb2, 0xcc, 0x9b, 0x08, 0xe6, 0x40, 0x73, 0xaa, 0x86, 0x8e,
+	0xb5, 0x63, 0xf5, 0x5b, 0x3c, 0x27, 0xdd, 0x7f, 0x5b, 0x50, 0xe3, 0x9e, 0x2f, 0xd8, 0x5d, 0x68,
+	0xce, 0x22, 0xef, 0x52, 0xa4, 0x99, 0x63, 0xed, 0x54, 0xfb, 0xed, 0x47, 0x70, 0x7f, 0xf6, 0xe2,
+	0xfe, 0x31, 0x41, 0x3c, 0x9f, 0x62, 0x6f, 0x43, 0x2b, 0x10, 0x99, 0x7c, 0xee, 0x45, 0x73, 0xe1,
+	0x54, 0x76, 0xac, 0x7e, 0x97, 0x17, 0x00, 0xeb, 0xc3, 0xc6, 0x99, 0x17, 0x07, 0xa1, 0xef, 0xcd,
+	0x9c, 0x2a, 0x09, 0xe9, 0xa0, 0x90, 0x2f, 0x35, 0xc6, 0x17, 0xb3, 0xec, 0xe7, 0xf0, 0x56, 0x3e,
+	0x7e, 0x92, 0x26, 0xd3, 0x63, 0x91, 0x66, 0x49, 0xec, 0x45, 0x5f, 0x88, 0x4c, 0x66, 0x4e, 0x6d,
+	0xc7, 0xea, 0x6f, 0xf0, 0xf5, 0x0c, 0xec, 0x0e, 0xd4, 0xa5, 0xf0, 0xa6, 0x99, 0x53, 0xa7, 0x4d,
+	0x36, 0x70, 0x93, 0xb1, 0xf0, 0xa6, 0x5c, 0xc1, 0xec, 0x5d, 0x68, 0x4c, 0xce, 0x12, 0x14, 0xd5,
+	0x20, 0x86, 0x16, 0x32, 0xec, 0x23, 0xc2, 0xf5, 0x84, 0xfb, 0x7b, 0x0b, 0xea, 0x84, 0xb0, 0x3e,
+	0x34, 0xb2, 0x64, 0x9e, 0xfa, 0xca, 0x34, 0xbd, 0x47, 0xf6, 0x82, 0xf9, 0xfe, 0x09, 0xe1, 0x5c,
+	0xcf, 0xb3, 0x3b, 0x00, 0xca, 0x0e, 0x47, 0xde, 0x54, 0x9d, 0xbe, 0xc5, 0x0d, 0xc4, 0x7d, 0x0c,
+	0x0d, 0xb5, 0x82, 0xdd, 0x82, 0xee, 0xf1, 0x90, 0x9f, 0x3c, 0x3d, 0x1a, 0x1c, 0xfc, 0xf6, 0x8b,
+	0xe1, 0xc9, 0xd8, 0x7e, 0x83, 0x01, 0x34, 0x0e, 0x86, 0x83, 0xbd, 0x21, 0xb7, 0x2d, 0x1c, 0xf3,
+	0xe1, 0xee, 0x53, 0xbe, 0x67, 0x57, 0xdc, 0x5f, 0x40, 0x0d, 0x55, 0x67, 0x0c, 0x6a, 0xb1, 0x37,
+	0x55, 0x4a, 0xb4, 0x38, 0x8d, 0x99, 0x0b, 0x8d, 0x34, 0x0c, 0xf0, 0x4a, 0x2a, 0x57, 0xae, 0x44,
+	0xcf, 0xb8, 0xdf, 0x40, 0xf5, 0x40, 0x4c, 0xd8, 0x0e, 0xd4, 0x09, 0xa0, 0xf5, 0x65, 0x4e, 0x35,
+	0xc1, 0xb6, 0x61, 0x23, 0x08, 0x33, 0xe9, 0xc5, 0x7e, 0x7e, 0x73, 0x0b, 0x1a, 0x37, 0x97, 0xe1,
+	0x54, 0x38, 0x55, 0xc2, 0x69, 0x8c, 0x3e, 0x13, 0x89, 0xc9, 0x18, 0x61, 0xbc, 0x90, 0x0a, 0xcf,
+	0x49, 0xf7, 0x4f, 0x16, 0xb4, 0x87, 0x51, 0x38, 0x0d, 0x63, 0x4f, 0x86, 0x49, 0x8c, 0x4e, 0xa1,
+	0xad, 0x30, 0x9f, 0xd2, 0xfe, 0x5d, 0x5e, 0x00, 0x78, 0x08, 0x45, 0x38, 0x95, 0x2b, 0xaa, 0xe9,
+	0x19, 0xb6, 0x05, 0xf5, 0x59, 0xe4, 0xf9, 0xb9, 0x02, 0x8a, 0x58, 0x68, 0x55, 0x33, 0xb4, 0xba,
+	0x0d, 0x0d, 0xdf, 0x9b, 0x4f, 0xce, 0xa4, 0x53, 0x27, 0x2f, 0xd1, 0x94, 0x2b, 0xa1, 0x76, 0x72,
+	0xe1, 0xcd, 0x5e, 0xa1, 0x8b, 0x0d, 0xd5, 0x48, 0x4c, 0xf4, 0xf1, 0xab, 0x91, 0x69, 0xb7, 0xea,
+	0x4d, 0xec, 0x56, 0x2b, 0xdb, 0xcd, 0x3d, 0x86, 0x8d, 0xdc, 0xb9, 0xd1, 0x3b, 0x32, 0xe9, 0xa5,
+	0x72, 0x4f, 0x44, 0xde, 0xa5, 0xde, 0xda, 0x40, 0xd8, 0x5d, 0xe8, 0xe6, 0xeb, 0xbe, 0x48, 0xe2,
+	0x79, 0xa6, 0xb5, 0x28, 0x83, 0xee, 0xd7, 0xd0, 0xde, 0x13, 0xa7, 0x61, 0x2c, 0x02, 0x8a, 0xca,
+	0xf7, 0xa0, 0x97, 0x7a, 0xbe, 0xc8, 0xb8, 0x98, 0x7a, 0x61, 0x1c, 0xc6, 0x13, 0x2d, 0x78, 0x09,
+	0x65, 0x1f, 0x18, 0x46, 0x46, 0x4f, 0xb9, 0x85, 0xe7, 0xd0, 0x82, 0xca, 0xb6, 0x76, 0x3f, 0x84,
+	0x26, 0x17, 0xd9, 0x3c, 0x92, 0x19, 0xf9, 0x17, 0x0d, 0xcd, 0x90, 0x57, 0x93, 0x5c, 0xcf, 0xb8,
+	0x7f, 0x69, 0x40, 0x43, 0x41, 0xc6, 0x4d, 0x5a, 0x6b, 0x6f, 0xf2, 0xf6, 0x42, 0x64, 0x85, 0x9c,
+	0x46, 0x53, 0xe5, 0xc4, 0x51, 0x5d, 0x4e, 0x1c, 0x2e, 0x74, 0x66, 0x46, 0x84, 0xeb, 0x0c, 0x50,
+	0xc2, 0x94, 0x64, 0x3f, 0x49, 0x83, 0xfc, 0xe6, 0x15, 0xc5, 0x7a, 0x50, 0x09, 0x03, 0xa7, 0x41,
+	0x22, 0x2b, 0x61, 0x40, 0x7c, 0x9e, 0x2f, 0x46, 0x81, 0xd3, 0x24, 0x4c, 0x53, 0xb8, 0x47, 0x10,
+	0x66, 0xdf, 0xcc, 0xbd, 0x28, 0x3c, 0x0d, 0x45, 0xe0, 0x6c, 0xa8, 0x3d, 0x4c, 0x8c, 0xfd, 0x04,
+	0x6e, 0x17, 0xb4, 0x4f, 0xde, 0xcd, 0x85, 0x97, 0x25, 0xb1, 0xd3, 0xa2, 0xb0, 0x5c, 0x33, 0x8b,
+	0xd7, 0x44, 0x37, 0x8d, 0xe1, 0x91, 0x49, 0x6f, 0x3a, 0x73, 0x60, 0xc7, 0xea, 0x57, 0xf9, 0x12,
+	0x8a, 0x1e, 0x1d, 0x79, 0xb1, 0x70, 0xda, 0xca, 0xa3, 0x71, 0x8c, 0xbe, 0xef, 0x27, 0x51, 0x92,
+	0x3a, 0x1d, 0xda, 0x42, 0x11, 0xac, 0x0f, 0xad, 0x64, 0x36, 0x4b, 0x62, 0x11, 0xcb, 0xcc, 0xe9,
+	0x5e, 0x89, 0xfe, 0x62, 0x12, 0xfd, 0xca, 0x4f, 0xe6, 0xb1, 0x0c, 0x92, 0x8b, 0x98, 0xa2, 0xb5,
+	0xa7, 0xfc, 0xaa, 0x04, 0xb2, 0x1d, 0x68, 0x9f, 0x7a, 0x51, 0x26, 0x4e, 0x50, 0xa1, 0xcc, 0xd9,
+	0x24, 0x1e, 0x13, 0x42, 0xff, 0x0d, 0xc4, 0x79, 0xe8, 0x8b, 0xf1, 0xe5, 0x4c, 0x38, 0xb6, 0xca,
+	0x6e, 0x05, 0xc2, 0xde, 0x81, 0x7a, 0x36, 0x8b, 0x42, 0xe9, 0xdc, 0x2a, 0x72, 0xea, 0x09, 0x02,
+	0x5c, 0xe1, 0xec, 0x2e, 0xd4, 0x25, 0xda, 0xda, 0x61, 0xc4, 0xd0, 0xa3, 0xac, 0x8c, 0xc0, 0x71,
+	0x12, 0xc6, 0x92, 0xab, 0x49, 0x74, 0x84, 0x54, 0x78, 0xfe, 0x99, 0x08, 0x06, 0xd2, 0x79, 0x53,
+	0x39, 0xc2, 0x02, 0xa0, 0x59, 0xef, 0x42, 0xf9, 0x9b, 0xb3, 0x45, 0x1e, 0x54, 0x00, 0xa5, 0xfa,
+	0xf2, 0x9d, 0x1d, 0xeb, 0x9a, 0xfa, 0xf2, 0x3d, 0xa8, 0x45, 0x62, 0x92, 0x39, 0xb7, 0x49, 0x95,
+	0x26, 0x72, 0x1d, 0x88, 0x09, 0x27, 0xb0, 0xc8, 0x36, 0xdf, 0x35, 0xb3, 0xcd, 0x1d, 0x00, 0xa1,
+	0x93, 0x9a, 0x08, 0x1c, 0x87, 0xbc, 0xc3, 0x40, 0x70, 0x15, 0xd5, 0x0e, 0xe7, 0x2d, 0x9a, 0x52,
+	0x84, 0xfb, 0x09, 0xd4, 0xc9, 0x08, 0xa5, 0x34, 0x61, 0xad, 0x49, 0xaf, 0x95, 0x22, 0x91, 0xb9,
+	0x1c, 0xa0, 0x30, 0xce, 0x82, 0xc3, 0x2a, 0x38, 0xae, 0x4d, 0xd8, 0x5b, 0x78, 0x19, 0x42, 0x04,
+	0x14, 0x4a, 0x15, 0xae, 0x08, 0xf7, 0x8f, 0x16, 0x80, 0x32, 0xd5, 0x30, 0x08, 0x25, 0x7a, 0xa5,
+	0x4c, 0xe6, 0x29, 0x96, 0x92, 0x58, 0x1e, 0x15, 0xc5, 0x65, 0x09, 0xc5, 0x8d, 0x54, 0x94, 0x8e,
+	0x82, 0x7c, 0xa3, 0x9c, 0x36, 0x62, 0xbe, 0x7a, 0x7d, 0xcc, 0x53, 0x94, 0xd4, 0x48, 0xbe, 0xa6,
+	0xdc, 0x97, 0x00, 0x83, 0x79, 0x10, 0xca, 0x61, 0x2c, 0xd3, 0x4b, 0xbc, 0x5a, 0xb9, 0x08, 0x0f,
+	0x8b, 0xc2, 0xa3, 0x00, 0x50, 0x86, 0xe7, 0x63, 0x44, 0xe9, 0xba, 0xaa, 0xa9, 0x92, 0x6e, 0xd5,
+	0x25, 0xdd, 0x1c, 0x68, 0x06, 0x42, 0x7a, 0x61, 0x94, 0xe9, 0x8d, 0x73, 0xd2, 0xfd, 0xbb, 0x85,
+	0x49, 0x8b, 0xd2, 0x83, 0x99, 0xe3, 0xac, 0xd5, 0x39, 0x8e, 0x3d, 0x84, 0xda, 0x34, 0x09, 0x94,
+	0x95, 0x7b, 0x8f, 0xbe, 0x4f, 0x8e, 0xbb, 0x30, 0x91, 0x31, 0x3c, 0x4c, 0x02, 0xc1, 0x89, 0x75,
+	0x85, 0x6d, 0xab, 0xeb, 0x6c, 0xeb, 0x7b, 0x52, 0x4c, 0x92, 0xf4, 0x52, 0x2b, 0xb9, 0xa0, 0xdd,
+	0x03, 0x00, 0xa5, 0xe4, 0xc9, 0x4c, 0xf8, 0xa8, 0xe8, 0x44, 0xc4, 0x79, 0x09, 0xef, 0x29, 0x45,
+	0xf7, 0x09, 0xe1, 0x7a, 0xa6, 0x24, 0xad, 0xb2, 0x24, 0xed, 0x33, 0x68, 0x17, 0x9a, 0x66, 0xec,
+	0x3e, 0x40, 0xa1, 0x8a, 0x63, 0x19, 0x21, 0xb9, 0x40, 0xb9, 0xc1, 0xe1, 0x0e, 0x60, 0x73, 0x5c,
+	0x52, 0x3d, 0x33, 0x5a, 0x92, 0xea, 0xa2, 0x25, 0xd9, 0x86, 0x0d, 0x2f, 0xf5, 0xcf, 0xc2, 0x73,
+	0x11, 0x50, 0xa9, 0x69, 0xf1, 0x05, 0xed, 0x7e, 0x0e, 0xbd, 0x42, 0x04, 0x9d, 0x69, 0x55, 0x53,
+	0x53, 0x96, 0x80, 0xa1, 0x54, 0x48, 0x38, 0x00, 0x18, 0xbe, 0x9c, 0x25, 0xa9, 0x5a, 0x7d, 0x53,
+	0xff, 0xbd, 0x0d, 0x8d, 0xd3, 0x24, 0x9d, 0x7a, 0x32, 0xf7, 0x1d, 0x45, 0xb9, 0x7f, 0xb3, 0xa0,
+	0x75, 0x8c, 0x3d, 0xf2, 0x6b, 0x49, 0xdb, 0x82, 0xba, 0x0c, 0x65, 0x94, 0x37, 0x78, 0x8a, 0xc0,
+	0x93, 0x04, 0x9e, 0xcc, 0x6f, 0x99, 0xc6, 0x58, 0x51, 0x7c, 0x91, 0x4a, 0x55, 0x0c, 0x44, 0xde,
+	0xb7, 0x96, 0xb0, 0xa5, 0x9e, 0xb1, 0x7e, 0xa5, 0x67, 0xfc, 0x16, 0x60, 0x34, 0xfd, 0x7f, 0x9d,
+	0x18, 0x23, 0xc2, 0x4f, 0x62, 0x89, 0x37, 0x8e, 0x8a, 0x76, 0x78, 0x4e, 0xe2, 0x8a, 0x20, 0xbd,
+	0xe4, 0xf3, 0x58, 0x6b, 0xa9, 0x29, 0xf7, 0x1c, 0x3a, 0x6a, 0x7f, 0x2e, 0xf0, 0x2f, 0xde, 0x4e,
+	0x48, 0xb4, 0x08, 0xf2, 0x34, 0x96, 0xd3, 0x54, 0xc3, 0xe7, 0xb3, 0x88, 0x4e, 0xa6, 0x2f, 0xbf,
+	0x00, 0xd0, 0x6e, 0x22, 0x4d, 0x93, 0x94, 0x3a, 0xff, 0x16, 0x57, 0xc4, 0xda, 0x7d, 0xbf, 0x86,
+	0x8e, 0xba, 0x69, 0x11, 0x3c, 0x09, 0x23, 0xf2, 0x8a, 0xd3, 0x30, 0x12, 0xc6, 0x99, 0x17, 0x34,
+	0xce, 0x4d, 0xc3, 0xa9, 0xaa, 0x4b, 0xda, 0xeb, 0x73, 0x7a, 0xfd, 0x89, 0xdd, 0xcf, 0xc1, 0x36,
+	0x5c, 0x5d, 0xe0, 0xff, 0x95, 0xfe, 0xe8, 0x40, 0x33, 0x16, 0x17, 0x46, 0x4b, 0x9f, 0x93, 0xee,
+	0x1f, 0x2c, 0xe8, 0x96, 0x7a, 0xa8, 0xa2, 0x56, 0x5b, 0x66, 0xad, 0xbe, 0xda, 0xa4, 0x55, 0x56,
+	0x36, 0x69, 0x9f, 0xc2, 0x66, 0x22, 0xcf, 0x44, 0xba, 0x4b, 0x1a, 0x52, 0x5f, 0x5f, 0x5d, 0xd7,
+	0xad, 0x2d, 0x73, 0xba, 0xff, 0x58, 0xe4, 0xf6, 0x1b, 0x67, 0x0b, 0xea, 0x36, 0x32, 0x99, 0x97,
+	0x1d, 0x1c, 0xdf, 0x38, 0x6f, 0xd9, 0x50, 0x95, 0xc9, 0x4c, 0x37, 0xbc, 0x38, 0xc4, 0x9b, 0x4c,
+	0x4e, 0x4f, 0x33, 0xa1, 0x3a, 0xef, 0x2e, 0xd7, 0x94, 0xd1, 0x6f, 0x35, 0xcc, 0x7e, 0xcb, 0xfd,
+	0x0d, 0x34, 0xb4, 0xd5, 0xd6, 0x3c, 0x6d, 0xb4, 0xfe, 0x95, 0x1b, 0x65, 0xbb, 0xea, 0x52, 0xb6,
+	0x7b, 0x00, 0x4d, 0xea, 0x5b, 0x44, 0x7a, 0xb5, 0x01, 0xb2, 0x56, 0x34, 0x40, 0xee, 0x00, 0xea,
+	0xd8, 0x51, 0xa7, 0xaf, 0x78, 0x21, 0x5c, 0x53, 0x74, 0xdd, 0x7f, 0xd6, 0x00, 0x0a, 0x97, 0x5a,
+	0x79, 0xac, 0xeb, 0xdb, 0xdc, 0xbc, 0xce, 0xd4, 0x6e, 0x5e, 0x67, 0x76, 0xa0, 0xad, 0x94, 0xdb,
+	0xc5, 0xd3, 0x68, 0xd3, 0x9b, 0x50, 0xe1, 0x93, 0x0d, 0x15, 0x77, 0x44, 0x18, 0x65, 0xaf, 0xb9,
+	0xae, 0xb5, 0xc7, 0xfc, 0x85, 0x3e, 0xc1, 0xf3, 0xfa, 0xba, 0x41, 0xc2, 0x4b, 0x18, 0xe6, 0x2f,
+	0xa2, 0xd5, 0x0d, 0xb7, 0x88, 0xc3, 0x40, 0xb0, 0xe9, 0xf3, 0xb0, 0xc6, 0x3b, 0x50, 0x54, 0x98,
+	0xa2, 0xe8, 0x73, 0x35, 0x59, 0xca, 0xf9, 0xed, 0x72, 0xce, 0x47, 0x93, 0x25, 0xe7, 0x22, 0xbd,
+	0x48, 0x43, 0x29, 0xa8, 0x07, 0xde, 0xe0, 0x05, 0x60, 0xf4, 0x1f, 0x57, 0x9b, 0x60, 0x3d, 0xc3,
+	0xfa, 0xb0, 0xa9, 0x4e, 0x94, 0x8d, 0xe2, 0x43, 0x21, 0x31, 0xae, 0x7a, 0x24, 0x67, 0x19, 0xc6,
+	0x13, 0x67, 0x17, 0xde, 0x6c, 0x2f, 0xbf, 0xe1, 0xcd, 0x9d, 0x2a, 0x9e, 0xd8, 0xc4, 0xd0, 0xe2,
+	0xd4, 0xb5, 0x3f, 0x55, 0xce, 0x6e, 0x2b, 0x8b, 0x1b, 0x90, 0xfb, 0x4b, 0xe8, 0x95, 0xef, 0x8a,
+	0x75, 0x60, 0x63, 0x6f, 0x74, 0x32, 0x1e, 0x1c, 0xed, 0x0e, 0xed, 0x37, 0xd8, 0x06, 0xd4, 0xc6,
+	0xa3, 0xc3, 0xa1, 0x6d, 0xb1, 0x16, 0xd4, 0xf9, 0xf0, 0x60, 0xf0, 0x2b, 0xbb, 0xc2, 0x36, 0xa1,
+	0x3d, 0x3c, 0x18, 0x1d, 0x8e, 0x8e, 0x06, 0xe3, 0xd1, 0xd3, 0x23, 0xbb, 0xca, 0xda, 0xd0, 0x3c,
+	0x7e, 0xc6, 0x4f, 0x9e, 0x8d, 0xc6, 0x76, 0xcd, 0xfd, 0x73, 0x05, 0xec, 0xe7, 0x61, 0xb6, 0x9b,
+	0xc4, 0xa7, 0xe1, 0x64, 0x9e, 0x7a, 0x79, 0xef, 0x83, 0x3d, 0xa6, 0x99, 0x13, 0x73, 0x1a, 0xb3,
+	0xd6, 0x79, 0x98, 0x99, 0x59, 0x4b, 0x93, 0x78, 0x63, 0xa7, 0xf3, 0x28, 0xca, 0xfc, 0x54, 0x88,
+	0x98, 0x7c, 0x70, 0x83, 0x1b, 0x88, 0xb6, 0x56, 0x12, 0xcd, 0x71, 0x8f, 0xaf, 0xc2, 0x40, 0x9e,
+	0xe9, 0x28, 0x5f, 0x86, 0xd9, 0x3d, 0xb0, 0x0b, 0xe8, 0x4b, 0x11, 0xe6, 0xaf, 0xee, 0x2e, 0xbf,
+	0x82, 0xe3, 0xae, 0xd3, 0xe4, 0x3c, 0x8c, 0x27, 0xcf, 0xe2, 0x50, 0xea, 0x4c, 0x60, 0x20, 0x38,
+	0x8f, 0x71, 0xf4, 0xc4, 0xf3, 0x65, 0x92, 0xea, 0x97, 0x99, 0x81, 0xe0, 0x79, 0x2e, 0xc4, 0x8b,
+	0xe3, 0x24, 0x95, 0xda, 0x0d, 0x73, 0xd2, 0xfd, 0x16, 0xd8, 0xf3, 0x30, 0x9b, 0x7b, 0x11, 0x17,
+	0x93, 0x30, 0x93, 0xda, 0x36, 0x0e, 0x34, 0xbd, 0x20, 0x48, 0x45, 0x96, 0xe5, 0x5f, 0xb4, 0x34,
+	0x89, 0x61, 0x89, 0x75, 0x25, 0xcf, 0x7a, 0x38, 0x66, 0x3f, 0xc3, 0x14, 0x61, 0x98, 0x56, 0x37,
+	0xb3, 0x5b, 0xe8, 0x4c, 0xcb, 0x66, 0xe7, 0x65, 0x56, 0xf7, 0x0c, 0x3a, 0x83, 0x38, 0x4e, 0xe6,
+	0xb1, 0x2f, 0x28, 0xec, 0x5d, 0xe8, 0xa4, 0x86, 0x26, 0xb4, 0x7d, 0x87, 0x97, 0x30, 0xcc, 0x89,
+	0x99, 0x88, 0xe5, 0x40, 0x69, 0x51, 0xe5, 0x9a, 0x42, 0x5f, 0xcf, 0xc2, 0x49, 0xec, 0xc9, 0x79,
+	0x2a, 0x74, 0xbd, 0x2a, 0x00, 0xb7, 0x0f, 0xa0, 0x4e, 0x4a, 0x19, 0xfe, 0x9a, 0xdb, 0x77, 0x5f,
+	0xe6, 0x9c, 0xa3, 0xf8, 0x34, 0xb9, 0xc6, 0x16, 0xf8, 0x4c, 0x90, 0xaa, 0x5a, 0x53, 0xbd, 0x22,
+	0xe2, 0x7f, 0xb2, 0xc6, 0x43, 0x68, 0xaa, 0x9d, 0x33, 0xf6, 0x1e, 0x34, 0xce, 0x69, 0x68, 0x76,
+	0x97, 0x85, 0x5a, 0x5c, 0xcf, 0xba, 0x77, 0xa1, 0x77, 0xec, 0x85, 0x69, 0x18, 0x4f, 0xb8, 0xf8,
+	0x66, 0x8e, 0x4f, 0x79, 0x06, 0x35, 0x1f, 0xf3, 0xa0, 0xce, 0x9c, 0x38, 0x76, 0x3f, 0x83, 0xa6,
+	0xe6, 0x42, 0xad, 0x65, 0xf2, 0x3b, 0x11, 0xe7, 0x55, 0x96, 0x08, 0xf6, 0x36, 0xd4, 0xd2, 0x24,
+	0xca, 0x9b, 0x74, 0xfa, 0xe6, 0xc7, 0x93, 0x48, 0x70, 0x42, 0xdd, 0x5f, 0x43, 0x5b, 0x2f, 0xdf,
+	0xc5, 0x80, 0x5c, 0xb1, 0xc3, 0xf5, 0x02, 0xd0, 0xdc, 0xe7, 0x5e, 0x14, 0x06, 0x4f, 0x92, 0x34,
+	0x7f, 0x68, 0xe4, 0xf4, 0xbd, 0x0f, 0xa0, 0xa1, 0x4a, 0x13, 0x86, 0xf6, 0xe1, 0xe0, 0x60, 0xa8,
+	0xbe, 0xe7, 0x3d, 0x19, 0xd2, 0x98, 0xc2, 0xfc, 0xe9, 0xf8, 0xcb, 0x21, 0xb7, 0x2b, 0xf7, 0xee,
+	0x41, 0x0d, 0x85, 0xe2, 0xf4, 0xf3, 0xd1, 0xf0, 0xab, 0x21, 0xb7, 0xdf, 0xc0, 0x48, 0x3f, 0x19,
+	0x0f, 0xf8, 0x98, 0xbe, 0xfd, 0xb5, 0xa0, 0x3e, 0xd8, 0x3b, 0x1c, 0x1d, 0xd9, 0x95, 0x47, 0xff,
+	0xc2, 0x09, 0xf5, 0x11, 0x97, 0x3d, 0x80, 0xee, 0x91, 0xb8, 0x30, 0xaa, 0xcb, 0x52, 0xaf, 0xbe,
+	0xbd, 0x44, 0xb3, 0x3b, 0xd0, 0x3c, 0x12, 0x17, 0xf4, 0x91, 0x48, 0x1d, 0xc5, 0xf3, 0xc5, 0x36,
+	0x3d, 0xca, 0xe9, 0x13, 0x30, 0x73, 0xa1, 0x45, 0x05, 0x92, 0x38, 0x0a, 0x7c, 0xdb, 0x48, 0xa0,
+	0xf8, 0x91, 0x81, 0x3e, 0x13, 0x13, 0x0f, 0x7d, 0xf7, 0x34, 0xbf, 0x1a, 0x9b, 0xd2, 0x1e, 0x40,
+	0x27, 0x77, 0x0b, 0xf1, 0x3c, 0xcc, 0xd8, 0x4a, 0x5f, 0x31, 0x17, 0xdc, 0x03, 0xd8, 0x17, 0x32,
+	0xff, 0xd0, 0xd4, 0x2b, 0xaa, 0x0f, 0xfa, 0xf6, 0xb6, 0x51, 0x8d, 0x3e, 0xb2, 0xd8, 0xc7, 0xc0,
+	0xf6, 0x85, 0x5c, 0x7e, 0x7d, 0x18, 0x3a, 0xbf, 0x59, 0x3e, 0xbb, 0x9a, 0x7f, 0x08, 0x5b, 0xfb,
+	0x42, 0xee, 0xce, 0xd3, 0x54, 0xc4, 0xc6, 0x62, 0x73, 0xdd, 0xb2, 0xcd, 0x3e, 0x86, 0xde, 0x41,
+	0xe2, 0x05, 0x06, 0xc2, 0xca, 0x1c, 0xa4, 0xdc, 0xf2, 0xaa, 0x3e, 0xb4, 0x4f, 0xce, 0x92, 0x8b,
+	0x75, 0x67, 0x31, 0x0e, 0xfd, 0x23, 0xb0, 0xf7, 0x85, 0x2c, 0x7f, 0xab, 0x36, 0xec, 0x9d, 0x1f,
+	0x1b, 0x9f, 0x7c, 0x1f, 0x59, 0x0b, 0x13, 0x21, 0xb9, 0x10, 0x9b, 0x3f, 0x07, 0x97, 0x78, 0xfb,
+	0x00, 0xf8, 0xa4, 0x57, 0xdb, 0x9a, 0x2a, 0x20, 0x6a, 0x9a, 0x93, 0xdd, 0x07, 0x7b, 0x2f, 0xff,
+	0x48, 0x75, 0x79, 0x03, 0xfe, 0x0f, 0xa0, 0xb3, 0x27, 0x22, 0x21, 0xc5, 0x1a, 0xde, 0xb2, 0x13,
+	0x3c, 0x8b, 0x83, 0xe4, 0x40, 0x57, 0xff, 0x95, 0xc6, 0x6b, 0x17, 0xcb, 0x33, 0xf6, 0x53, 0xb0,
+	0x55, 0xe3, 0x6d, 0x58, 0x73, 0xab, 0xbc, 0x48, 0xcd, 0x5f, 0xb1, 0xf9, 0x43, 0xb0, 0x95, 0x56,
+	0xaf, 0xb8, 0x2b, 0x43, 0xbb, 0x4f, 0xe0, 0xd6, 0x40, 0xf5, 0x14, 0xaf, 0x79, 0xbf, 0x9f, 0xc2,
+	0x9b, 0x7b, 0xf9, 0x2b, 0xe6, 0xbf, 0x50, 0xb4, 0xab, 0xde, 0x31, 0x25, 0xf7, 0x28, 0x1e, 0xb1,
+	0xdb, 0x76, 0x41, 0xeb, 0xa7, 0xce, 0x03, 0xe8, 0xd0, 0xab, 0x34, 0x5f, 0xd1, 0x25, 0x0f, 0xc9,
+	0xdf, 0xa9, 0x2b, 0x16, 0x3c, 0x84, 0xae, 0x7a, 0xa3, 0x1d, 0xeb, 0x5f, 0x61, 0x68, 0x8f, 0xe2,
+	0xd9, 0xb8, 0x6d, 0x17, 0xb4, 0x7e, 0xc6, 0x2d, 0x96, 0x94, 0xd4, 0xba, 0x76, 0xc9, 0x87, 0xd0,
+	0xdd, 0x17, 0x12, 0xbf, 0x92, 0xdd, 0x28, 0x68, 0xdf, 0x83, 0x9e, 0x4e, 0xe7, 0x79, 0xda, 0x36,
+	0x02, 0xaf, 0x18, 0xb2, 0xf7, 0xa1, 0x86, 0x0c, 0xea, 0x26, 0xca, 0x75, 0x60, 0xbb, 0x6d, 0x60,
+	0xec, 0x31, 0x0a, 0xc4, 0x1a, 0x2a, 0x52, 0x55, 0x44, 0xd8, 0xed, 0xa2, 0xa0, 0x98, 0xb5, 0xdf,
+	0x94, 0xfe, 0x63, 0xb0, 0x9f, 0xc5, 0xe9, 0x6b, 0x2f, 0xbb, 0x4b, 0xa1, 0x97, 0x17, 0x32, 0x43,
+	0xf1, 0x76, 0xb1, 0x36, 0x63, 0xef, 0xd3, 0x8b, 0x0b, 0x93, 0xe8, 0xf3, 0x50, 0x9b, 0xa3, 0xa8,
+	0xcf, 0x65, 0x71, 0xcd, 0x13, 0x99, 0xcc, 0xae, 0xe7, 0x7a, 0xf4, 0xd7, 0x1a, 0x34, 0xb4, 0x8a,
+	0xf7, 0x5e, 0x95, 0xed, 0x0d, 0xe1, 0xaf, 0x4a, 0xf4, 0x3f, 0x30, 0x13, 0x3d, 0xe9, 0xaf, 0x1f,
+	0x46, 0x26, 0xd3, 0xcd, 0x33, 0xfd, 0x5d, 0x80, 0x67, 0xb3, 0xc0, 0x93, 0xa2, 0x28, 0x1c, 0x38,
+	0x32, 0xa5, 0xf5, 0x2d, 0xf6, 0x2e, 0x00, 0xfe, 0xdc, 0xc2, 0xe9, 0x37, 0x28, 0xa5, 0x17, 0xd2,
+	0xe5, 0x64, 0xd8, 0xcb, 0x7f, 0x24, 0x12, 0xc4, 0xc7, 0x36, 0x69, 0xb2, 0xf8, 0xe1, 0x68, 0x69,
+	0xdb, 0x27, 0x61, 0x1c, 0x66, 0x67, 0xc5, 0x31, 0xb4, 0x1f, 0x9a, 0x5c, 0x3f, 0x2c, 0xa7, 0xe2,
+	0x75, 0x6c, 0xaf, 0x5d, 0xad, 0xde, 0x29, 0x2e, 0x70, 0xb5, 0x17, 0x7f, 0x08, 0x9b, 0xb8, 0xb1,
+	0xd9, 0x62, 0x6c, 0x1a, 0xce, 0x8b, 0x80, 0xc9, 0xfe, 0x98, 0x0a, 0x41, 0x69, 0x3b, 0x53, 0xf0,
+	0x4a, 0x7d, 0x5e, 0x34, 0xe8, 0x87, 0xdc, 0xc7, 0xff, 0x19, 0x00, 0xba, 0xee, 0x5a, 0xdd, 0xd9,
+	0x1d, 0x00, 0x00,
 }